@@ -2,54 +2,513 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"flag"
+	"fmt"
+	"log/slog"
+	"net"
 	"os"
 	"os/signal"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/grpc"
+	"simple-bank/internal/infrastructure/health"
 	"simple-bank/internal/infrastructure/http"
+	"simple-bank/internal/infrastructure/http/auth"
 	handlers "simple-bank/internal/infrastructure/http/handler"
+	handlersV2 "simple-bank/internal/infrastructure/http/handler/v2"
+	"simple-bank/internal/infrastructure/http/ratelimit"
+	"simple-bank/internal/infrastructure/metrics"
+	"simple-bank/internal/infrastructure/outbox"
+	"simple-bank/internal/infrastructure/repository/file"
 	"simple-bank/internal/infrastructure/repository/inmemory"
+	"simple-bank/internal/infrastructure/stream"
+	"simple-bank/internal/infrastructure/telemetry"
+	"simple-bank/internal/infrastructure/webhook"
+	"simple-bank/internal/shared/egress"
+	"simple-bank/internal/shared/logging"
 	usecase "simple-bank/internal/usecase/account"
+	"simple-bank/internal/usecase/apikey"
+	customerUseCase "simple-bank/internal/usecase/customer"
+	productUseCase "simple-bank/internal/usecase/product"
+	webhookUseCase "simple-bank/internal/usecase/webhook"
+	"strings"
 	"syscall"
 	"time"
 )
 
+const (
+	adminAPIKeyEnv = "SIMPLE_BANK_ADMIN_API_KEY"
+	auditKeyEnv    = "SIMPLE_BANK_AUDIT_KEY"
+	jwtSecretEnv   = "SIMPLE_BANK_JWT_HS256_SECRET"
+	profileEnv     = "SIMPLE_BANK_PROFILE"
+	resetTokenEnv  = "SIMPLE_BANK_RESET_TOKEN"
+)
+
+const (
+	profileProduction  = "production"
+	profileStaging     = "staging"
+	profileDevelopment = "development"
+	profileTest        = "test"
+)
+
 func main() {
 	port := flag.String("port", "3000", "server port, default is 3000")
+	grpcPort := flag.String("grpc-port", "", "gRPC server port, the gRPC server is not started when empty")
+	profile := flag.String("profile", envOr(profileEnv, profileDevelopment), "deployment profile: production, staging, development or test, /reset is not served in production")
+	logLevel := flag.String("log-level", "info", "minimum level of the logs: debug, info, warn or error")
+	redactAmounts := flag.Bool("log-redact-amounts", false, "replace amounts with [REDACTED] in the logs")
+	shutdownDelay := flag.Duration("shutdown-delay", 5*time.Second, "how long /readyz reports not ready before connections are drained")
+	traceExporter := flag.String("trace-exporter", telemetry.ExporterNone, "where spans are exported: none or stdout (written to stderr)")
+	auditLogPath := flag.String("audit-log", "", "file the hash-chained audit log is appended to, kept in memory when empty")
+	productsPath := flag.String("products", "config/products.json", "path of the account product catalog")
+	requestTimeout := flag.Duration("request-timeout", 30*time.Second, "maximum duration of a request, 0 disables it")
+	legacyErrors := flag.Bool("legacy-errors", false, "answer unknown accounts with the plain \"0\" body instead of problem+json")
+	strictValidation := flag.Bool("strict-validation", false, "reject request bodies with unknown fields")
+	authEnabled := flag.Bool("auth", true, "require API keys, the admin key is read from "+adminAPIKeyEnv)
+	jwksPath := flag.String("jwks", "", "path of a JWKS file with the keys bearer tokens are verified against")
+	jwtPublicKeyPath := flag.String("jwt-rs256-public-key", "", "path of a PEM RSA public key bearer tokens are verified against")
+	jwtIssuer := flag.String("jwt-issuer", "", "required iss claim of bearer tokens")
+	jwtAudience := flag.String("jwt-audience", "", "required aud claim of bearer tokens")
+	rateLimitKey := flag.String("rate-limit-key", "credential", "what requests are rate limited by: credential, ip or account")
+	readRate := flag.Float64("read-rate", 0, "read requests per second allowed for each key, 0 disables the limit")
+	readBurst := flag.Int("read-burst", 20, "read requests allowed in a burst for each key")
+	writeRate := flag.Float64("write-rate", 0, "write requests per second allowed for each key, 0 disables the limit")
+	writeBurst := flag.Int("write-burst", 10, "write requests allowed in a burst for each key")
+	trustedProxies := flag.String("trusted-proxies", "", "comma separated CIDRs of the proxies whose X-Forwarded-For header is trusted, the header is ignored when empty")
+	outboxInterval := flag.Duration("outbox-interval", time.Second, "how often pending events are relayed from the outbox, which is kept in memory with the accounts and lost on restart")
+	outboxSinkURL := flag.String("outbox-sink-url", "", "URL every event of the outbox is also posted to, off when empty")
+	outboxSinkFile := flag.String("outbox-sink-file", "", "file every event of the outbox is also appended to as JSON lines, off when empty")
+	webhookInterval := flag.Duration("webhook-interval", time.Second, "how often due webhook deliveries are sent")
+	webhookMaxAttempts := flag.Int("webhook-max-attempts", webhook.DefaultRetryPolicy.MaxAttempts, "attempts made before a webhook delivery is dead lettered")
+	streamBuffer := flag.Int("stream-buffer", stream.DefaultBufferSize, "balance changes kept for streams resuming with Last-Event-ID")
+	wsMaxInFlight := flag.Int("ws-max-in-flight", handlers.DefaultWebSocketConfig.MaxInFlight, "events a websocket connection may run at once")
+	wsMaxSubscriptions := flag.Int("ws-max-subscriptions", handlers.DefaultWebSocketConfig.MaxSubscriptions, "accounts a websocket connection may subscribe to")
+	webhookRetryDelay := flag.Duration("webhook-retry-delay", webhook.DefaultRetryPolicy.BaseDelay, "delay before the first webhook retry, doubled on each attempt")
+	webhookRetention := flag.Duration("webhook-retention", webhook.DefaultRetention, "how long webhook deliveries are kept once delivered or dead")
+	webhookAllowPrivate := flag.Bool("webhook-allow-private", false, "let webhooks target addresses that are not globally reachable, such as loopback and private ones, for local development")
 	flag.Parse()
 
-	accountRepository := inmemory.NewAccountRepository()
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		panic(err)
+	}
+	slog.SetDefault(slog.New(logging.NewHandler(os.Stdout, logging.Options{
+		Level:         level,
+		RedactAmounts: *redactAmounts,
+	})))
+
+	if err := validateProfile(*profile); err != nil {
+		panic(err)
+	}
+
+	shutdownTracing, err := telemetry.Setup(*traceExporter, os.Stderr)
+	if err != nil {
+		panic(err)
+	}
+
+	rateLimitKeyFunc, err := rateLimitKeyFunc(*rateLimitKey)
+	if err != nil {
+		panic(err)
+	}
+
+	proxies, err := parseCIDRs(*trustedProxies)
+	if err != nil {
+		panic(err)
+	}
+
+	auditLogger, err := newAuditStore(*auditLogPath, []byte(os.Getenv(auditKeyEnv)))
+	if err != nil {
+		panic(err)
+	}
+
+	appMetrics := metrics.New()
+
+	accountStore := inmemory.NewAccountRepository()
+	appMetrics.WatchAccounts(accountStore)
+	productStore, err := file.NewProductRepository(*productsPath)
+	if err != nil {
+		panic(err)
+	}
+
+	accountRepository := metrics.NewAccountRepository(telemetry.NewAccountRepository(accountStore), appMetrics)
+	customerStore := inmemory.NewCustomerRepository()
+	customerRepository := metrics.NewCustomerRepository(customerStore, appMetrics)
+	productRepository := metrics.NewProductRepository(productStore, appMetrics)
+	apiKeyRepository := inmemory.NewAPIKeyRepository()
+	webhookRepository := inmemory.NewWebhookRepository()
+
+	webhookTargets := egress.Policy{AllowPrivate: *webhookAllowPrivate}
+	dispatcher := webhook.NewDispatcher(webhookRepository, webhook.Config{
+		Targets: webhookTargets,
+		Retry: webhook.RetryPolicy{
+			MaxAttempts: *webhookMaxAttempts,
+			BaseDelay:   *webhookRetryDelay,
+			MaxDelay:    webhook.DefaultRetryPolicy.MaxDelay,
+		},
+		Retention: *webhookRetention,
+	})
+	relay, err := newRelay(accountStore, dispatcher, *outboxSinkURL, *outboxSinkFile)
+	if err != nil {
+		panic(err)
+	}
+
+	bus := event.NewBus()
+	broker := stream.NewBroker(*streamBuffer)
+	bus.Subscribe("stream", event.Sync, broker.Handle)
+	bus.Subscribe("metrics", event.Async, appMetrics.HandleEvent)
 
 	getBalanceUseCase := usecase.NewGetBalanceUseCase(accountRepository)
+	getAccountUseCase := usecase.NewGetAccountUseCase(accountRepository)
 	resetUseCase := usecase.NewResetUseCase(accountRepository)
-	depositUseCase := usecase.NewDepositUseCase(accountRepository)
-	withdrawUseCase := usecase.NewWithdrawUseCase(accountRepository)
-	transferUseCase := usecase.NewTransferUseCase(accountRepository)
+	depositUseCase := usecase.NewDepositUseCase(accountRepository, productRepository).
+		WithRecorder(appMetrics).
+		WithPublisher(bus)
+	withdrawUseCase := usecase.NewWithdrawUseCase(accountRepository, productRepository).
+		WithRecorder(appMetrics).
+		WithPublisher(bus)
+	transferUseCase := usecase.NewTransferUseCase(accountRepository, customerRepository, productRepository).
+		WithRecorder(appMetrics).
+		WithPublisher(bus)
+	listAccountsUseCase := usecase.NewListAccountsUseCase(accountRepository)
+
+	createCustomerUseCase := customerUseCase.NewCreateCustomerUseCase(customerRepository)
+	getCustomerUseCase := customerUseCase.NewGetCustomerUseCase(customerRepository)
+	updateCustomerUseCase := customerUseCase.NewUpdateCustomerUseCase(customerRepository)
+	deleteCustomerUseCase := customerUseCase.NewDeleteCustomerUseCase(customerRepository)
+	listCustomerAccountsUseCase := customerUseCase.NewListCustomerAccountsUseCase(customerRepository, accountRepository)
+	linkAccountUseCase := customerUseCase.NewLinkAccountUseCase(customerRepository, accountRepository)
+	unlinkAccountUseCase := customerUseCase.NewUnlinkAccountUseCase(customerRepository)
+	setKYCStatusUseCase := customerUseCase.NewSetKYCStatusUseCase(customerRepository)
+
+	listProductsUseCase := productUseCase.NewListProductsUseCase(productRepository)
+
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(apiKeyRepository)
+	rotateAPIKeyUseCase := apikey.NewRotateAPIKeyUseCase(apiKeyRepository)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(apiKeyRepository)
+	authenticateAPIKeyUseCase := apikey.NewAuthenticateAPIKeyUseCase(apiKeyRepository)
+
+	createWebhookUseCase := webhookUseCase.NewCreateWebhookUseCase(webhookRepository).WithTargetPolicy(webhookTargets)
+	listWebhooksUseCase := webhookUseCase.NewListWebhooksUseCase(webhookRepository)
+	deleteWebhookUseCase := webhookUseCase.NewDeleteWebhookUseCase(webhookRepository)
+	listDeliveriesUseCase := webhookUseCase.NewListDeliveriesUseCase(webhookRepository)
+	redeliverUseCase := webhookUseCase.NewRedeliverUseCase(webhookRepository)
+
+	var authenticators []auth.Authenticator
+	if *authEnabled {
+		if err := bootstrapAdminAPIKey(apiKeyRepository, os.Getenv(adminAPIKeyEnv)); err != nil {
+			panic(err)
+		}
+		authenticators = append(authenticators, auth.NewAPIKeyAuthenticator(authenticateAPIKeyUseCase))
+
+		jwtKeys, err := loadJWTKeys(*jwksPath, *jwtPublicKeyPath, os.Getenv(jwtSecretEnv))
+		if err != nil {
+			panic(err)
+		}
+		if !jwtKeys.IsEmpty() {
+			authenticators = append(authenticators, auth.NewJWTAuthenticator(jwtKeys, auth.JWTOptions{
+				Issuer:        *jwtIssuer,
+				Audience:      *jwtAudience,
+				DefaultScopes: []entity.Scope{entity.ScopeBalanceRead, entity.ScopeEventWrite},
+			}))
+		}
+	}
 
 	balanceHandler := handlers.NewBalanceHandler(getBalanceUseCase)
-	resetHandler := handlers.NewResetHandler(resetUseCase)
+	accountHandler := handlers.NewAccountHandler(listAccountsUseCase)
+	productHandler := handlers.NewProductHandler(listProductsUseCase)
+	customerHandler := handlers.NewCustomerHandler(
+		createCustomerUseCase,
+		getCustomerUseCase,
+		updateCustomerUseCase,
+		deleteCustomerUseCase,
+		listCustomerAccountsUseCase,
+		linkAccountUseCase,
+		unlinkAccountUseCase,
+		setKYCStatusUseCase,
+	)
 	eventHandler := handlers.NewEventHandler(
 		depositUseCase,
 		withdrawUseCase,
 		transferUseCase,
-	)
+	).WithAudit(auditLogger)
+	accountV2Handler := handlersV2.NewAccountHandler(getAccountUseCase, depositUseCase, withdrawUseCase).
+		WithAudit(auditLogger)
+	transferV2Handler := handlersV2.NewTransferHandler(transferUseCase).WithAudit(auditLogger)
+	docsHandler := handlers.NewDocsHandler()
+	apiKeyHandler := handlers.NewAPIKeyHandler(createAPIKeyUseCase, rotateAPIKeyUseCase, revokeAPIKeyUseCase).
+		WithAudit(auditLogger)
+	webhookHandler := handlers.NewWebhookHandler(
+		createWebhookUseCase,
+		listWebhooksUseCase,
+		deleteWebhookUseCase,
+		listDeliveriesUseCase,
+		redeliverUseCase,
+	).WithAudit(auditLogger)
+	streamHandler := handlers.NewStreamHandler(broker)
+	// websocket events and gRPC calls take from the same buckets as the
+	// requests.
+	rateLimit := &ratelimit.Config{
+		Store: ratelimit.NewMemoryStore(),
+		Key:   rateLimitKeyFunc,
+		Read:  ratelimit.Limit{Rate: *readRate, Burst: *readBurst},
+		Write: ratelimit.Limit{Rate: *writeRate, Burst: *writeBurst},
+	}
+	webSocketHandler := handlers.NewWebSocketHandler(eventHandler, broker, handlers.WebSocketConfig{
+		MaxInFlight:      *wsMaxInFlight,
+		MaxSubscriptions: *wsMaxSubscriptions,
+		StrictValidation: *strictValidation,
+		RateLimit:        rateLimit,
+	})
 
-	httpServer := http.NewHTTPServer(
-		*port,
+	httpHandlers := []http.HTTPHandler{
 		balanceHandler,
-		resetHandler,
 		eventHandler,
+		accountHandler,
+		customerHandler,
+		productHandler,
+		accountV2Handler,
+		transferV2Handler,
+		docsHandler,
+		apiKeyHandler,
+		webhookHandler,
+		streamHandler,
+		webSocketHandler,
+	}
+	accountService := grpc.NewAccountService(
+		getBalanceUseCase,
+		depositUseCase,
+		withdrawUseCase,
+		transferUseCase,
+		broker,
+	).WithAudit(auditLogger)
+	if *profile != profileProduction {
+		resetToken := os.Getenv(resetTokenEnv)
+		if resetToken == "" {
+			slog.Warn(resetTokenEnv + " is not set, every /reset request will be refused")
+		}
+		httpHandlers = append(httpHandlers, handlers.NewResetHandler(resetUseCase, handlers.ResetConfig{
+			ConfirmationToken: resetToken,
+			Audit:             auditLogger,
+		}))
+		accountService.WithReset(resetUseCase, resetToken)
+	}
+
+	httpServer := http.NewHTTPServer(
+		http.HTTPServerConfig{
+			Port:             *port,
+			RequestTimeout:   *requestTimeout,
+			LegacyErrors:     *legacyErrors,
+			ErrorRecorder:    appMetrics,
+			StrictValidation: *strictValidation,
+			Authenticators:   authenticators,
+			RateLimit:        rateLimit,
+			TrustedProxies:   proxies,
+			Metrics:          appMetrics,
+			Readiness: health.NewReadiness(2*time.Second,
+				health.RepositoryCheck("account_repository", accountStore),
+				health.RepositoryCheck("customer_repository", customerStore),
+			),
+			ShutdownDelay: *shutdownDelay,
+		},
+		httpHandlers...,
 	)
 
+	// streams and websockets never end on their own, they are closed when the
+	// server starts draining connections.
+	httpServer.Engine.Server.RegisterOnShutdown(broker.Close)
+	httpServer.Engine.Server.RegisterOnShutdown(webSocketHandler.Close)
+
+	workers, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go relay.Run(workers, *outboxInterval)
+	go dispatcher.Run(workers, *webhookInterval)
+
 	go httpServer.Start()
 
-	stop := make(chan os.Signal)
+	var grpcServer *grpc.GRPCServer
+	if *grpcPort != "" {
+		grpcServer = grpc.NewGRPCServer(grpc.GRPCServerConfig{
+			Port:           *grpcPort,
+			Authenticators: authenticators,
+			RateLimit:      rateLimit,
+		}, accountService)
+		go func() {
+			if err := grpcServer.Start(); err != nil {
+				panic(err)
+			}
+		}()
+	}
+
+	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	<-stop
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
 	defer cancel()
 
+	// both servers drain at once, so the gRPC calls are not held back by the
+	// shutdown delay of the HTTP server.
+	grpcStopped := make(chan error, 1)
+	go func() {
+		if grpcServer == nil {
+			grpcStopped <- nil
+			return
+		}
+		grpcStopped <- grpcServer.Stop(ctx)
+	}()
 	if err := httpServer.Stop(ctx); err != nil {
 		panic(err)
 	}
+	if err := <-grpcStopped; err != nil {
+		panic(err)
+	}
+	// the operations are over, the asynchronous subscribers handle the events
+	// left in their queues.
+	bus.Close()
+	stopWorkers()
+	logAuditHead(auditLogger, "audit log closed")
+	if err := shutdownTracing(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// bootstrapAdminAPIKey registers the key given through the environment with
+// every scope, so the first keys can be created through the admin endpoints.
+func bootstrapAdminAPIKey(repo *inmemory.APIKeyRepository, secret string) error {
+	if secret == "" {
+		slog.Warn("authentication is enabled but " + adminAPIKeyEnv + " is not set, no request will be authorized")
+		return nil
+	}
+
+	key := entity.NewAPIKey("bootstrap", "bootstrap admin", apikey.HashSecret(secret), entity.Scopes, nil, time.Now().UTC())
+	return repo.SaveAPIKey(context.Background(), key)
+}
+
+// loadJWTKeys gathers the bearer token keys from the JWKS file, the RSA public
+// key and the HS256 secret, any of which may be empty.
+func loadJWTKeys(jwksPath, publicKeyPath, secret string) (*auth.JWTKeySet, error) {
+	keys := auth.NewJWTKeySet()
+	if jwksPath != "" {
+		var err error
+		if keys, err = auth.LoadJWKS(jwksPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if publicKeyPath != "" {
+		data, err := os.ReadFile(publicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := keys.AddRSAPEM("", data); err != nil {
+			return nil, err
+		}
+	}
+
+	if secret != "" {
+		keys.AddHMAC("", []byte(secret))
+	}
+	return keys, nil
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// validateProfile refuses unknown profiles, so a typo cannot expose routes
+// meant to stay off in production.
+func validateProfile(profile string) error {
+	switch profile {
+	case profileProduction, profileStaging, profileDevelopment, profileTest:
+		return nil
+	}
+	return fmt.Errorf("unknown profile %q", profile)
+}
+
+func rateLimitKeyFunc(key string) (ratelimit.KeyFunc, error) {
+	switch key {
+	case "credential":
+		return ratelimit.KeyByCredential, nil
+	case "ip":
+		return ratelimit.KeyByIP, nil
+	case "account":
+		return ratelimit.KeyByAccount, nil
+	}
+	return nil, fmt.Errorf("unknown rate limit key %q", key)
+}
+
+// parseCIDRs reads a comma separated list of CIDRs, which may be empty.
+func parseCIDRs(list string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// newRelay delivers the outbox to the webhooks and to the sinks configured
+// with a URL or a file. Sink names are the cursors kept in the outbox.
+func newRelay(outboxRepository repository.OutboxRepository, dispatcher *webhook.Dispatcher, url, path string) (*outbox.Relay, error) {
+	relay := outbox.NewRelay(outboxRepository).WithSink("webhooks", dispatcher)
+	if url != "" {
+		relay.WithSink("http", outbox.NewHTTPSink(url, nil))
+	}
+	if path != "" {
+		sink, err := outbox.NewFileSink(path)
+		if err != nil {
+			return nil, err
+		}
+		relay.WithSink("file", sink)
+	}
+	return relay, nil
+}
+
+// newAuditStore keys the chain of the audit log with key. A log kept in
+// memory cannot be verified after the process ends, it gets a random key
+// when none is set.
+func newAuditStore(path string, key []byte) (audit.Store, error) {
+	if path == "" {
+		slog.Warn("the audit log is kept in memory, set -audit-log to persist it")
+		if len(key) == 0 {
+			key = make([]byte, 32)
+			if _, err := rand.Read(key); err != nil {
+				return nil, err
+			}
+		}
+		return audit.NewMemoryStore(key), nil
+	}
+
+	if len(key) == 0 {
+		return nil, fmt.Errorf("%w: set %s", audit.ErrMissingKey, auditKeyEnv)
+	}
+	store, err := audit.NewFileStore(path, key)
+	if err != nil {
+		return nil, err
+	}
+	logAuditHead(store, "audit log opened")
+	return store, nil
+}
+
+// logAuditHead publishes the head of the audit log, so a log cut short can
+// be caught with verify_audit -head even when its head file is rolled back.
+func logAuditHead(store audit.Store, msg string) {
+	head, err := store.Head(context.Background())
+	if err != nil {
+		slog.Error("fail to read the audit log head", logging.KeyError, err.Error())
+		return
+	}
+	slog.Info(msg, slog.Uint64("audit_head_sequence", head.Sequence), slog.String("audit_head_hash", head.Hash))
 }
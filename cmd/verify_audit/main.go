@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"simple-bank/internal/infrastructure/audit"
+	"strconv"
+	"strings"
+)
+
+const auditKeyEnv = "SIMPLE_BANK_AUDIT_KEY"
+
+func main() {
+	auditLogPath := flag.String("audit-log", "audit.log", "path of the audit log to verify")
+	headFlag := flag.String("head", "", "published head the log must reach, as <sequence>:<hash>, read from the head file next to the log when empty")
+	flag.Parse()
+
+	key := []byte(os.Getenv(auditKeyEnv))
+	if len(key) == 0 {
+		fmt.Fprintln(os.Stderr, auditKeyEnv+" must hold the key the audit log was written with")
+		os.Exit(2)
+	}
+
+	entries, err := audit.ReadFile(*auditLogPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	head, err := readHead(*headFlag, *auditLogPath, key)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := audit.Verify(key, entries, head); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%d entries verified up to head %d\n", len(entries), head.Sequence)
+}
+
+func readHead(value, path string, key []byte) (audit.Head, error) {
+	if value == "" {
+		return audit.ReadHeadFile(audit.HeadPath(path), key)
+	}
+
+	sequence, hash, ok := strings.Cut(value, ":")
+	if !ok {
+		return audit.Head{}, fmt.Errorf("invalid head %q", value)
+	}
+	n, err := strconv.ParseUint(sequence, 10, 64)
+	if err != nil {
+		return audit.Head{}, fmt.Errorf("invalid head %q: %w", value, err)
+	}
+	return audit.Head{Sequence: n, Hash: hash}, nil
+}
@@ -2,17 +2,33 @@ package entity
 
 import (
 	domainErrs "simple-bank/internal/domain/errors"
+	"time"
 )
 
+type AccountStatus string
+
+const (
+	AccountStatusActive AccountStatus = "active"
+	AccountStatusFrozen AccountStatus = "frozen"
+	AccountStatusClosed AccountStatus = "closed"
+)
+
+const withdrawalPeriodLayout = "2006-01"
+
 type Account struct {
-	ID      string
-	Balance int
+	ID                 string
+	Balance            int
+	Status             AccountStatus
+	ProductID          string
+	WithdrawalPeriod   string
+	MonthlyWithdrawals int
 }
 
 func NewAccount(id string, balance int) *Account {
 	return &Account{
 		ID:      id,
 		Balance: balance,
+		Status:  AccountStatusActive,
 	}
 }
 
@@ -22,9 +38,67 @@ func (a *Account) Deposit(amount int) {
 
 func (a *Account) Withdraw(amount int) error {
 	if a.Balance < amount {
-		return domainErrs.ErrAccountInsufficientBalance
+		return &domainErrs.InsufficientBalanceError{Balance: a.Balance, Available: a.Balance}
 	}
 
 	a.Balance -= amount
 	return nil
 }
+
+// Debit withdraws amount plus fee following the rules of the account product:
+// the balance may go negative up to the product overdraft limit and the number
+// of debits per calendar month is capped by the product withdrawal limit.
+func (a *Account) Debit(product *Product, amount int, fee int, at time.Time) error {
+	if err := a.checkMovement(amount); err != nil {
+		return err
+	}
+
+	period := at.UTC().Format(withdrawalPeriodLayout)
+	withdrawals := a.MonthlyWithdrawals
+	if a.WithdrawalPeriod != period {
+		withdrawals = 0
+	}
+
+	if product.MonthlyWithdrawalLimit > 0 && withdrawals >= product.MonthlyWithdrawalLimit {
+		return domainErrs.ErrAccountWithdrawalLimitExceeded
+	}
+
+	if a.Balance-amount-fee < -product.OverdraftLimit {
+		return &domainErrs.InsufficientBalanceError{
+			Balance:   a.Balance,
+			Available: a.Balance + product.OverdraftLimit,
+		}
+	}
+
+	a.Balance -= amount + fee
+	a.WithdrawalPeriod = period
+	a.MonthlyWithdrawals = withdrawals + 1
+	return nil
+}
+
+// Credit deposits amount minus fee, refusing amounts that do not cover the
+// fee as they would debit the account.
+func (a *Account) Credit(amount int, fee int) error {
+	if err := a.checkMovement(amount); err != nil {
+		return err
+	}
+	if fee > amount {
+		return domainErrs.ErrAmountBelowFee
+	}
+
+	a.Balance += amount - fee
+	return nil
+}
+
+func (a *Account) checkMovement(amount int) error {
+	switch {
+	case amount <= 0:
+		return domainErrs.ErrInvalidAmount
+	case a.Status == AccountStatusFrozen:
+		return domainErrs.ErrAccountFrozen
+	case a.Status == AccountStatusClosed:
+		return domainErrs.ErrAccountClosed
+	}
+
+	return nil
+}
@@ -1,7 +1,9 @@
 package entity
 
 import (
+	domainErrs "simple-bank/internal/domain/errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,6 +14,7 @@ func TestNewAccount(t *testing.T) {
 
 		assert.Equal(t, "ID", account.ID)
 		assert.Equal(t, 100, account.Balance)
+		assert.Equal(t, AccountStatusActive, account.Status)
 	})
 }
 
@@ -38,5 +41,111 @@ func TestAccount_Withdraw(t *testing.T) {
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "Account as insufficient balance", err.Error())
+		assert.ErrorIs(t, err, domainErrs.ErrAccountInsufficientBalance)
+	})
+}
+
+func TestAccount_Debit(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should debit amount and fee from account", func(t *testing.T) {
+		account := NewAccount("ID", 100)
+		product := NewProduct("checking", "Checking")
+
+		err := account.Debit(product, 50, 2, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 48, account.Balance)
+		assert.Equal(t, "2024-03", account.WithdrawalPeriod)
+		assert.Equal(t, 1, account.MonthlyWithdrawals)
+	})
+
+	t.Run("Should allow overdraft up to the product limit", func(t *testing.T) {
+		account := NewAccount("ID", 100)
+		product := NewProduct("business", "Business")
+		product.OverdraftLimit = 50
+
+		assert.NoError(t, account.Debit(product, 150, 0, now))
+		assert.Equal(t, -50, account.Balance)
+
+		err := account.Debit(product, 1, 0, now)
+		assert.ErrorIs(t, err, domainErrs.ErrAccountInsufficientBalance)
+		assert.Equal(t, -50, account.Balance)
+
+		var insufficientErr *domainErrs.InsufficientBalanceError
+		assert.ErrorAs(t, err, &insufficientErr)
+		assert.Equal(t, -50, insufficientErr.Balance)
+		assert.Equal(t, 0, insufficientErr.Available)
+	})
+
+	t.Run("Should refuse invalid amounts", func(t *testing.T) {
+		account := NewAccount("ID", 100)
+		product := NewProduct("checking", "Checking")
+
+		assert.ErrorIs(t, account.Debit(product, 0, 0, now), domainErrs.ErrInvalidAmount)
+		assert.ErrorIs(t, account.Debit(product, -10, 0, now), domainErrs.ErrInvalidAmount)
+		assert.Equal(t, 100, account.Balance)
+	})
+
+	t.Run("Should refuse debits from frozen and closed accounts", func(t *testing.T) {
+		product := NewProduct("checking", "Checking")
+		frozen := NewAccount("ID", 100)
+		frozen.Status = AccountStatusFrozen
+		closed := NewAccount("ID", 100)
+		closed.Status = AccountStatusClosed
+
+		assert.ErrorIs(t, frozen.Debit(product, 10, 0, now), domainErrs.ErrAccountFrozen)
+		assert.ErrorIs(t, closed.Debit(product, 10, 0, now), domainErrs.ErrAccountClosed)
+		assert.Equal(t, 100, frozen.Balance)
+	})
+
+	t.Run("Should enforce the monthly withdrawal limit", func(t *testing.T) {
+		account := NewAccount("ID", 100)
+		product := NewProduct("savings", "Savings")
+		product.MonthlyWithdrawalLimit = 1
+
+		assert.NoError(t, account.Debit(product, 10, 0, now))
+
+		err := account.Debit(product, 10, 0, now)
+		assert.ErrorIs(t, err, domainErrs.ErrAccountWithdrawalLimitExceeded)
+		assert.Equal(t, 90, account.Balance)
+	})
+
+	t.Run("Should reset the withdrawal count on a new month", func(t *testing.T) {
+		account := NewAccount("ID", 100)
+		product := NewProduct("savings", "Savings")
+		product.MonthlyWithdrawalLimit = 1
+
+		assert.NoError(t, account.Debit(product, 10, 0, now))
+		assert.NoError(t, account.Debit(product, 10, 0, now.AddDate(0, 1, 0)))
+		assert.Equal(t, "2024-04", account.WithdrawalPeriod)
+		assert.Equal(t, 1, account.MonthlyWithdrawals)
+	})
+}
+
+func TestAccount_Credit(t *testing.T) {
+	t.Run("Should credit amount minus fee", func(t *testing.T) {
+		account := NewAccount("ID", 100)
+		err := account.Credit(50, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 149, account.Balance)
+	})
+
+	t.Run("Should refuse amounts below the fee", func(t *testing.T) {
+		account := NewAccount("ID", 100)
+
+		assert.ErrorIs(t, account.Credit(1, 2), domainErrs.ErrAmountBelowFee)
+		assert.Equal(t, 100, account.Balance)
+	})
+
+	t.Run("Should refuse credits to frozen accounts and invalid amounts", func(t *testing.T) {
+		account := NewAccount("ID", 100)
+
+		assert.ErrorIs(t, account.Credit(-1, 0), domainErrs.ErrInvalidAmount)
+
+		account.Status = AccountStatusFrozen
+		assert.ErrorIs(t, account.Credit(50, 0), domainErrs.ErrAccountFrozen)
+		assert.Equal(t, 100, account.Balance)
 	})
 }
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+type Scope string
+
+const (
+	ScopeBalanceRead   Scope = "balance:read"
+	ScopeEventWrite    Scope = "event:write"
+	ScopeCustomerRead  Scope = "customer:read"
+	ScopeCustomerWrite Scope = "customer:write"
+	ScopeAdminReset    Scope = "admin:reset"
+	ScopeAdminKeys     Scope = "admin:keys"
+	ScopeAdminWebhooks Scope = "admin:webhooks"
+	ScopeAdminStream   Scope = "admin:stream"
+	ScopeAdminKYC      Scope = "admin:kyc"
+)
+
+var Scopes = []Scope{
+	ScopeBalanceRead,
+	ScopeEventWrite,
+	ScopeCustomerRead,
+	ScopeCustomerWrite,
+	ScopeAdminReset,
+	ScopeAdminKeys,
+	ScopeAdminWebhooks,
+	ScopeAdminStream,
+	ScopeAdminKYC,
+}
+
+func (s Scope) IsValid() bool {
+	return slices.Contains(Scopes, s)
+}
+
+// APIKey only keeps the hash of the secret handed to the client. AccountIDs
+// restricts the key to those accounts, an empty list allows every account.
+// CustomerID, when set, makes the key act on behalf of that customer, who
+// must own the accounts money is transferred from.
+type APIKey struct {
+	ID         string
+	Name       string
+	Hash       string
+	Scopes     []Scope
+	AccountIDs []string
+	CustomerID string
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+func NewAPIKey(id, name, hash string, scopes []Scope, accountIDs []string, createdAt time.Time) *APIKey {
+	return &APIKey{
+		ID:         id,
+		Name:       name,
+		Hash:       hash,
+		Scopes:     slices.Clone(scopes),
+		AccountIDs: slices.Clone(accountIDs),
+		CreatedAt:  createdAt,
+	}
+}
+
+func (k *APIKey) HasScope(scope Scope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+func (k *APIKey) CanAccessAccount(accountID string) bool {
+	return len(k.AccountIDs) == 0 || slices.Contains(k.AccountIDs, accountID)
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) Revoke(at time.Time) {
+	if k.RevokedAt == nil {
+		k.RevokedAt = &at
+	}
+}
+
+func (k *APIKey) Rotate(hash string) {
+	k.Hash = hash
+}
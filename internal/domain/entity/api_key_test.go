@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	t.Run("Should create active key", func(t *testing.T) {
+		now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+		key := NewAPIKey("K1", "backoffice", "hash", []Scope{ScopeBalanceRead}, nil, now)
+
+		assert.Equal(t, "K1", key.ID)
+		assert.Equal(t, now, key.CreatedAt)
+		assert.False(t, key.IsRevoked())
+	})
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	t.Run("Should only grant the given scopes", func(t *testing.T) {
+		key := NewAPIKey("K1", "", "hash", []Scope{ScopeBalanceRead}, nil, time.Now())
+
+		assert.True(t, key.HasScope(ScopeBalanceRead))
+		assert.False(t, key.HasScope(ScopeEventWrite))
+	})
+}
+
+func TestAPIKey_CanAccessAccount(t *testing.T) {
+	t.Run("Should access every account when not restricted", func(t *testing.T) {
+		key := NewAPIKey("K1", "", "hash", nil, nil, time.Now())
+
+		assert.True(t, key.CanAccessAccount("100"))
+	})
+
+	t.Run("Should only access listed accounts when restricted", func(t *testing.T) {
+		key := NewAPIKey("K1", "", "hash", nil, []string{"100"}, time.Now())
+
+		assert.True(t, key.CanAccessAccount("100"))
+		assert.False(t, key.CanAccessAccount("200"))
+	})
+}
+
+func TestAPIKey_Revoke(t *testing.T) {
+	t.Run("Should keep the first revocation time", func(t *testing.T) {
+		first := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+		key := NewAPIKey("K1", "", "hash", nil, nil, first)
+
+		key.Revoke(first)
+		key.Revoke(first.Add(time.Hour))
+
+		assert.True(t, key.IsRevoked())
+		assert.Equal(t, first, *key.RevokedAt)
+	})
+}
+
+func TestScope_IsValid(t *testing.T) {
+	t.Run("Should accept known scopes only", func(t *testing.T) {
+		assert.True(t, ScopeAdminReset.IsValid())
+		assert.False(t, Scope("admin:*").IsValid())
+	})
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "slices"
+
+type KYCStatus string
+
+const (
+	KYCStatusPending  KYCStatus = "pending"
+	KYCStatusVerified KYCStatus = "verified"
+	KYCStatusRejected KYCStatus = "rejected"
+)
+
+type Customer struct {
+	ID         string
+	Name       string
+	Email      string
+	Phone      string
+	KYCStatus  KYCStatus
+	AccountIDs []string
+}
+
+func NewCustomer(id, name, email, phone string) *Customer {
+	return &Customer{
+		ID:         id,
+		Name:       name,
+		Email:      email,
+		Phone:      phone,
+		KYCStatus:  KYCStatusPending,
+		AccountIDs: []string{},
+	}
+}
+
+func (c *Customer) LinkAccount(accountID string) {
+	if !c.OwnsAccount(accountID) {
+		c.AccountIDs = append(c.AccountIDs, accountID)
+	}
+}
+
+func (c *Customer) UnlinkAccount(accountID string) {
+	c.AccountIDs = slices.DeleteFunc(c.AccountIDs, func(id string) bool { return id == accountID })
+}
+
+func (c *Customer) OwnsAccount(accountID string) bool {
+	return slices.Contains(c.AccountIDs, accountID)
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCustomer(t *testing.T) {
+	t.Run("Should create new customer pending KYC", func(t *testing.T) {
+		customer := NewCustomer("ID", "John", "john@example.com", "+5511999999999")
+
+		assert.Equal(t, "ID", customer.ID)
+		assert.Equal(t, "John", customer.Name)
+		assert.Equal(t, "john@example.com", customer.Email)
+		assert.Equal(t, "+5511999999999", customer.Phone)
+		assert.Equal(t, KYCStatusPending, customer.KYCStatus)
+		assert.Empty(t, customer.AccountIDs)
+	})
+}
+
+func TestCustomer_LinkAccount(t *testing.T) {
+	t.Run("Should link account once", func(t *testing.T) {
+		customer := NewCustomer("ID", "John", "", "")
+		customer.LinkAccount("100")
+		customer.LinkAccount("100")
+
+		assert.Equal(t, []string{"100"}, customer.AccountIDs)
+		assert.True(t, customer.OwnsAccount("100"))
+	})
+}
+
+func TestCustomer_UnlinkAccount(t *testing.T) {
+	t.Run("Should unlink account", func(t *testing.T) {
+		customer := NewCustomer("ID", "John", "", "")
+		customer.LinkAccount("100")
+		customer.LinkAccount("200")
+		customer.UnlinkAccount("100")
+
+		assert.Equal(t, []string{"200"}, customer.AccountIDs)
+		assert.False(t, customer.OwnsAccount("100"))
+	})
+}
@@ -0,0 +1,22 @@
+package entity
+
+type FeeSchedule struct {
+	Deposit    int
+	Withdrawal int
+	Transfer   int
+}
+
+type Product struct {
+	ID                     string
+	Name                   string
+	OverdraftLimit         int
+	MonthlyWithdrawalLimit int
+	Fees                   FeeSchedule
+}
+
+func NewProduct(id, name string) *Product {
+	return &Product{
+		ID:   id,
+		Name: name,
+	}
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"simple-bank/internal/domain/event"
+	"slices"
+	"time"
+)
+
+// Webhook subscribes URL to the events of EventTypes. Deliveries are signed
+// with Secret, so the receiver can tell they come from the bank.
+type Webhook struct {
+	ID         string
+	URL        string
+	EventTypes []event.Type
+	Secret     string
+	CreatedAt  time.Time
+}
+
+func NewWebhook(id, url string, eventTypes []event.Type, secret string, createdAt time.Time) *Webhook {
+	return &Webhook{
+		ID:         id,
+		URL:        url,
+		EventTypes: slices.Clone(eventTypes),
+		Secret:     secret,
+		CreatedAt:  createdAt,
+	}
+}
+
+func (w *Webhook) Accepts(eventType event.Type) bool {
+	return slices.Contains(w.EventTypes, eventType)
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusDead      DeliveryStatus = "dead"
+)
+
+func (s DeliveryStatus) IsValid() bool {
+	return s == DeliveryStatusPending || s == DeliveryStatusDelivered || s == DeliveryStatusDead
+}
+
+// WebhookDelivery is one event to send to one webhook. It keeps the payload,
+// so it can be redelivered after the event left the outbox.
+type WebhookDelivery struct {
+	ID            string
+	WebhookID     string
+	EventID       string
+	EventType     event.Type
+	Payload       []byte
+	Status        DeliveryStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+	// FinishedAt is when the delivery was delivered or moved to the dead
+	// letters, nil while it is pending.
+	FinishedAt *time.Time
+}
+
+func NewWebhookDelivery(id string, webhook *Webhook, e event.Event, payload []byte, createdAt time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhook.ID,
+		EventID:       e.ID,
+		EventType:     e.Type,
+		Payload:       slices.Clone(payload),
+		Status:        DeliveryStatusPending,
+		NextAttemptAt: createdAt,
+		CreatedAt:     createdAt,
+	}
+}
+
+func (d *WebhookDelivery) Succeed(at time.Time) {
+	d.Attempts++
+	d.Status = DeliveryStatusDelivered
+	d.LastError = ""
+	d.DeliveredAt = &at
+	d.FinishedAt = &at
+}
+
+// Fail schedules the next attempt at retryAt, or moves the delivery to the
+// dead letters at at when retryAt is nil.
+func (d *WebhookDelivery) Fail(reason string, at time.Time, retryAt *time.Time) {
+	d.Attempts++
+	d.LastError = reason
+	if retryAt == nil {
+		d.Status = DeliveryStatusDead
+		d.FinishedAt = &at
+		return
+	}
+	d.NextAttemptAt = *retryAt
+}
+
+// Redeliver queues the delivery again with a fresh retry budget.
+func (d *WebhookDelivery) Redeliver(at time.Time) {
+	d.Status = DeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+	d.DeliveredAt = nil
+	d.FinishedAt = nil
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"simple-bank/internal/domain/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	t.Run("Should only accept subscribed event types", func(t *testing.T) {
+		webhook := NewWebhook("W1", "https://example.com", []event.Type{event.TypeFundsDeposited}, "secret", time.Now())
+
+		assert.True(t, webhook.Accepts(event.TypeFundsDeposited))
+		assert.False(t, webhook.Accepts(event.TypeFundsWithdrawn))
+	})
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	webhook := NewWebhook("W1", "https://example.com", []event.Type{event.TypeFundsDeposited}, "secret", now)
+	e := event.New("E1", now, event.FundsDeposited{Account: "100", Amount: 10, Balance: 10})
+
+	t.Run("Should schedule retries until moved to the dead letters", func(t *testing.T) {
+		delivery := NewWebhookDelivery("D1", webhook, e, []byte("{}"), now)
+		retryAt := now.Add(time.Second)
+
+		delivery.Fail("503 Service Unavailable", now, &retryAt)
+		assert.Equal(t, DeliveryStatusPending, delivery.Status)
+		assert.Equal(t, retryAt, delivery.NextAttemptAt)
+		assert.Nil(t, delivery.FinishedAt)
+
+		delivery.Fail("503 Service Unavailable", retryAt, nil)
+		assert.Equal(t, DeliveryStatusDead, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, "503 Service Unavailable", delivery.LastError)
+		assert.Equal(t, &retryAt, delivery.FinishedAt)
+	})
+
+	t.Run("Should redeliver with a fresh retry budget", func(t *testing.T) {
+		delivery := NewWebhookDelivery("D1", webhook, e, []byte("{}"), now)
+		delivery.Fail("timeout", now, nil)
+
+		delivery.Redeliver(now.Add(time.Hour))
+
+		assert.Equal(t, DeliveryStatusPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+		assert.Nil(t, delivery.FinishedAt)
+		assert.Equal(t, now.Add(time.Hour), delivery.NextAttemptAt)
+	})
+
+	t.Run("Should record the delivery", func(t *testing.T) {
+		delivery := NewWebhookDelivery("D1", webhook, e, []byte("{}"), now)
+
+		delivery.Succeed(now)
+
+		assert.Equal(t, DeliveryStatusDelivered, delivery.Status)
+		assert.Equal(t, &now, delivery.DeliveredAt)
+		assert.Equal(t, &now, delivery.FinishedAt)
+		assert.Equal(t, 1, delivery.Attempts)
+	})
+}
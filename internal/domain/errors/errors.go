@@ -3,5 +3,34 @@ package errors
 import "errors"
 
 var (
-	ErrAccountInsufficientBalance = errors.New("Account as insufficient balance")
+	ErrAccountClosed                  = errors.New("Account is closed")
+	ErrAccountFrozen                  = errors.New("Account is frozen")
+	ErrAccountInsufficientBalance     = errors.New("Account as insufficient balance")
+	ErrAccountNotFound                = errors.New("Account not found")
+	ErrAccountNotOwned                = errors.New("Account not owned by customer")
+	ErrAccountWithdrawalLimitExceeded = errors.New("Account exceeded the monthly withdrawal limit")
+	ErrAmountBelowFee                 = errors.New("Amount does not cover the fee")
+	ErrAPIKeyNotFound                 = errors.New("API key not found")
+	ErrAPIKeyRevoked                  = errors.New("API key revoked")
+	ErrCustomerNotFound               = errors.New("Customer not found")
+	ErrInvalidAmount                  = errors.New("Amount must be greater than zero")
+	ErrProductNotFound                = errors.New("Product not found")
+	ErrSameAccountTransfer            = errors.New("Transfer origin and destination must differ")
+	ErrWebhookNotFound                = errors.New("Webhook not found")
+	ErrWebhookDeliveryNotFound        = errors.New("Webhook delivery not found")
 )
+
+// InsufficientBalanceError is returned instead of ErrAccountInsufficientBalance
+// so callers can report how much was available when the debit was refused.
+type InsufficientBalanceError struct {
+	Balance   int
+	Available int
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return ErrAccountInsufficientBalance.Error()
+}
+
+func (e *InsufficientBalanceError) Is(target error) bool {
+	return target == ErrAccountInsufficientBalance
+}
@@ -0,0 +1,232 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// DefaultQueueSize is how many events an asynchronous subscriber may lag
+// behind before the next ones are dropped for it.
+const DefaultQueueSize = 1024
+
+var (
+	ErrSubscriberPanicked = errors.New("event subscriber panicked")
+	ErrQueueFull          = errors.New("event subscriber queue full, event dropped")
+)
+
+// Publisher is told about the events of changes once they are committed.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event)
+}
+
+// Discard is a Publisher dropping every event.
+var Discard Publisher = discard{}
+
+type discard struct{}
+
+func (discard) Publish(context.Context, ...Event) {}
+
+// Handler receives the events published on a Bus. The change the event
+// describes is already committed, so the error it returns is only reported.
+type Handler func(ctx context.Context, e Event) error
+
+// ErrorHandler is told about the events a subscriber failed to handle.
+type ErrorHandler func(ctx context.Context, subscriber string, e Event, err error)
+
+// Delivery tells how a subscriber receives the events.
+type Delivery int
+
+const (
+	// Sync runs the handler on the publishing goroutine before Publish
+	// returns, it must return quickly as the caller waits for it.
+	Sync Delivery = iota
+	// Async queues the events and runs the handler on a goroutine of its own,
+	// in the order they were published. The caller never waits for it.
+	Async
+)
+
+// Bus hands every published event to its subscribers. Synchronous
+// subscribers run in the order they subscribed. Failing or panicking
+// subscribers are reported to the error handler and never affect the
+// publisher or the other subscribers.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []*subscriber
+	queueSize   int
+	onError     ErrorHandler
+	closed      bool
+}
+
+type subscriber struct {
+	name     string
+	handler  Handler
+	delivery Delivery
+
+	// mu guards queue against being closed while an event is queued.
+	mu      sync.Mutex
+	queue   chan published
+	done    chan struct{}
+	stopped bool
+}
+
+type published struct {
+	ctx   context.Context
+	event Event
+}
+
+func NewBus() *Bus {
+	return &Bus{queueSize: DefaultQueueSize, onError: logError}
+}
+
+// WithQueueSize bounds the queue of the asynchronous subscribers registered
+// from then on.
+func (b *Bus) WithQueueSize(size int) *Bus {
+	if size > 0 {
+		b.queueSize = size
+	}
+	return b
+}
+
+// WithErrorHandler reports the failures of the subscribers to onError
+// instead of logging them.
+func (b *Bus) WithErrorHandler(onError ErrorHandler) *Bus {
+	b.onError = onError
+	return b
+}
+
+// Subscribe registers handler under name until the returned function is
+// called. Unsubscribing an asynchronous subscriber waits for the events
+// already queued for it.
+func (b *Bus) Subscribe(name string, delivery Delivery, handler Handler) (unsubscribe func()) {
+	s := &subscriber{name: name, handler: handler, delivery: delivery}
+	if delivery == Async {
+		s.queue = make(chan published, b.queueSize)
+		s.done = make(chan struct{})
+		go b.run(s)
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		s.stop()
+		return func() {}
+	}
+	b.subscribers = append(b.subscribers, s)
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if b.remove(s) {
+				s.stop()
+			}
+		})
+	}
+}
+
+// Publish hands events to every subscriber. The asynchronous subscribers get
+// them with ctx detached from its cancellation, as they outlive the call.
+func (b *Bus) Publish(ctx context.Context, events ...Event) {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	for _, e := range events {
+		for _, s := range subscribers {
+			if s.delivery == Sync {
+				b.handle(ctx, s, e)
+			} else if !s.enqueue(published{ctx: context.WithoutCancel(ctx), event: e}) {
+				b.onError(ctx, s.name, e, ErrQueueFull)
+			}
+		}
+	}
+}
+
+// Close unsubscribes everyone, waiting for the asynchronous subscribers to
+// handle the events already queued. Events published afterwards are
+// dropped.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	subscribers := b.subscribers
+	b.subscribers = nil
+	b.closed = true
+	b.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.stop()
+	}
+}
+
+func (b *Bus) remove(s *subscriber) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, other := range b.subscribers {
+		if other == s {
+			b.subscribers = append(b.subscribers[:i:i], b.subscribers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bus) run(s *subscriber) {
+	defer close(s.done)
+	for p := range s.queue {
+		b.handle(p.ctx, s, p.event)
+	}
+}
+
+func (b *Bus) handle(ctx context.Context, s *subscriber, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.onError(ctx, s.name, e, fmt.Errorf("%w: %v", ErrSubscriberPanicked, r))
+		}
+	}()
+
+	if err := s.handler(ctx, e); err != nil {
+		b.onError(ctx, s.name, e, err)
+	}
+}
+
+// enqueue fails when the queue is full. Events queued after the subscriber
+// stopped are dropped silently, it unsubscribed.
+func (s *subscriber) enqueue(p published) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return true
+	}
+	select {
+	case s.queue <- p:
+		return true
+	default:
+		return false
+	}
+}
+
+// stop waits for an asynchronous subscriber to drain its queue.
+func (s *subscriber) stop() {
+	if s.delivery != Async {
+		return
+	}
+
+	s.mu.Lock()
+	s.stopped = true
+	close(s.queue)
+	s.mu.Unlock()
+	<-s.done
+}
+
+func logError(ctx context.Context, subscriber string, e Event, err error) {
+	slog.ErrorContext(ctx, "event subscriber failed",
+		slog.String("subscriber", subscriber),
+		slog.String("event_id", e.ID),
+		slog.String("event_type", string(e.Type)),
+		slog.String("error", err.Error()),
+	)
+}
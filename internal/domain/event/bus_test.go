@@ -0,0 +1,195 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestBusSuite struct {
+	suite.Suite
+	bus *Bus
+
+	mu       sync.Mutex
+	failures []string
+}
+
+func (suite *TestBusSuite) SetupSubTest() {
+	suite.failures = nil
+	suite.bus = NewBus().WithErrorHandler(func(ctx context.Context, subscriber string, e Event, err error) {
+		suite.mu.Lock()
+		defer suite.mu.Unlock()
+		suite.failures = append(suite.failures, subscriber+" "+e.ID+": "+err.Error())
+	})
+}
+
+func (suite *TestBusSuite) reported() []string {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+	return append([]string(nil), suite.failures...)
+}
+
+func deposited(id string) Event {
+	return New(id, time.Now(), FundsDeposited{Account: "100", Amount: 10, Balance: 10})
+}
+
+// recorder collects the IDs of the events it handles.
+type recorder struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *recorder) handle(ctx context.Context, e Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, e.ID)
+	return nil
+}
+
+func (r *recorder) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ids...)
+}
+
+func (suite *TestBusSuite) TestPublish() {
+	suite.Run("Should hand every event to every subscriber in order", func() {
+		var first, second recorder
+		suite.bus.Subscribe("first", Sync, first.handle)
+		suite.bus.Subscribe("second", Sync, second.handle)
+
+		suite.bus.Publish(context.Background(), deposited("E1"), deposited("E2"))
+
+		suite.Equal([]string{"E1", "E2"}, first.received())
+		suite.Equal([]string{"E1", "E2"}, second.received())
+	})
+
+	suite.Run("Should stop handing events once unsubscribed", func() {
+		var received recorder
+		unsubscribe := suite.bus.Subscribe("received", Sync, received.handle)
+
+		suite.bus.Publish(context.Background(), deposited("E1"))
+		unsubscribe()
+		suite.bus.Publish(context.Background(), deposited("E2"))
+
+		suite.Equal([]string{"E1"}, received.received())
+	})
+
+	suite.Run("Should report failing and panicking subscribers and go on", func() {
+		var received recorder
+		suite.bus.Subscribe("failing", Sync, func(ctx context.Context, e Event) error { return errors.New("boom") })
+		suite.bus.Subscribe("panicking", Sync, func(ctx context.Context, e Event) error { panic("boom") })
+		suite.bus.Subscribe("received", Sync, received.handle)
+
+		suite.bus.Publish(context.Background(), deposited("E1"))
+
+		suite.Equal([]string{"E1"}, received.received())
+		suite.Equal([]string{"failing E1: boom", "panicking E1: event subscriber panicked: boom"}, suite.reported())
+	})
+}
+
+func (suite *TestBusSuite) TestAsync() {
+	suite.Run("Should hand the events in order without waiting for the subscriber", func() {
+		release := make(chan struct{})
+		var received recorder
+		suite.bus.Subscribe("slow", Async, func(ctx context.Context, e Event) error {
+			<-release
+			return received.handle(ctx, e)
+		})
+
+		suite.bus.Publish(context.Background(), deposited("E1"), deposited("E2"))
+		suite.Empty(received.received())
+
+		close(release)
+		suite.bus.Close()
+		suite.Equal([]string{"E1", "E2"}, received.received())
+	})
+
+	suite.Run("Should hand the events with a context outliving the publisher", func() {
+		var errs []error
+		suite.bus.Subscribe("context", Async, func(ctx context.Context, e Event) error {
+			errs = append(errs, ctx.Err())
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		suite.bus.Publish(ctx, deposited("E1"))
+		cancel()
+		suite.bus.Close()
+
+		suite.Equal([]error{nil}, errs)
+	})
+
+	suite.Run("Should isolate failing subscribers", func() {
+		var received recorder
+		suite.bus.Subscribe("failing", Async, func(ctx context.Context, e Event) error { return errors.New("boom") })
+		suite.bus.Subscribe("panicking", Async, func(ctx context.Context, e Event) error { panic("boom") })
+		suite.bus.Subscribe("received", Async, received.handle)
+
+		suite.bus.Publish(context.Background(), deposited("E1"), deposited("E2"))
+		suite.bus.Close()
+
+		suite.Equal([]string{"E1", "E2"}, received.received())
+		suite.ElementsMatch([]string{
+			"failing E1: boom",
+			"failing E2: boom",
+			"panicking E1: event subscriber panicked: boom",
+			"panicking E2: event subscriber panicked: boom",
+		}, suite.reported())
+	})
+
+	suite.Run("Should drop the events of a subscriber with a full queue", func() {
+		suite.bus.WithQueueSize(1)
+		release := make(chan struct{})
+		started := make(chan struct{}, 3)
+		var received recorder
+		suite.bus.Subscribe("slow", Async, func(ctx context.Context, e Event) error {
+			started <- struct{}{}
+			<-release
+			return received.handle(ctx, e)
+		})
+
+		suite.bus.Publish(context.Background(), deposited("E1"))
+		<-started
+		suite.bus.Publish(context.Background(), deposited("E2"), deposited("E3"))
+
+		close(release)
+		suite.bus.Close()
+		suite.Equal([]string{"E1", "E2"}, received.received())
+		suite.Equal([]string{"slow E3: " + ErrQueueFull.Error()}, suite.reported())
+	})
+
+	suite.Run("Should wait for the queued events when unsubscribing", func() {
+		var received recorder
+		unsubscribe := suite.bus.Subscribe("received", Async, received.handle)
+
+		suite.bus.Publish(context.Background(), deposited("E1"))
+		unsubscribe()
+		suite.Equal([]string{"E1"}, received.received())
+
+		suite.bus.Publish(context.Background(), deposited("E2"))
+		unsubscribe()
+		suite.Equal([]string{"E1"}, received.received())
+	})
+
+	suite.Run("Should drop the events published once closed", func() {
+		var received recorder
+		suite.bus.Subscribe("received", Async, received.handle)
+		suite.bus.Close()
+
+		suite.bus.Publish(context.Background(), deposited("E1"))
+		suite.bus.Subscribe("late", Async, received.handle)
+		suite.bus.Publish(context.Background(), deposited("E2"))
+
+		suite.Empty(received.received())
+		suite.Empty(suite.reported())
+	})
+}
+
+func TestBus(t *testing.T) {
+	suite.Run(t, new(TestBusSuite))
+}
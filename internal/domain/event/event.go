@@ -0,0 +1,106 @@
+package event
+
+import (
+	"slices"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeAccountCreated    Type = "account.created"
+	TypeFundsDeposited    Type = "funds.deposited"
+	TypeFundsWithdrawn    Type = "funds.withdrawn"
+	TypeTransferCompleted Type = "transfer.completed"
+)
+
+var Types = []Type{
+	TypeAccountCreated,
+	TypeFundsDeposited,
+	TypeFundsWithdrawn,
+	TypeTransferCompleted,
+}
+
+func (t Type) IsValid() bool {
+	return slices.Contains(Types, t)
+}
+
+// Event is a fact about accounts, recorded with the balance change it
+// describes. ID is unique, consumers receiving an event twice use it to drop
+// the duplicate.
+type Event struct {
+	ID         string    `json:"id"`
+	Type       Type      `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       Data      `json:"data"`
+}
+
+// Data is the payload of an event, its concrete type depends on the event
+// type.
+type Data interface {
+	EventType() Type
+	// Balances lists the balances the event left on the accounts it changed.
+	Balances() []Balance
+}
+
+type Balance struct {
+	Account string `json:"account"`
+	Balance int    `json:"balance"`
+}
+
+func New(id string, occurredAt time.Time, data Data) Event {
+	return Event{ID: id, Type: data.EventType(), OccurredAt: occurredAt, Data: data}
+}
+
+type AccountCreated struct {
+	Account string `json:"account"`
+	Product string `json:"product"`
+}
+
+func (AccountCreated) EventType() Type { return TypeAccountCreated }
+
+func (AccountCreated) Balances() []Balance { return nil }
+
+type FundsDeposited struct {
+	Account string `json:"account"`
+	Amount  int    `json:"amount"`
+	Fee     int    `json:"fee"`
+	Balance int    `json:"balance"`
+}
+
+func (FundsDeposited) EventType() Type { return TypeFundsDeposited }
+
+func (e FundsDeposited) Balances() []Balance {
+	return []Balance{{Account: e.Account, Balance: e.Balance}}
+}
+
+type FundsWithdrawn struct {
+	Account string `json:"account"`
+	Amount  int    `json:"amount"`
+	Fee     int    `json:"fee"`
+	Balance int    `json:"balance"`
+}
+
+func (FundsWithdrawn) EventType() Type { return TypeFundsWithdrawn }
+
+func (e FundsWithdrawn) Balances() []Balance {
+	return []Balance{{Account: e.Account, Balance: e.Balance}}
+}
+
+type TransferCompleted struct {
+	Origin             string `json:"origin"`
+	Destination        string `json:"destination"`
+	Amount             int    `json:"amount"`
+	Fee                int    `json:"fee"`
+	OriginBalance      int    `json:"origin_balance"`
+	DestinationBalance int    `json:"destination_balance"`
+}
+
+func (TransferCompleted) EventType() Type { return TypeTransferCompleted }
+
+func (e TransferCompleted) Balances() []Balance {
+	return []Balance{
+		{Account: e.Origin, Balance: e.OriginBalance},
+		{Account: e.Destination, Balance: e.DestinationBalance},
+	}
+}
@@ -1,12 +1,72 @@
 package repository
 
-import "simple-bank/internal/domain/entity"
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+)
 
 //go:generate go run go.uber.org/mock/mockgen@v0.4.0 -source=${GOFILE} -destination=mocks/${GOFILE} -package=mocks AccountRepository
 
+type AccountSortField string
+
+const (
+	AccountSortByID      AccountSortField = "id"
+	AccountSortByBalance AccountSortField = "balance"
+)
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+type AccountFilter struct {
+	MinBalance *int
+	MaxBalance *int
+	Status     entity.AccountStatus
+	IDPrefix   string
+}
+
+// AccountCursor identifies the last account of a page. Balance is only
+// meaningful when the listing is sorted by balance.
+type AccountCursor struct {
+	ID      string
+	Balance int
+}
+
+type ListAccountsQuery struct {
+	Filter AccountFilter
+	SortBy AccountSortField
+	Order  SortOrder
+	After  *AccountCursor
+	Limit  int
+}
+
+type ListAccountsResult struct {
+	Accounts []*entity.Account
+	Next     *AccountCursor
+}
+
+// AccountsUpdate changes the accounts read by UpdateAccounts, in the order of
+// their IDs, and returns the events of the change. Accounts that do not exist
+// are nil, they are created when the update sets them.
+type AccountsUpdate func(accounts []*entity.Account) ([]event.Event, error)
+
+// AccountRepository writes the events given to UpdateAccount, SaveAccount and
+// UpdateAccounts to its outbox in the same transaction as the accounts, so
+// they are recorded if and only if the change is.
 type AccountRepository interface {
-	GetAccountByID(id string) (*entity.Account, error)
-	UpdateAccount(account *entity.Account) error
-	SaveAccount(account *entity.Account) error
-	DeleteAllAccounts() error
+	GetAccountByID(ctx context.Context, id string) (*entity.Account, error)
+	ListAccounts(ctx context.Context, query ListAccountsQuery) (*ListAccountsResult, error)
+	UpdateAccount(ctx context.Context, account *entity.Account, events ...event.Event) error
+	SaveAccount(ctx context.Context, account *entity.Account, events ...event.Event) error
+	// UpdateAccounts locks the accounts with the given IDs, reads them and
+	// writes them back with the events of update in one step, so the change
+	// spans several accounts without any other UpdateAccounts on them in
+	// between. Nothing is written when update fails, its error is returned
+	// as is.
+	UpdateAccounts(ctx context.Context, ids []string, update AccountsUpdate) error
+	DeleteAllAccounts(ctx context.Context) error
 }
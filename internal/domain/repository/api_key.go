@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.4.0 -source=${GOFILE} -destination=mocks/${GOFILE} -package=mocks APIKeyRepository
+
+type APIKeyRepository interface {
+	GetAPIKeyByID(ctx context.Context, id string) (*entity.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+	SaveAPIKey(ctx context.Context, key *entity.APIKey) error
+	UpdateAPIKey(ctx context.Context, key *entity.APIKey) error
+}
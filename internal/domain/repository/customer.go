@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.4.0 -source=${GOFILE} -destination=mocks/${GOFILE} -package=mocks CustomerRepository
+
+type CustomerRepository interface {
+	GetCustomerByID(ctx context.Context, id string) (*entity.Customer, error)
+	GetCustomersByAccountID(ctx context.Context, accountID string) ([]*entity.Customer, error)
+	SaveCustomer(ctx context.Context, customer *entity.Customer) error
+	UpdateCustomer(ctx context.Context, customer *entity.Customer) error
+	DeleteCustomer(ctx context.Context, id string) error
+}
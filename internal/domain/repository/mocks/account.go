@@ -10,8 +10,11 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	entity "simple-bank/internal/domain/entity"
+	event "simple-bank/internal/domain/event"
+	repository "simple-bank/internal/domain/repository"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,58 +43,97 @@ func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
 }
 
 // DeleteAllAccounts mocks base method.
-func (m *MockAccountRepository) DeleteAllAccounts() error {
+func (m *MockAccountRepository) DeleteAllAccounts(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAllAccounts")
+	ret := m.ctrl.Call(m, "DeleteAllAccounts", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAllAccounts indicates an expected call of DeleteAllAccounts.
-func (mr *MockAccountRepositoryMockRecorder) DeleteAllAccounts() *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) DeleteAllAccounts(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllAccounts", reflect.TypeOf((*MockAccountRepository)(nil).DeleteAllAccounts))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllAccounts", reflect.TypeOf((*MockAccountRepository)(nil).DeleteAllAccounts), ctx)
 }
 
 // GetAccountByID mocks base method.
-func (m *MockAccountRepository) GetAccountByID(id string) (*entity.Account, error) {
+func (m *MockAccountRepository) GetAccountByID(ctx context.Context, id string) (*entity.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountByID", id)
+	ret := m.ctrl.Call(m, "GetAccountByID", ctx, id)
 	ret0, _ := ret[0].(*entity.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountByID indicates an expected call of GetAccountByID.
-func (mr *MockAccountRepositoryMockRecorder) GetAccountByID(id any) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) GetAccountByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockAccountRepository)(nil).GetAccountByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByID", reflect.TypeOf((*MockAccountRepository)(nil).GetAccountByID), ctx, id)
+}
+
+// ListAccounts mocks base method.
+func (m *MockAccountRepository) ListAccounts(ctx context.Context, query repository.ListAccountsQuery) (*repository.ListAccountsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, query)
+	ret0, _ := ret[0].(*repository.ListAccountsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockAccountRepositoryMockRecorder) ListAccounts(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockAccountRepository)(nil).ListAccounts), ctx, query)
 }
 
 // SaveAccount mocks base method.
-func (m *MockAccountRepository) SaveAccount(account *entity.Account) error {
+func (m *MockAccountRepository) SaveAccount(ctx context.Context, account *entity.Account, events ...event.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveAccount", account)
+	varargs := []any{ctx, account}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveAccount", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveAccount indicates an expected call of SaveAccount.
-func (mr *MockAccountRepositoryMockRecorder) SaveAccount(account any) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) SaveAccount(ctx, account any, events ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAccount", reflect.TypeOf((*MockAccountRepository)(nil).SaveAccount), account)
+	varargs := append([]any{ctx, account}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAccount", reflect.TypeOf((*MockAccountRepository)(nil).SaveAccount), varargs...)
 }
 
 // UpdateAccount mocks base method.
-func (m *MockAccountRepository) UpdateAccount(account *entity.Account) error {
+func (m *MockAccountRepository) UpdateAccount(ctx context.Context, account *entity.Account, events ...event.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAccount", account)
+	varargs := []any{ctx, account}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateAccount", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateAccount indicates an expected call of UpdateAccount.
-func (mr *MockAccountRepositoryMockRecorder) UpdateAccount(account any) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) UpdateAccount(ctx, account any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, account}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccount), varargs...)
+}
+
+// UpdateAccounts mocks base method.
+func (m *MockAccountRepository) UpdateAccounts(ctx context.Context, ids []string, update repository.AccountsUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccounts", ctx, ids, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccounts indicates an expected call of UpdateAccounts.
+func (mr *MockAccountRepositoryMockRecorder) UpdateAccounts(ctx, ids, update any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccount), account)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccounts", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccounts), ctx, ids, update)
 }
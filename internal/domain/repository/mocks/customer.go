@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: customer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=customer.go -destination=mocks/customer.go -package=mocks CustomerRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	entity "simple-bank/internal/domain/entity"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomerRepository is a mock of CustomerRepository interface.
+type MockCustomerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerRepositoryMockRecorder
+}
+
+// MockCustomerRepositoryMockRecorder is the mock recorder for MockCustomerRepository.
+type MockCustomerRepositoryMockRecorder struct {
+	mock *MockCustomerRepository
+}
+
+// NewMockCustomerRepository creates a new mock instance.
+func NewMockCustomerRepository(ctrl *gomock.Controller) *MockCustomerRepository {
+	mock := &MockCustomerRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerRepository) EXPECT() *MockCustomerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteCustomer mocks base method.
+func (m *MockCustomerRepository) DeleteCustomer(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomer indicates an expected call of DeleteCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) DeleteCustomer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).DeleteCustomer), ctx, id)
+}
+
+// GetCustomerByID mocks base method.
+func (m *MockCustomerRepository) GetCustomerByID(ctx context.Context, id string) (*entity.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerByID indicates an expected call of GetCustomerByID.
+func (mr *MockCustomerRepositoryMockRecorder) GetCustomerByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByID", reflect.TypeOf((*MockCustomerRepository)(nil).GetCustomerByID), ctx, id)
+}
+
+// GetCustomersByAccountID mocks base method.
+func (m *MockCustomerRepository) GetCustomersByAccountID(ctx context.Context, accountID string) ([]*entity.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomersByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]*entity.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomersByAccountID indicates an expected call of GetCustomersByAccountID.
+func (mr *MockCustomerRepositoryMockRecorder) GetCustomersByAccountID(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomersByAccountID", reflect.TypeOf((*MockCustomerRepository)(nil).GetCustomersByAccountID), ctx, accountID)
+}
+
+// SaveCustomer mocks base method.
+func (m *MockCustomerRepository) SaveCustomer(ctx context.Context, customer *entity.Customer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCustomer", ctx, customer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCustomer indicates an expected call of SaveCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) SaveCustomer(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).SaveCustomer), ctx, customer)
+}
+
+// UpdateCustomer mocks base method.
+func (m *MockCustomerRepository) UpdateCustomer(ctx context.Context, customer *entity.Customer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomer", ctx, customer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomer indicates an expected call of UpdateCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) UpdateCustomer(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).UpdateCustomer), ctx, customer)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox.go -destination=mocks/outbox.go -package=mocks OutboxRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	repository "simple-bank/internal/domain/repository"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdvanceOutboxCursor mocks base method.
+func (m *MockOutboxRepository) AdvanceOutboxCursor(ctx context.Context, sink string, sequence uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceOutboxCursor", ctx, sink, sequence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdvanceOutboxCursor indicates an expected call of AdvanceOutboxCursor.
+func (mr *MockOutboxRepositoryMockRecorder) AdvanceOutboxCursor(ctx, sink, sequence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceOutboxCursor", reflect.TypeOf((*MockOutboxRepository)(nil).AdvanceOutboxCursor), ctx, sink, sequence)
+}
+
+// OutboxCursor mocks base method.
+func (m *MockOutboxRepository) OutboxCursor(ctx context.Context, sink string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxCursor", ctx, sink)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutboxCursor indicates an expected call of OutboxCursor.
+func (mr *MockOutboxRepositoryMockRecorder) OutboxCursor(ctx, sink any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxCursor", reflect.TypeOf((*MockOutboxRepository)(nil).OutboxCursor), ctx, sink)
+}
+
+// OutboxEntries mocks base method.
+func (m *MockOutboxRepository) OutboxEntries(ctx context.Context, after uint64, limit int) ([]repository.OutboxEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxEntries", ctx, after, limit)
+	ret0, _ := ret[0].([]repository.OutboxEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutboxEntries indicates an expected call of OutboxEntries.
+func (mr *MockOutboxRepositoryMockRecorder) OutboxEntries(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxEntries", reflect.TypeOf((*MockOutboxRepository)(nil).OutboxEntries), ctx, after, limit)
+}
+
+// PruneOutbox mocks base method.
+func (m *MockOutboxRepository) PruneOutbox(ctx context.Context, sequence uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneOutbox", ctx, sequence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PruneOutbox indicates an expected call of PruneOutbox.
+func (mr *MockOutboxRepositoryMockRecorder) PruneOutbox(ctx, sequence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneOutbox", reflect.TypeOf((*MockOutboxRepository)(nil).PruneOutbox), ctx, sequence)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: product.go
+//
+// Generated by this command:
+//
+//	mockgen -source=product.go -destination=mocks/product.go -package=mocks ProductRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	entity "simple-bank/internal/domain/entity"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductRepository is a mock of ProductRepository interface.
+type MockProductRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductRepositoryMockRecorder
+}
+
+// MockProductRepositoryMockRecorder is the mock recorder for MockProductRepository.
+type MockProductRepositoryMockRecorder struct {
+	mock *MockProductRepository
+}
+
+// NewMockProductRepository creates a new mock instance.
+func NewMockProductRepository(ctrl *gomock.Controller) *MockProductRepository {
+	mock := &MockProductRepository{ctrl: ctrl}
+	mock.recorder = &MockProductRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDefaultProduct mocks base method.
+func (m *MockProductRepository) GetDefaultProduct(ctx context.Context) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultProduct", ctx)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultProduct indicates an expected call of GetDefaultProduct.
+func (mr *MockProductRepositoryMockRecorder) GetDefaultProduct(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultProduct", reflect.TypeOf((*MockProductRepository)(nil).GetDefaultProduct), ctx)
+}
+
+// GetProductByID mocks base method.
+func (m *MockProductRepository) GetProductByID(ctx context.Context, id string) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByID indicates an expected call of GetProductByID.
+func (mr *MockProductRepositoryMockRecorder) GetProductByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductRepository)(nil).GetProductByID), ctx, id)
+}
+
+// ListProducts mocks base method.
+func (m *MockProductRepository) ListProducts(ctx context.Context) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", ctx)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockProductRepositoryMockRecorder) ListProducts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductRepository)(nil).ListProducts), ctx)
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -destination=mocks/webhook.go -package=mocks WebhookRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	entity "simple-bank/internal/domain/entity"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).DeleteWebhook), ctx, id)
+}
+
+// DueDeliveries mocks base method.
+func (m *MockWebhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueDeliveries indicates an expected call of DueDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) DueDeliveries(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).DueDeliveries), ctx, now, limit)
+}
+
+// GetDeliveryByID mocks base method.
+func (m *MockWebhookRepository) GetDeliveryByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryByID", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryByID indicates an expected call of GetDeliveryByID.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveryByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryByID", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveryByID), ctx, id)
+}
+
+// GetWebhookByID mocks base method.
+func (m *MockWebhookRepository) GetWebhookByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookByID indicates an expected call of GetWebhookByID.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhookByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookByID", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhookByID), ctx, id)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, status entity.DeliveryStatus) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, status)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ListDeliveries(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ListDeliveries), ctx, status)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockWebhookRepository) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockWebhookRepositoryMockRecorder) ListWebhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockWebhookRepository)(nil).ListWebhooks), ctx)
+}
+
+// PruneDeliveries mocks base method.
+func (m *MockWebhookRepository) PruneDeliveries(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneDeliveries", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneDeliveries indicates an expected call of PruneDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) PruneDeliveries(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).PruneDeliveries), ctx, before)
+}
+
+// SaveDeliveries mocks base method.
+func (m *MockWebhookRepository) SaveDeliveries(ctx context.Context, deliveries ...*entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range deliveries {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveDeliveries", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDeliveries indicates an expected call of SaveDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) SaveDeliveries(ctx any, deliveries ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, deliveries...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).SaveDeliveries), varargs...)
+}
+
+// SaveWebhook mocks base method.
+func (m *MockWebhookRepository) SaveWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhook indicates an expected call of SaveWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) SaveWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).SaveWebhook), ctx, webhook)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateDelivery), ctx, delivery)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"simple-bank/internal/domain/event"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.4.0 -source=${GOFILE} -destination=mocks/${GOFILE} -package=mocks OutboxRepository
+
+// OutboxEntry is an event of the outbox at its position in the log.
+// Sequences only grow, in the order the changes were stored.
+type OutboxEntry struct {
+	Sequence uint64
+	Event    event.Event
+}
+
+// OutboxRepository reads back the log of events written along with account
+// changes. Every sink reading the log keeps a cursor of its own, the
+// sequence of the last entry it received, so a sink failing never holds
+// back the others and a crash before a cursor moves delivers the entries
+// again.
+type OutboxRepository interface {
+	// OutboxEntries returns the oldest entries with a sequence above after.
+	OutboxEntries(ctx context.Context, after uint64, limit int) ([]OutboxEntry, error)
+	// OutboxCursor returns the cursor of sink, 0 when it never moved.
+	OutboxCursor(ctx context.Context, sink string) (uint64, error)
+	// AdvanceOutboxCursor moves the cursor of sink to sequence, it never
+	// moves back.
+	AdvanceOutboxCursor(ctx context.Context, sink string, sequence uint64) error
+	// PruneOutbox drops the entries up to sequence, once every sink got them.
+	PruneOutbox(ctx context.Context, sequence uint64) error
+}
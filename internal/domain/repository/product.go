@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.4.0 -source=${GOFILE} -destination=mocks/${GOFILE} -package=mocks ProductRepository
+
+type ProductRepository interface {
+	GetProductByID(ctx context.Context, id string) (*entity.Product, error)
+	GetDefaultProduct(ctx context.Context) (*entity.Product, error)
+	ListProducts(ctx context.Context) ([]*entity.Product, error)
+}
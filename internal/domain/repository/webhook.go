@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"time"
+)
+
+//go:generate go run go.uber.org/mock/mockgen@v0.4.0 -source=${GOFILE} -destination=mocks/${GOFILE} -package=mocks WebhookRepository
+
+type WebhookRepository interface {
+	GetWebhookByID(ctx context.Context, id string) (*entity.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+	SaveWebhook(ctx context.Context, webhook *entity.Webhook) error
+	DeleteWebhook(ctx context.Context, id string) error
+
+	GetDeliveryByID(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+	// ListDeliveries returns the deliveries with status, every delivery when
+	// status is empty, oldest first.
+	ListDeliveries(ctx context.Context, status entity.DeliveryStatus) ([]*entity.WebhookDelivery, error)
+	// DueDeliveries returns up to limit pending deliveries whose next attempt
+	// is not after now, oldest first.
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	// SaveDeliveries keeps the stored delivery when one with the same ID
+	// exists, so queuing an event twice delivers it once.
+	SaveDeliveries(ctx context.Context, deliveries ...*entity.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	// PruneDeliveries deletes the delivered and dead deliveries finished
+	// before before and returns how many it deleted. Pending deliveries are
+	// kept.
+	PruneDeliveries(ctx context.Context, before time.Time) (int, error)
+}
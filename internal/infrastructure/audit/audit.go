@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	ActionDeposit          = "deposit"
+	ActionWithdraw         = "withdraw"
+	ActionTransfer         = "transfer"
+	ActionInvalidEvent     = "invalid_event"
+	ActionReset            = "reset"
+	ActionAPIKeyCreate     = "api_key.create"
+	ActionAPIKeyRotate     = "api_key.rotate"
+	ActionAPIKeyRevoke     = "api_key.revoke"
+	ActionWebhookCreate    = "webhook.create"
+	ActionWebhookDelete    = "webhook.delete"
+	ActionWebhookRedeliver = "webhook.redeliver"
+
+	OutcomeSuccess  = "success"
+	OutcomeRejected = "rejected"
+	OutcomeDenied   = "denied"
+	OutcomeFailure  = "failure"
+)
+
+var (
+	ErrChainBroken = errors.New("audit chain broken")
+	ErrMissingKey  = errors.New("audit key is required")
+)
+
+// Entry describes a sensitive action, who attempted it and how it ended.
+// Sequence, PrevHash and Hash are set by the store: each entry holds the
+// HMAC-SHA256, keyed with the secret of the store, of its content together
+// with the hash of the previous one. Modifying, removing or reordering any
+// entry breaks the chain from that point on, and it cannot be recomputed
+// without the key.
+type Entry struct {
+	Sequence  uint64          `json:"sequence"`
+	Time      time.Time       `json:"time"`
+	Action    string          `json:"action"`
+	Target    string          `json:"target,omitempty"`
+	Actor     string          `json:"actor"`
+	IP        string          `json:"ip"`
+	RequestID string          `json:"request_id,omitempty"`
+	Outcome   string          `json:"outcome"`
+	Detail    string          `json:"detail,omitempty"`
+	Balances  []BalanceChange `json:"balances,omitempty"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+}
+
+// BalanceChange is the balance of an account before and after an action.
+type BalanceChange struct {
+	Account string `json:"account"`
+	Before  int    `json:"before"`
+	After   int    `json:"after"`
+}
+
+// Head is the sequence and hash of the last entry of a chain. Entries cut off
+// the end of a log leave a valid chain behind, they are only detected by
+// checking the chain still reaches the head recorded along with it.
+type Head struct {
+	Sequence uint64 `json:"sequence"`
+	Hash     string `json:"hash"`
+}
+
+type Logger interface {
+	Record(ctx context.Context, entry Entry) error
+}
+
+// Store is a Logger whose entries can be read back, oldest first, to be
+// verified against its head.
+type Store interface {
+	Logger
+	Entries(ctx context.Context) ([]Entry, error)
+	Head(ctx context.Context) (Head, error)
+}
+
+// chain links entries to the ones recorded before them. It is not safe for
+// concurrent use, stores serialize the calls.
+type chain struct {
+	key  []byte
+	head Head
+}
+
+func (c *chain) link(entry Entry) (Entry, error) {
+	entry.Sequence = c.head.Sequence + 1
+	entry.PrevHash = c.head.Hash
+	hash, err := hashOf(c.key, entry)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry.Hash = hash
+	return entry, nil
+}
+
+func (c *chain) advance(entry Entry) {
+	c.head = Head{Sequence: entry.Sequence, Hash: entry.Hash}
+}
+
+func hashOf(key []byte, entry Entry) (string, error) {
+	entry.Hash = ""
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	return sign(key, content), nil
+}
+
+func sign(key, content []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(content)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks that entries, hashed with key, form an unbroken chain
+// starting at the first entry ever recorded and reaching head. Entries after
+// the head are accepted, they are recorded before the head is updated.
+func Verify(key []byte, entries []Entry, head Head) error {
+	if len(key) == 0 {
+		return ErrMissingKey
+	}
+
+	c := chain{key: key}
+	for _, entry := range entries {
+		if entry.Sequence != c.head.Sequence+1 {
+			return fmt.Errorf("%w: expected sequence %d, found %d", ErrChainBroken, c.head.Sequence+1, entry.Sequence)
+		}
+		if entry.PrevHash != c.head.Hash {
+			return fmt.Errorf("%w: entry %d does not follow entry %d", ErrChainBroken, entry.Sequence, c.head.Sequence)
+		}
+		hash, err := hashOf(key, entry)
+		if err != nil {
+			return err
+		}
+		if !hmac.Equal([]byte(hash), []byte(entry.Hash)) {
+			return fmt.Errorf("%w: entry %d was modified", ErrChainBroken, entry.Sequence)
+		}
+		if entry.Sequence == head.Sequence && entry.Hash != head.Hash {
+			return fmt.Errorf("%w: entry %d is not the head", ErrChainBroken, entry.Sequence)
+		}
+		c.advance(entry)
+	}
+
+	if c.head.Sequence < head.Sequence {
+		return fmt.Errorf("%w: entries after %d were removed, the head is %d", ErrChainBroken, c.head.Sequence, head.Sequence)
+	}
+	return nil
+}
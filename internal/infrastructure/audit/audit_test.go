@@ -0,0 +1,202 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+var testKey = []byte("test-audit-key")
+
+type TestAuditSuite struct {
+	suite.Suite
+}
+
+func (suite *TestAuditSuite) entry(action string) Entry {
+	return Entry{
+		Time:    time.Date(2024, time.March, 10, 12, 0, 0, 123, time.UTC),
+		Action:  action,
+		Actor:   "api-key:admin",
+		IP:      "192.0.2.1",
+		Outcome: OutcomeSuccess,
+		Balances: []BalanceChange{
+			{Account: "100", Before: 10, After: 20},
+		},
+	}
+}
+
+func (suite *TestAuditSuite) TestMemoryStore() {
+	suite.Run("Should chain entries", func() {
+		store := NewMemoryStore(testKey)
+		suite.NoError(store.Record(context.Background(), suite.entry(ActionDeposit)))
+		suite.NoError(store.Record(context.Background(), suite.entry(ActionWithdraw)))
+
+		entries, err := store.Entries(context.Background())
+
+		suite.NoError(err)
+		suite.Require().Len(entries, 2)
+		suite.Equal(uint64(1), entries[0].Sequence)
+		suite.Empty(entries[0].PrevHash)
+		suite.Equal(entries[0].Hash, entries[1].PrevHash)
+		head, _ := store.Head(context.Background())
+		suite.Equal(Head{Sequence: 2, Hash: entries[1].Hash}, head)
+		suite.NoError(Verify(testKey, entries, head))
+	})
+}
+
+func (suite *TestAuditSuite) TestVerify() {
+	newEntries := func() ([]Entry, Head) {
+		store := NewMemoryStore(testKey)
+		for _, action := range []string{ActionDeposit, ActionWithdraw, ActionTransfer} {
+			suite.Require().NoError(store.Record(context.Background(), suite.entry(action)))
+		}
+		entries, _ := store.Entries(context.Background())
+		head, _ := store.Head(context.Background())
+		return entries, head
+	}
+
+	suite.Run("Should detect a modified entry", func() {
+		entries, head := newEntries()
+		entries[1].Balances[0].After = 1000
+
+		suite.ErrorIs(Verify(testKey, entries, head), ErrChainBroken)
+	})
+
+	suite.Run("Should detect a chain rehashed without the key", func() {
+		entries, _ := newEntries()
+		entries[1].Outcome = OutcomeFailure
+		key := []byte("guessed-key")
+		c := chain{key: key}
+		for i := range entries {
+			entries[i], _ = c.link(entries[i])
+			c.advance(entries[i])
+		}
+
+		suite.ErrorIs(Verify(testKey, entries, c.head), ErrChainBroken)
+		suite.NoError(Verify(key, entries, c.head))
+	})
+
+	suite.Run("Should detect a removed entry", func() {
+		entries, head := newEntries()
+
+		suite.ErrorIs(Verify(testKey, append(entries[:1], entries[2:]...), head), ErrChainBroken)
+		entries, head = newEntries()
+		suite.ErrorIs(Verify(testKey, entries[1:], head), ErrChainBroken)
+	})
+
+	suite.Run("Should detect a truncated log", func() {
+		entries, head := newEntries()
+
+		suite.ErrorIs(Verify(testKey, entries[:2], head), ErrChainBroken)
+		suite.ErrorIs(Verify(testKey, nil, head), ErrChainBroken)
+	})
+
+	suite.Run("Should accept entries recorded after the head", func() {
+		entries, _ := newEntries()
+
+		suite.NoError(Verify(testKey, entries, Head{Sequence: 2, Hash: entries[1].Hash}))
+		suite.ErrorIs(Verify(testKey, entries, Head{Sequence: 2, Hash: entries[0].Hash}), ErrChainBroken)
+	})
+
+	suite.Run("Should require a key", func() {
+		entries, head := newEntries()
+
+		suite.ErrorIs(Verify(nil, entries, head), ErrMissingKey)
+	})
+}
+
+func (suite *TestAuditSuite) TestFileStore() {
+	newLog := func() string {
+		path := filepath.Join(suite.T().TempDir(), "audit.log")
+		store, err := NewFileStore(path, testKey)
+		suite.Require().NoError(err)
+		for _, action := range []string{ActionDeposit, ActionWithdraw, ActionTransfer} {
+			suite.Require().NoError(store.Record(context.Background(), suite.entry(action)))
+		}
+		suite.Require().NoError(store.Close())
+		return path
+	}
+
+	suite.Run("Should continue the chain when reopened", func() {
+		path := newLog()
+
+		store, err := NewFileStore(path, testKey)
+		suite.Require().NoError(err)
+		suite.NoError(store.Record(context.Background(), suite.entry(ActionReset)))
+		defer store.Close()
+
+		entries, err := store.Entries(context.Background())
+		suite.NoError(err)
+		suite.Require().Len(entries, 4)
+		suite.Equal(uint64(4), entries[3].Sequence)
+		head, err := ReadHeadFile(HeadPath(path), testKey)
+		suite.NoError(err)
+		suite.Equal(Head{Sequence: 4, Hash: entries[3].Hash}, head)
+		suite.NoError(Verify(testKey, entries, head))
+	})
+
+	suite.Run("Should refuse to reopen an edited file", func() {
+		path := newLog()
+		content, err := os.ReadFile(path)
+		suite.Require().NoError(err)
+		tampered := strings.Replace(string(content), `"after":20`, `"after":2000`, 1)
+		suite.Require().NoError(os.WriteFile(path, []byte(tampered), 0o600))
+
+		_, err = NewFileStore(path, testKey)
+
+		suite.ErrorIs(err, ErrChainBroken)
+	})
+
+	suite.Run("Should refuse to reopen a truncated file", func() {
+		path := newLog()
+		content, err := os.ReadFile(path)
+		suite.Require().NoError(err)
+		lines := strings.SplitAfter(string(content), "\n")
+		suite.Require().NoError(os.WriteFile(path, []byte(strings.Join(lines[:2], "")), 0o600))
+
+		_, err = NewFileStore(path, testKey)
+
+		suite.ErrorIs(err, ErrChainBroken)
+	})
+
+	suite.Run("Should refuse a head moved back without the key", func() {
+		path := newLog()
+		entries, err := ReadFile(path)
+		suite.Require().NoError(err)
+		moved := fmt.Sprintf(`{"sequence":1,"hash":%q,"signature":"00"}`, entries[0].Hash)
+		suite.Require().NoError(os.WriteFile(HeadPath(path), []byte(moved), 0o600))
+
+		_, err = NewFileStore(path, testKey)
+
+		suite.ErrorIs(err, ErrChainBroken)
+	})
+
+	suite.Run("Should refuse entries without their head", func() {
+		path := newLog()
+		suite.Require().NoError(os.Remove(HeadPath(path)))
+
+		_, err := NewFileStore(path, testKey)
+
+		suite.ErrorIs(err, os.ErrNotExist)
+	})
+
+	suite.Run("Should refuse to open a file with another key", func() {
+		path := newLog()
+
+		_, err := NewFileStore(path, []byte("other-key"))
+
+		suite.ErrorIs(err, ErrChainBroken)
+		_, err = NewFileStore(path, nil)
+		suite.ErrorIs(err, ErrMissingKey)
+	})
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, new(TestAuditSuite))
+}
@@ -0,0 +1,188 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// FileStore appends entries as JSON lines to a file, synced before Record
+// returns. The head of the chain is kept next to it in HeadPath, signed with
+// the key. Reopening the file verifies the entries already in it against the
+// head and continues their chain.
+type FileStore struct {
+	mu    sync.Mutex
+	path  string
+	file  *os.File
+	chain chain
+}
+
+func NewFileStore(path string, key []byte) (*FileStore, error) {
+	if len(key) == 0 {
+		return nil, ErrMissingKey
+	}
+
+	entries, err := ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	head, err := ReadHeadFile(HeadPath(path), key)
+	if errors.Is(err, os.ErrNotExist) && len(entries) == 0 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Verify(key, entries, head); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileStore{path: path, file: file, chain: chain{key: key}}
+	if len(entries) > 0 {
+		s.chain.advance(entries[len(entries)-1])
+	}
+	return s, nil
+}
+
+func (s *FileStore) Record(ctx context.Context, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.chain.link(entry)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err = s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err = s.file.Sync(); err != nil {
+		return err
+	}
+	s.chain.advance(entry)
+	return writeHeadFile(HeadPath(s.path), s.chain.key, s.chain.head)
+}
+
+func (s *FileStore) Entries(ctx context.Context) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ReadFile(s.path)
+}
+
+func (s *FileStore) Head(ctx context.Context) (Head, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chain.head, nil
+}
+
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
+
+// HeadPath is the file the head of the audit log at path is kept in.
+func HeadPath(path string) string {
+	return path + ".head"
+}
+
+// signedHead is a Head with the signature keeping it from being moved back
+// to an earlier entry without the key.
+type signedHead struct {
+	Head
+	Signature string `json:"signature"`
+}
+
+func headSignature(key []byte, head Head) string {
+	return sign(key, []byte("head:"+strconv.FormatUint(head.Sequence, 10)+":"+head.Hash))
+}
+
+// ReadHeadFile reads the head written by a FileStore, checking its signature
+// with key.
+func ReadHeadFile(path string, key []byte) (Head, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Head{}, err
+	}
+
+	var head signedHead
+	if err := json.Unmarshal(content, &head); err != nil {
+		return Head{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if !hmac.Equal([]byte(head.Signature), []byte(headSignature(key, head.Head))) {
+		return Head{}, fmt.Errorf("%w: %s was modified", ErrChainBroken, path)
+	}
+	return head.Head, nil
+}
+
+// writeHeadFile replaces the head file through a synced temporary file, so a
+// crash leaves either head in place.
+func writeHeadFile(path string, key []byte, head Head) error {
+	content, err := json.Marshal(signedHead{Head: head, Signature: headSignature(key, head)})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// ReadFile reads the entries of an audit log written by a FileStore.
+func ReadFile(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// Read decodes JSON lines audit entries.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"context"
+	"sync"
+)
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	chain   chain
+	entries []Entry
+}
+
+func NewMemoryStore(key []byte) *MemoryStore {
+	return &MemoryStore{chain: chain{key: key}}
+}
+
+func (s *MemoryStore) Record(ctx context.Context, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.chain.link(entry)
+	if err != nil {
+		return err
+	}
+	s.entries = append(s.entries, entry)
+	s.chain.advance(entry)
+	return nil
+}
+
+func (s *MemoryStore) Entries(ctx context.Context) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Entry(nil), s.entries...), nil
+}
+
+func (s *MemoryStore) Head(ctx context.Context) (Head, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chain.head, nil
+}
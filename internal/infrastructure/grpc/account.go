@@ -0,0 +1,325 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/grpc/bankv1"
+	"simple-bank/internal/infrastructure/http/auth"
+	handlers "simple-bank/internal/infrastructure/http/handler"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/infrastructure/http/validation"
+	"simple-bank/internal/infrastructure/stream"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/logging"
+	usecase "simple-bank/internal/usecase/account"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const MetadataResetConfirmation = "x-reset-confirmation"
+
+var ErrSubscriptionClosed = status.Error(codes.Unavailable, "Stream closed, resume it with the last_id received")
+
+type accountRequest struct {
+	AccountID string `json:"account_id" validate:"required,account_id"`
+}
+
+// AccountService serves bankv1.AccountService on the account use cases, with
+// the scopes, validation and audit of the HTTP API.
+type AccountService struct {
+	bankv1.UnimplementedAccountServiceServer
+	getBalanceUseCase *usecase.GetBalanceUseCase
+	depositUseCase    *usecase.DepositUseCase
+	withdrawUseCase   *usecase.WithdrawUseCase
+	transferUseCase   *usecase.TransferUseCase
+	resetUseCase      *usecase.ResetUseCase
+	resetToken        string
+	broker            *stream.Broker
+	validator         *validation.Validator
+	audit             audit.Logger
+	now               func() time.Time
+	closed            chan struct{}
+	closeOnce         sync.Once
+}
+
+func NewAccountService(
+	getBalanceUseCase *usecase.GetBalanceUseCase,
+	depositUseCase *usecase.DepositUseCase,
+	withdrawUseCase *usecase.WithdrawUseCase,
+	transferUseCase *usecase.TransferUseCase,
+	broker *stream.Broker,
+) *AccountService {
+	return &AccountService{
+		getBalanceUseCase: getBalanceUseCase,
+		depositUseCase:    depositUseCase,
+		withdrawUseCase:   withdrawUseCase,
+		transferUseCase:   transferUseCase,
+		broker:            broker,
+		validator:         validation.NewValidator(),
+		now:               time.Now,
+		closed:            make(chan struct{}),
+	}
+}
+
+// WithReset serves Reset to the callers sending confirmationToken in the
+// x-reset-confirmation metadata. Without it Reset is unimplemented.
+func (s *AccountService) WithReset(resetUseCase *usecase.ResetUseCase, confirmationToken string) *AccountService {
+	s.resetUseCase = resetUseCase
+	s.resetToken = confirmationToken
+	return s
+}
+
+// WithAudit records every money movement and reset, including the rejected
+// ones, on logger.
+func (s *AccountService) WithAudit(logger audit.Logger) *AccountService {
+	s.audit = logger
+	return s
+}
+
+func (s *AccountService) GetBalance(
+	ctx context.Context,
+	req *bankv1.GetBalanceRequest,
+) (*bankv1.GetBalanceResponse, error) {
+	if err := authorize(ctx, entity.ScopeBalanceRead, req.GetAccountId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(&accountRequest{AccountID: req.GetAccountId()}); err != nil {
+		return nil, err
+	}
+
+	output, err := s.getBalanceUseCase.Execute(ctx, usecase.GetBalanceInputDTO{ID: req.GetAccountId()})
+	if err != nil {
+		return nil, err
+	}
+	return &bankv1.GetBalanceResponse{Balance: int64(output.Balance)}, nil
+}
+
+func (s *AccountService) Deposit(ctx context.Context, req *bankv1.DepositRequest) (res *bankv1.DepositResponse, err error) {
+	var balances []audit.BalanceChange
+	defer func() { s.record(ctx, audit.Entry{Action: audit.ActionDeposit, Balances: balances}, err) }()
+
+	if err := authorize(ctx, entity.ScopeEventWrite, req.GetDestination()); err != nil {
+		return nil, err
+	}
+
+	if err := s.validate("deposit", req.GetDestination(), "", req.GetAmount(), req.GetProduct()); err != nil {
+		return nil, err
+	}
+
+	output, err := s.depositUseCase.Execute(ctx, usecase.DepositInputDTO{
+		Destination: req.GetDestination(),
+		Amount:      int(req.GetAmount()),
+		Product:     req.GetProduct(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	balances = handlers.DepositBalances(output)
+	return &bankv1.DepositResponse{Destination: toAccount(output.Destination)}, nil
+}
+
+func (s *AccountService) Withdraw(ctx context.Context, req *bankv1.WithdrawRequest) (res *bankv1.WithdrawResponse, err error) {
+	var balances []audit.BalanceChange
+	defer func() { s.record(ctx, audit.Entry{Action: audit.ActionWithdraw, Balances: balances}, err) }()
+
+	if err := authorize(ctx, entity.ScopeEventWrite, req.GetOrigin()); err != nil {
+		return nil, err
+	}
+
+	if err := s.validate("withdraw", "", req.GetOrigin(), req.GetAmount(), ""); err != nil {
+		return nil, err
+	}
+
+	output, err := s.withdrawUseCase.Execute(ctx, usecase.WithdrawInputDTO{
+		Origin: req.GetOrigin(),
+		Amount: int(req.GetAmount()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	balances = handlers.WithdrawBalances(output)
+	return &bankv1.WithdrawResponse{Origin: toAccount(output.Origin)}, nil
+}
+
+func (s *AccountService) Transfer(ctx context.Context, req *bankv1.TransferRequest) (res *bankv1.TransferResponse, err error) {
+	var balances []audit.BalanceChange
+	defer func() { s.record(ctx, audit.Entry{Action: audit.ActionTransfer, Balances: balances}, err) }()
+
+	if err := authorize(ctx, entity.ScopeEventWrite, req.GetOrigin()); err != nil {
+		return nil, err
+	}
+
+	if err := s.validate("transfer", req.GetDestination(), req.GetOrigin(), req.GetAmount(), ""); err != nil {
+		return nil, err
+	}
+
+	output, err := s.transferUseCase.Execute(ctx, usecase.TransferInputDTO{
+		Origin:      req.GetOrigin(),
+		Destination: req.GetDestination(),
+		Amount:      int(req.GetAmount()),
+		CustomerID:  auth.PrincipalFromContext(ctx).CustomerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	balances = handlers.TransferBalances(output)
+	return &bankv1.TransferResponse{
+		Origin:      toAccount(output.Origin),
+		Destination: toAccount(output.Destination),
+	}, nil
+}
+
+func (s *AccountService) Reset(ctx context.Context, req *bankv1.ResetRequest) (res *bankv1.ResetResponse, err error) {
+	if s.resetUseCase == nil {
+		return nil, status.Error(codes.Unimplemented, "Reset is disabled")
+	}
+
+	if err := authorize(ctx, entity.ScopeAdminReset); err != nil {
+		return nil, err
+	}
+
+	if !s.confirmed(ctx) {
+		s.record(ctx, audit.Entry{
+			Action:  audit.ActionReset,
+			Outcome: audit.OutcomeDenied,
+			Detail:  "missing or invalid confirmation token",
+		}, nil)
+		return nil, problem.ErrResetNotConfirmed
+	}
+
+	err = s.resetUseCase.Execute(ctx)
+	s.record(ctx, audit.Entry{Action: audit.ActionReset}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &bankv1.ResetResponse{}, nil
+}
+
+// StreamAccountUpdates replays the buffered updates after last_id, when set,
+// or a reset when they are lost, then sends the new ones. A stream falling behind is closed with Unavailable, the client
+// resumes it with the last id it received.
+func (s *AccountService) StreamAccountUpdates(
+	req *bankv1.StreamAccountUpdatesRequest,
+	srv bankv1.AccountService_StreamAccountUpdatesServer,
+) error {
+	ctx := srv.Context()
+	if req.GetAccountId() == "" {
+		if err := authorizeUnrestricted(ctx, entity.ScopeAdminStream); err != nil {
+			return err
+		}
+	} else {
+		if err := authorize(ctx, entity.ScopeBalanceRead, req.GetAccountId()); err != nil {
+			return err
+		}
+		if err := s.validator.Validate(&accountRequest{AccountID: req.GetAccountId()}); err != nil {
+			return err
+		}
+	}
+
+	replay, subscription := s.broker.Subscribe(req.GetAccountId(), req.GetLastId())
+	defer subscription.Close()
+
+	// the headers tell the client it is subscribed, no update published from
+	// then on is missed.
+	if err := srv.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for _, message := range replay {
+		if err := srv.Send(toAccountUpdate(message)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.closed:
+			return ErrSubscriptionClosed
+		case message, ok := <-subscription.C:
+			if !ok {
+				return ErrSubscriptionClosed
+			}
+			if err := srv.Send(toAccountUpdate(message)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close ends the update streams, they never end on their own.
+func (s *AccountService) Close() {
+	s.closeOnce.Do(func() { close(s.closed) })
+}
+
+// validate applies the validation of the HTTP events to the request.
+func (s *AccountService) validate(eventType, destination, origin string, amount int64, product string) error {
+	return s.validator.Validate(&handlers.HandleEventRequest{
+		Type:        eventType,
+		Destination: destination,
+		Origin:      origin,
+		Amount:      int(amount),
+		Product:     product,
+	})
+}
+
+func (s *AccountService) confirmed(ctx context.Context) bool {
+	tokens := metadata.ValueFromIncomingContext(ctx, MetadataResetConfirmation)
+	return s.resetToken != "" && len(tokens) == 1 &&
+		subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(s.resetToken)) == 1
+}
+
+// record completes entry with the call and the outcome of err. Failing to
+// audit does not fail the call, it is logged instead.
+func (s *AccountService) record(ctx context.Context, entry audit.Entry, err error) {
+	if s.audit == nil {
+		return
+	}
+
+	entry.Time = s.now().UTC()
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		entry.Actor = principal.Subject
+	}
+	entry.IP = remoteIP(ctx)
+	entry.RequestID = logging.RequestIDFromContext(ctx)
+	if entry.Outcome == "" {
+		entry.Outcome, entry.Detail = handlers.AuditOutcome(err)
+	}
+
+	if err := s.audit.Record(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "fail to audit "+entry.Action, logging.KeyError, err.Error())
+	}
+}
+
+func toAccount(account dto.AccountDTO) *bankv1.Account {
+	return &bankv1.Account{Id: account.ID, Balance: int64(account.Balance)}
+}
+
+func toAccountUpdate(message stream.Message) *bankv1.AccountUpdate {
+	if message.Reset {
+		return &bankv1.AccountUpdate{Id: message.ID, ResetRequired: true}
+	}
+	return &bankv1.AccountUpdate{
+		Id:         message.ID,
+		EventId:    message.Change.EventID,
+		EventType:  message.Change.EventType,
+		Account:    message.Change.Account,
+		Balance:    int64(message.Change.Balance),
+		OccurredAt: timestamppb.New(message.Change.OccurredAt),
+	}
+}
+
+var _ bankv1.AccountServiceServer = (*AccountService)(nil)
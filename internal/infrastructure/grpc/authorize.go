@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/http/auth"
+)
+
+// authorize fails unless the principal of ctx has scope and may act on every
+// given account, like auth.RequireScope and auth.CheckAccount on HTTP.
+func authorize(ctx context.Context, scope entity.Scope, accountIDs ...string) error {
+	principal := auth.PrincipalFromContext(ctx)
+	if principal == nil {
+		return auth.ErrUnauthenticated
+	}
+
+	if !principal.HasScope(scope) {
+		return auth.ErrInsufficientScope
+	}
+
+	for _, accountID := range accountIDs {
+		if !principal.CanAccessAccount(accountID) {
+			return auth.ErrAccountForbidden
+		}
+	}
+	return nil
+}
+
+// authorizeUnrestricted fails unless the principal of ctx has scope and is
+// not restricted to some accounts, for calls spanning every account.
+func authorizeUnrestricted(ctx context.Context, scope entity.Scope) error {
+	if err := authorize(ctx, scope); err != nil {
+		return err
+	}
+
+	if auth.PrincipalFromContext(ctx).IsRestricted() {
+		return auth.ErrAccountForbidden
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"simple-bank/internal/infrastructure/grpc/bankv1"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/ratelimit"
+	"simple-bank/internal/shared/logging"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const MetadataRequestID = "x-request-id"
+
+var errPanic = errors.New("Call panicked")
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// call prepares the context of every call like the HTTP middlewares do: it
+// keeps or generates the request ID, resolves the principal from the
+// metadata and takes from the rate limits. Calls with invalid credentials are
+// refused, calls without any credential go on without principal and fail on
+// the methods requiring one. Panics fail the call rather than the server.
+type call struct {
+	authenticators []auth.Authenticator
+	rateLimit      *ratelimit.Config
+	logger         *slog.Logger
+}
+
+func (c *call) unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	ctx, err := c.prepare(ctx)
+	if err == nil {
+		err = c.takeRateLimit(ctx, info.FullMethod, requestAccount(req))
+	}
+	var res any
+	if err == nil {
+		err = c.recover(ctx, func() (err error) {
+			res, err = handler(ctx, req)
+			return err
+		})
+	}
+	return res, c.finish(ctx, info.FullMethod, start, err)
+}
+
+func (c *call) stream(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+	ctx, err := c.prepare(ss.Context())
+	if err == nil {
+		err = c.takeRateLimit(ctx, info.FullMethod, "")
+	}
+	if err == nil {
+		err = c.recover(ctx, func() error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		})
+	}
+	return c.finish(ctx, info.FullMethod, start, err)
+}
+
+func (c *call) prepare(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := make(http.Header, len(md))
+	for name, values := range md {
+		for _, value := range values {
+			header.Add(name, value)
+		}
+	}
+
+	id := header.Get(MetadataRequestID)
+	if !validRequestID.MatchString(id) {
+		id = newRequestID()
+	}
+	ctx = logging.WithRequestID(ctx, id)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, id))
+
+	principal, err := auth.Authenticate(ctx, header, c.authenticators...)
+	if err != nil {
+		return ctx, err
+	}
+	if principal != nil {
+		ctx = auth.WithPrincipal(ctx, principal)
+	}
+	return ctx, nil
+}
+
+// recover runs handler, turning its panic into an error the call fails with,
+// as middleware.Recover does on HTTP.
+func (c *call) recover(ctx context.Context, handler func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger.LogAttrs(ctx, slog.LevelError, "grpc call panicked",
+				slog.Any("panic", r),
+				slog.String("stack", string(debug.Stack())),
+			)
+			err = fmt.Errorf("%w: %v", errPanic, r)
+		}
+	}()
+	return handler()
+}
+
+// takeRateLimit charges the calls of the account service to the buckets the
+// HTTP requests take from, GetBalance and the update streams as reads and the
+// other methods as writes. account is the account the call acts on, empty
+// when unknown.
+func (c *call) takeRateLimit(ctx context.Context, method, account string) error {
+	if c.rateLimit == nil || !strings.HasPrefix(method, "/"+bankv1.AccountService_ServiceDesc.ServiceName+"/") {
+		return nil
+	}
+
+	write := method != bankv1.AccountService_GetBalance_FullMethodName &&
+		method != bankv1.AccountService_StreamAccountUpdates_FullMethodName
+	decision, err := c.rateLimit.Take(ctx, write, ratelimit.Call{
+		Principal: auth.PrincipalFromContext(ctx),
+		IP:        remoteIP(ctx),
+		Account:   func() string { return account },
+	})
+	if err != nil {
+		return err
+	}
+	if !decision.Allowed {
+		return ratelimit.ErrRateLimited
+	}
+	return nil
+}
+
+// requestAccount is the account a request moves money from, or into for
+// deposits, like the rate limits read it from the events of the HTTP API.
+func requestAccount(req any) string {
+	switch req := req.(type) {
+	case *bankv1.GetBalanceRequest:
+		return req.GetAccountId()
+	case *bankv1.DepositRequest:
+		return req.GetDestination()
+	case *bankv1.WithdrawRequest:
+		return req.GetOrigin()
+	case *bankv1.TransferRequest:
+		return req.GetOrigin()
+	}
+	return ""
+}
+
+// finish logs the call and converts its error to a status.
+func (c *call) finish(ctx context.Context, method string, start time.Time, err error) error {
+	s := Status(err)
+	level := slog.LevelInfo
+	if s.Code() == codes.Internal || s.Code() == codes.Unknown {
+		level = slog.LevelError
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", s.Code().String()),
+		slog.Duration("latency", time.Since(start)),
+		slog.String("remote_ip", remoteIP(ctx)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String(logging.KeyError, err.Error()))
+	}
+	c.logger.LogAttrs(ctx, level, "grpc call", attrs...)
+
+	if err != nil {
+		return s.Err()
+	}
+	return nil
+}
+
+// serverStream replaces the context of a stream with the prepared one.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func remoteIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
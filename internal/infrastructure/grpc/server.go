@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"simple-bank/internal/infrastructure/grpc/bankv1"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/ratelimit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+//go:generate protoc --proto_path=../../../api/proto --go_out=../../.. --go_opt=module=simple-bank --go-grpc_out=../../.. --go-grpc_opt=module=simple-bank simplebank/v1/bank.proto
+
+type GRPCServerConfig struct {
+	Port string
+	// Authenticators resolve the caller of each call from its metadata,
+	// authentication is disabled when none is given.
+	Authenticators []auth.Authenticator
+	// RateLimit throttles the calls of the account service, share it with the
+	// HTTP server so both APIs take from the same buckets. nil disables it.
+	RateLimit *ratelimit.Config
+	// Logger writes a line per call, slog.Default() is used when nil.
+	Logger *slog.Logger
+}
+
+// GRPCServer serves the account service next to the HTTP API, along with the
+// standard health and reflection services.
+type GRPCServer struct {
+	Server   *grpc.Server
+	Health   *health.Server
+	accounts *AccountService
+	port     string
+}
+
+func NewGRPCServer(config GRPCServerConfig, accountService *AccountService) *GRPCServer {
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	c := &call{authenticators: config.Authenticators, rateLimit: config.RateLimit, logger: logger}
+	server := &GRPCServer{
+		Server: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(c.unary),
+			grpc.ChainStreamInterceptor(c.stream),
+		),
+		Health:   health.NewServer(),
+		accounts: accountService,
+		port:     config.Port,
+	}
+
+	bankv1.RegisterAccountServiceServer(server.Server, accountService)
+	healthpb.RegisterHealthServer(server.Server, server.Health)
+	reflection.Register(server.Server)
+
+	server.Health.SetServingStatus(bankv1.AccountService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return server
+}
+
+func (s *GRPCServer) Start() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", s.port))
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+func (s *GRPCServer) Serve(listener net.Listener) error {
+	return s.Server.Serve(listener)
+}
+
+// Stop reports not serving, ends the update streams and waits for the
+// running calls to end. The calls still running when ctx is done are
+// cancelled.
+func (s *GRPCServer) Stop(ctx context.Context) error {
+	s.Health.Shutdown()
+	s.accounts.Close()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"simple-bank/internal/infrastructure/http/problem"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to every error,
+// its reason is the problem code the HTTP API answers with.
+const ErrorDomain = "simple-bank"
+
+var problemCodes = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusUnauthorized:         codes.Unauthenticated,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.FailedPrecondition,
+	http.StatusUnprocessableEntity:  codes.FailedPrecondition,
+	http.StatusPreconditionRequired: codes.FailedPrecondition,
+	http.StatusTooManyRequests:      codes.ResourceExhausted,
+	http.StatusServiceUnavailable:   codes.Unavailable,
+}
+
+// Status converts err to the status of a call. Errors are classified by the
+// problem mappings, so both APIs expose the same failures the same way.
+func Status(err error) *status.Status {
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, "Request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, "Request timed out")
+	}
+
+	p := problem.FromError(err)
+	code, ok := problemCodes[p.Status]
+	if !ok {
+		code = codes.Internal
+	}
+	if p.Code == problem.CodeInvalidAmount || p.Code == problem.CodeSameAccountTransfer {
+		code = codes.InvalidArgument
+	}
+
+	s := status.New(code, p.Title)
+	info := &errdetails.ErrorInfo{Reason: p.Code, Domain: ErrorDomain}
+	if p.Balance != nil && p.AvailableBalance != nil {
+		info.Metadata = map[string]string{
+			"balance":           strconv.Itoa(*p.Balance),
+			"available_balance": strconv.Itoa(*p.AvailableBalance),
+		}
+	}
+	details := []protoadapt.MessageV1{info}
+	if len(p.Errors) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(p.Errors))
+		for _, field := range p.Errors {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	if detailed, err := s.WithDetails(details...); err == nil {
+		return detailed
+	}
+	return s
+}
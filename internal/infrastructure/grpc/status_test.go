@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/usecase/account"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type StatusSuite struct {
+	suite.Suite
+}
+
+func (suite *StatusSuite) reason(s *status.Status) string {
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func (suite *StatusSuite) TestStatus() {
+	suite.Run("Should map the errors like the problem mappings", func() {
+		cases := []struct {
+			err    error
+			code   codes.Code
+			reason string
+		}{
+			{errors.Join(account.ErrGetBalanceAccountNotExists, domainErrs.ErrAccountNotFound), codes.NotFound, problem.CodeAccountNotFound},
+			{errors.Join(account.ErrWithdrawFailToWithdraw, domainErrs.ErrAccountFrozen), codes.FailedPrecondition, problem.CodeAccountFrozen},
+			{errors.Join(account.ErrDepositFailToDeposit, domainErrs.ErrInvalidAmount), codes.InvalidArgument, problem.CodeInvalidAmount},
+			{errors.Join(account.ErrTransferSameAccount, domainErrs.ErrSameAccountTransfer), codes.InvalidArgument, problem.CodeSameAccountTransfer},
+			{account.ErrDepositProductNotExists, codes.InvalidArgument, problem.CodeInvalidProduct},
+			{auth.ErrUnauthenticated, codes.Unauthenticated, problem.CodeUnauthenticated},
+			{auth.ErrAccountForbidden, codes.PermissionDenied, problem.CodeAccountForbidden},
+			{problem.ErrResetNotConfirmed, codes.FailedPrecondition, problem.CodeResetNotConfirmed},
+			{errors.New("boom"), codes.Internal, problem.CodeInternalError},
+		}
+
+		for _, c := range cases {
+			s := Status(c.err)
+			suite.Equal(c.code, s.Code(), c.err.Error())
+			suite.Equal(c.reason, suite.reason(s), c.err.Error())
+		}
+	})
+
+	suite.Run("Should keep the status of status errors", func() {
+		s := Status(status.Error(codes.Unimplemented, "nope"))
+
+		suite.Equal(codes.Unimplemented, s.Code())
+		suite.Equal("nope", s.Message())
+	})
+
+	suite.Run("Should map the context errors", func() {
+		suite.Equal(codes.Canceled, Status(context.Canceled).Code())
+		suite.Equal(codes.DeadlineExceeded, Status(errors.Join(errors.New("slow"), context.DeadlineExceeded)).Code())
+	})
+
+	suite.Run("Should report the balances on insufficient funds", func() {
+		err := &domainErrs.InsufficientBalanceError{Balance: 10, Available: 5}
+
+		s := Status(errors.Join(account.ErrWithdrawFailToWithdraw, err))
+
+		info := s.Details()[0].(*errdetails.ErrorInfo)
+		suite.Equal(codes.FailedPrecondition, s.Code())
+		suite.Equal(map[string]string{"balance": "10", "available_balance": "5"}, info.Metadata)
+	})
+
+	suite.Run("Should report OK without error", func() {
+		suite.Equal(codes.OK, Status(nil).Code())
+	})
+}
+
+func TestStatus(t *testing.T) {
+	suite.Run(t, new(StatusSuite))
+}
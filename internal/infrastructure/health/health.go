@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK           = "ok"
+	StatusReady        = "ready"
+	StatusNotReady     = "not_ready"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Check is a named dependency the application needs to serve requests.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Pinger is implemented by backends that can tell whether they are reachable.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// RepositoryCheck pings repo when it implements Pinger, repositories that
+// cannot tell are assumed reachable.
+func RepositoryCheck(name string, repo any) Check {
+	return Check{Name: name, Check: func(ctx context.Context) error {
+		if pinger, ok := repo.(Pinger); ok {
+			return pinger.Ping(ctx)
+		}
+		return nil
+	}}
+}
+
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Readiness runs the checks and stops reporting ready once shutdown began.
+type Readiness struct {
+	checks       []Check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewReadiness(timeout time.Duration, checks ...Check) *Readiness {
+	return &Readiness{checks: checks, timeout: timeout}
+}
+
+func (r *Readiness) ShutDown() {
+	r.shuttingDown.Store(true)
+}
+
+func (r *Readiness) Check(ctx context.Context) (Report, bool) {
+	if r.shuttingDown.Load() {
+		return Report{Status: StatusShuttingDown}, false
+	}
+
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		ready  = true
+		report = Report{Status: StatusReady, Checks: make(map[string]string, len(r.checks))}
+	)
+	for _, check := range r.checks {
+		check := check
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := StatusOK
+			if err := check.Check(ctx); err != nil {
+				result = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result != StatusOK {
+				ready = false
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !ready {
+		report.Status = StatusNotReady
+	}
+	return report, ready
+}
@@ -0,0 +1,52 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type backendStub struct {
+	pingErr error
+}
+
+func (b backendStub) Ping(ctx context.Context) error {
+	return b.pingErr
+}
+
+func TestReadiness(t *testing.T) {
+	t.Run("Should be ready when every check passes", func(t *testing.T) {
+		readiness := NewReadiness(0, RepositoryCheck("accounts", backendStub{}))
+
+		report, ready := readiness.Check(context.Background())
+
+		assert.True(t, ready)
+		assert.Equal(t, Report{Status: StatusReady, Checks: map[string]string{"accounts": StatusOK}}, report)
+	})
+
+	t.Run("Should not be ready when the backend is unreachable", func(t *testing.T) {
+		readiness := NewReadiness(0,
+			RepositoryCheck("accounts", backendStub{pingErr: errors.New("connection refused")}),
+			RepositoryCheck("customers", backendStub{}),
+		)
+
+		report, ready := readiness.Check(context.Background())
+
+		assert.False(t, ready)
+		assert.Equal(t, StatusNotReady, report.Status)
+		assert.Equal(t, "connection refused", report.Checks["accounts"])
+		assert.Equal(t, StatusOK, report.Checks["customers"])
+	})
+
+	t.Run("Should not be ready once shutting down", func(t *testing.T) {
+		readiness := NewReadiness(0, RepositoryCheck("accounts", backendStub{}))
+
+		readiness.ShutDown()
+		report, ready := readiness.Check(context.Background())
+
+		assert.False(t, ready)
+		assert.Equal(t, StatusShuttingDown, report.Status)
+	})
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/usecase/apikey"
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+type APIKeyAuthenticator struct {
+	authenticateAPIKeyUseCase *apikey.AuthenticateAPIKeyUseCase
+}
+
+func NewAPIKeyAuthenticator(authenticateAPIKeyUseCase *apikey.AuthenticateAPIKeyUseCase) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{authenticateAPIKeyUseCase: authenticateAPIKeyUseCase}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, header http.Header) (*Principal, error) {
+	secret := header.Get(HeaderAPIKey)
+	if secret == "" {
+		return nil, nil
+	}
+
+	output, err := a.authenticateAPIKeyUseCase.Execute(ctx, apikey.AuthenticateAPIKeyInputDTO{
+		Secret: secret,
+	})
+	if errors.Is(err, apikey.ErrAuthenticateAPIKeyInvalidKey) {
+		return nil, errors.Join(ErrInvalidCredentials, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]entity.Scope, 0, len(output.Key.Scopes))
+	for _, scope := range output.Key.Scopes {
+		scopes = append(scopes, entity.Scope(scope))
+	}
+
+	return &Principal{
+		Subject:    "api-key:" + output.Key.ID,
+		Scopes:     scopes,
+		AccountIDs: output.Key.AccountIDs,
+		Restricted: len(output.Key.AccountIDs) > 0,
+		CustomerID: output.Key.CustomerID,
+	}, nil
+}
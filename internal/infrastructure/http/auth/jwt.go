@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// JWTClaims are the claims read from bearer tokens. Accounts lists the only
+// accounts the caller may operate on, Scope holds space separated scopes and
+// CustomerID the customer the caller acts on behalf of.
+type JWTClaims struct {
+	jwt.RegisteredClaims
+	Scope      string   `json:"scope,omitempty"`
+	Accounts   []string `json:"accounts"`
+	CustomerID string   `json:"customer_id,omitempty"`
+}
+
+type JWTOptions struct {
+	Issuer   string
+	Audience string
+	// DefaultScopes are granted to tokens without a scope claim.
+	DefaultScopes []entity.Scope
+}
+
+type JWTAuthenticator struct {
+	keys    *JWTKeySet
+	options JWTOptions
+	parser  *jwt.Parser
+}
+
+func NewJWTAuthenticator(keys *JWTKeySet, options JWTOptions) *JWTAuthenticator {
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if options.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(options.Issuer))
+	}
+	if options.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(options.Audience))
+	}
+
+	return &JWTAuthenticator{
+		keys:    keys,
+		options: options,
+		parser:  jwt.NewParser(parserOptions...),
+	}
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, header http.Header) (*Principal, error) {
+	token, ok := strings.CutPrefix(header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok {
+		return nil, nil
+	}
+
+	var claims JWTClaims
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.keys.keyFunc); err != nil {
+		return nil, errors.Join(ErrInvalidCredentials, err)
+	}
+
+	scopes := a.options.DefaultScopes
+	if claims.Scope != "" {
+		scopes = nil
+		for _, scope := range strings.Fields(claims.Scope) {
+			scopes = append(scopes, entity.Scope(scope))
+		}
+	}
+
+	return &Principal{
+		Subject:    "jwt:" + claims.Subject,
+		Scopes:     scopes,
+		AccountIDs: claims.Accounts,
+		Restricted: true,
+		CustomerID: claims.CustomerID,
+	}, nil
+}
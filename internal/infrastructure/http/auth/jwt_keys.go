@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidJWKS = errors.New("Invalid JWKS")
+
+// JWTKeySet holds the keys tokens may be signed with, indexed by key ID. Keys
+// added without an ID verify tokens that do not carry a kid header.
+type JWTKeySet struct {
+	hmac map[string][]byte
+	rsa  map[string]*rsa.PublicKey
+}
+
+func NewJWTKeySet() *JWTKeySet {
+	return &JWTKeySet{
+		hmac: make(map[string][]byte),
+		rsa:  make(map[string]*rsa.PublicKey),
+	}
+}
+
+func (s *JWTKeySet) AddHMAC(kid string, secret []byte) {
+	s.hmac[kid] = secret
+}
+
+func (s *JWTKeySet) AddRSA(kid string, key *rsa.PublicKey) {
+	s.rsa[kid] = key
+}
+
+func (s *JWTKeySet) AddRSAPEM(kid string, data []byte) error {
+	key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+	if err != nil {
+		return err
+	}
+
+	s.AddRSA(kid, key)
+	return nil
+}
+
+func (s *JWTKeySet) IsEmpty() bool {
+	return len(s.hmac) == 0 && len(s.rsa) == 0
+}
+
+func (s *JWTKeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		if secret, ok := s.hmac[kid]; ok {
+			return secret, nil
+		}
+	case jwt.SigningMethodRS256.Alg():
+		if key, ok := s.rsa[kid]; ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s key with kid %q", token.Method.Alg(), kid)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// LoadJWKS reads a JSON Web Key Set file with RSA and symmetric (oct) keys.
+func LoadJWKS(path string) (*JWTKeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+func ParseJWKS(data []byte) (*JWTKeySet, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, errors.Join(ErrInvalidJWKS, err)
+	}
+
+	keys := NewJWTKeySet()
+	for _, key := range document.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		switch key.Kty {
+		case "RSA":
+			publicKey, err := parseRSAKey(key)
+			if err != nil {
+				return nil, errors.Join(ErrInvalidJWKS, fmt.Errorf("key %q: %w", key.Kid, err))
+			}
+			keys.AddRSA(key.Kid, publicKey)
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(key.K)
+			if err != nil || len(secret) == 0 {
+				return nil, errors.Join(ErrInvalidJWKS, fmt.Errorf("key %q: invalid k", key.Kid))
+			}
+			keys.AddHMAC(key.Kid, secret)
+		default:
+			return nil, errors.Join(ErrInvalidJWKS, fmt.Errorf("key %q: unsupported kty %q", key.Kid, key.Kty))
+		}
+	}
+	return keys, nil
+}
+
+func parseRSAKey(key jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid n")
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid e")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
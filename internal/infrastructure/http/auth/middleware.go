@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrUnauthenticated    = errors.New("Authentication required")
+	ErrInvalidCredentials = errors.New("Invalid credentials")
+	ErrInsufficientScope  = errors.New("Credential lacks the required scope")
+	ErrAccountForbidden   = errors.New("Credential is not allowed to access the account")
+)
+
+type Authenticator interface {
+	// Authenticate returns a nil principal and no error when header carries
+	// no credential handled by the authenticator. It only reads the headers,
+	// so gRPC metadata can be authenticated the same way.
+	Authenticate(ctx context.Context, header http.Header) (*Principal, error)
+}
+
+// Middleware resolves the principal of every request with the first
+// authenticator recognising its credentials. Without authenticators every
+// request runs as Anonymous.
+func Middleware(authenticators ...Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			principal, err := Authenticate(req.Context(), req.Header, authenticators...)
+			if err != nil {
+				return err
+			}
+
+			if principal != nil {
+				c.SetRequest(req.WithContext(WithPrincipal(req.Context(), principal)))
+			}
+			return next(c)
+		}
+	}
+}
+
+// Authenticate resolves the principal of header with the first authenticator
+// recognising its credentials, it is nil when none does. Without
+// authenticators it is Anonymous.
+func Authenticate(ctx context.Context, header http.Header, authenticators ...Authenticator) (*Principal, error) {
+	if len(authenticators) == 0 {
+		return Anonymous, nil
+	}
+
+	for _, authenticator := range authenticators {
+		principal, err := authenticator.Authenticate(ctx, header)
+		if err != nil || principal != nil {
+			return principal, err
+		}
+	}
+	return nil, nil
+}
+
+func RequireScope(scope entity.Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := PrincipalFromContext(c.Request().Context())
+			if principal == nil {
+				return ErrUnauthenticated
+			}
+
+			if !principal.HasScope(scope) {
+				return ErrInsufficientScope
+			}
+			return next(c)
+		}
+	}
+}
+
+// CheckScopes fails when the principal of the request lacks one of the given
+// scopes, so it cannot grant more than it holds.
+func CheckScopes(c echo.Context, scopes ...entity.Scope) error {
+	principal := PrincipalFromContext(c.Request().Context())
+	if principal == nil {
+		return nil
+	}
+
+	for _, scope := range scopes {
+		if !principal.HasScope(scope) {
+			return ErrInsufficientScope
+		}
+	}
+	return nil
+}
+
+// CheckAccount fails when the principal of the request is restricted to
+// other accounts than the given ones.
+func CheckAccount(c echo.Context, accountIDs ...string) error {
+	principal := PrincipalFromContext(c.Request().Context())
+	if principal == nil {
+		return nil
+	}
+
+	for _, accountID := range accountIDs {
+		if !principal.CanAccessAccount(accountID) {
+			return ErrAccountForbidden
+		}
+	}
+	return nil
+}
+
+// CheckUnrestricted fails when the principal of the request is restricted to
+// some accounts, for operations spanning every account.
+func CheckUnrestricted(c echo.Context) error {
+	principal := PrincipalFromContext(c.Request().Context())
+	if principal != nil && principal.IsRestricted() {
+		return ErrAccountForbidden
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"slices"
+)
+
+type principalKey struct{}
+
+// Principal is the authenticated caller of a request. A Restricted principal
+// may only act on its AccountIDs. A principal with a CustomerID acts on
+// behalf of that customer, its transfers must come from accounts the
+// customer owns.
+type Principal struct {
+	Subject    string
+	Scopes     []entity.Scope
+	AccountIDs []string
+	Restricted bool
+	CustomerID string
+	anonymous  bool
+}
+
+// Anonymous is used for every request when authentication is disabled.
+var Anonymous = &Principal{Subject: "anonymous", anonymous: true}
+
+func (p *Principal) HasScope(scope entity.Scope) bool {
+	return p.anonymous || slices.Contains(p.Scopes, scope)
+}
+
+func (p *Principal) CanAccessAccount(accountID string) bool {
+	return !p.IsRestricted() || slices.Contains(p.AccountIDs, accountID)
+}
+
+func (p *Principal) IsRestricted() bool {
+	return !p.anonymous && p.Restricted
+}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
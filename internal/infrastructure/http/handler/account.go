@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/shared/dto"
+	usecase "simple-bank/internal/usecase/account"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AccountHandler struct {
+	listAccountsUseCase *usecase.ListAccountsUseCase
+}
+
+type ListAccountsResponse struct {
+	Accounts   []dto.AccountDetailsDTO `json:"accounts"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+func NewAccountHandler(listAccountsUseCase *usecase.ListAccountsUseCase) *AccountHandler {
+	return &AccountHandler{listAccountsUseCase: listAccountsUseCase}
+}
+
+func (h *AccountHandler) ListAccounts(c echo.Context) error {
+	if err := auth.CheckUnrestricted(c); err != nil {
+		return err
+	}
+
+	input := usecase.ListAccountsInputDTO{
+		SortBy:   c.QueryParam("sort"),
+		Order:    c.QueryParam("order"),
+		Cursor:   c.QueryParam("cursor"),
+		Status:   c.QueryParam("status"),
+		IDPrefix: c.QueryParam("id_prefix"),
+	}
+
+	var fields []problem.FieldError
+	var err error
+	if input.Limit, err = queryInt(c, "limit"); err != nil {
+		fields = append(fields, problem.FieldError{Field: "limit", Message: "must be an integer"})
+	}
+	if input.MinBalance, err = queryOptionalInt(c, "min_balance"); err != nil {
+		fields = append(fields, problem.FieldError{Field: "min_balance", Message: "must be an integer"})
+	}
+	if input.MaxBalance, err = queryOptionalInt(c, "max_balance"); err != nil {
+		fields = append(fields, problem.FieldError{Field: "max_balance", Message: "must be an integer"})
+	}
+	if len(fields) > 0 {
+		return problem.NewValidationError(fields...)
+	}
+
+	output, err := h.listAccountsUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ListAccountsResponse{
+		Accounts:   output.Accounts,
+		NextCursor: output.NextCursor,
+	})
+}
+
+func (h *AccountHandler) Setup(e *echo.Echo) {
+	e.GET("/accounts", h.ListAccounts, auth.RequireScope(entity.ScopeBalanceRead))
+}
+
+func queryInt(c echo.Context, name string) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func queryOptionalInt(c echo.Context, name string) (*int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/shared/logging"
+	usecase "simple-bank/internal/usecase/account"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuditRecorder records the actions handled by a request on an audit log,
+// filling in who performed them, from where and how they ended. A nil
+// recorder records nothing.
+type AuditRecorder struct {
+	logger audit.Logger
+	now    func() time.Time
+}
+
+func NewAuditRecorder(logger audit.Logger) *AuditRecorder {
+	if logger == nil {
+		return nil
+	}
+	return &AuditRecorder{logger: logger, now: time.Now}
+}
+
+// Record completes entry with the request and the outcome of err. Failing to
+// audit does not fail the request, it is logged instead.
+func (r *AuditRecorder) Record(c echo.Context, entry audit.Entry, err error) {
+	if r == nil {
+		return
+	}
+
+	ctx := c.Request().Context()
+	entry.Time = r.now().UTC()
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		entry.Actor = principal.Subject
+	}
+	entry.IP = clientIP(c)
+	entry.RequestID = logging.RequestIDFromContext(ctx)
+	if entry.Outcome == "" {
+		entry.Outcome, entry.Detail = AuditOutcome(err)
+	}
+
+	if err := r.logger.Record(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "fail to audit "+entry.Action, logging.KeyError, err.Error())
+	}
+}
+
+// clientIP is the address of the client as told by the IP extractor of the
+// server, which only trusts the forwarding headers of known proxies. Without
+// one it is the peer of the connection, never a header the client chose.
+func clientIP(c echo.Context) string {
+	if extract := c.Echo().IPExtractor; extract != nil {
+		return extract(c.Request())
+	}
+	return echo.ExtractIPDirect()(c.Request())
+}
+
+// AuditOutcome classifies err the way it is exposed to clients: denied
+// credentials, rejected requests or server failures.
+func AuditOutcome(err error) (outcome, detail string) {
+	if err == nil {
+		return audit.OutcomeSuccess, ""
+	}
+
+	p := problem.FromError(err)
+	switch {
+	case p.Status == http.StatusUnauthorized || p.Status == http.StatusForbidden:
+		return audit.OutcomeDenied, p.Code
+	case p.Class() == problem.ClassServer:
+		return audit.OutcomeFailure, p.Code
+	default:
+		return audit.OutcomeRejected, p.Code
+	}
+}
+
+func DepositBalances(output *usecase.DepositOutputDTO) []audit.BalanceChange {
+	return []audit.BalanceChange{
+		{Account: output.Destination.ID, Before: output.DestinationPreviousBalance, After: output.Destination.Balance},
+	}
+}
+
+func WithdrawBalances(output *usecase.WithdrawOutputDTO) []audit.BalanceChange {
+	return []audit.BalanceChange{
+		{Account: output.Origin.ID, Before: output.OriginPreviousBalance, After: output.Origin.Balance},
+	}
+}
+
+func TransferBalances(output *usecase.TransferOutputDTO) []audit.BalanceChange {
+	return []audit.BalanceChange{
+		{Account: output.Origin.ID, Before: output.OriginPreviousBalance, After: output.Origin.Balance},
+		{Account: output.Destination.ID, Before: output.DestinationPreviousBalance, After: output.Destination.Balance},
+	}
+}
@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"errors"
-	"net/http"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/http/auth"
 	usecase "simple-bank/internal/usecase/account"
 	"strconv"
 
@@ -19,18 +19,18 @@ func NewBalanceHandler(getBalanceUseCase *usecase.GetBalanceUseCase) *BalanceHan
 
 func (h *BalanceHandler) GetBalance(c echo.Context) error {
 	accountID := c.QueryParam("account_id")
+	if err := auth.CheckAccount(c, accountID); err != nil {
+		return err
+	}
 
-	balance, err := h.getBalanceUseCase.Execute(usecase.GetBalanceInputDTO{ID: accountID})
+	balance, err := h.getBalanceUseCase.Execute(c.Request().Context(), usecase.GetBalanceInputDTO{ID: accountID})
 	if err != nil {
-		if errors.Is(err, usecase.ErrGetBalanceAccountNotExists) {
-			return c.String(404, "0")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.String(200, strconv.Itoa(balance.Balance))
 }
 
 func (h *BalanceHandler) Setup(e *echo.Echo) {
-	e.GET("/balance", h.GetBalance)
+	e.GET("/balance", h.GetBalance, auth.RequireScope(entity.ScopeBalanceRead))
 }
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/shared/dto"
+	usecase "simple-bank/internal/usecase/customer"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CustomerHandler struct {
+	createCustomerUseCase       *usecase.CreateCustomerUseCase
+	getCustomerUseCase          *usecase.GetCustomerUseCase
+	updateCustomerUseCase       *usecase.UpdateCustomerUseCase
+	deleteCustomerUseCase       *usecase.DeleteCustomerUseCase
+	listCustomerAccountsUseCase *usecase.ListCustomerAccountsUseCase
+	linkAccountUseCase          *usecase.LinkAccountUseCase
+	unlinkAccountUseCase        *usecase.UnlinkAccountUseCase
+	setKYCStatusUseCase         *usecase.SetKYCStatusUseCase
+}
+
+type CustomerRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+type KYCStatusRequest struct {
+	KYCStatus string `json:"kyc_status"`
+}
+
+type LinkAccountRequest struct {
+	AccountID string `json:"account_id"`
+}
+
+type CustomerAccountsResponse struct {
+	Accounts []dto.AccountDetailsDTO `json:"accounts"`
+}
+
+func NewCustomerHandler(
+	createCustomerUseCase *usecase.CreateCustomerUseCase,
+	getCustomerUseCase *usecase.GetCustomerUseCase,
+	updateCustomerUseCase *usecase.UpdateCustomerUseCase,
+	deleteCustomerUseCase *usecase.DeleteCustomerUseCase,
+	listCustomerAccountsUseCase *usecase.ListCustomerAccountsUseCase,
+	linkAccountUseCase *usecase.LinkAccountUseCase,
+	unlinkAccountUseCase *usecase.UnlinkAccountUseCase,
+	setKYCStatusUseCase *usecase.SetKYCStatusUseCase,
+) *CustomerHandler {
+	return &CustomerHandler{
+		createCustomerUseCase:       createCustomerUseCase,
+		getCustomerUseCase:          getCustomerUseCase,
+		updateCustomerUseCase:       updateCustomerUseCase,
+		deleteCustomerUseCase:       deleteCustomerUseCase,
+		listCustomerAccountsUseCase: listCustomerAccountsUseCase,
+		linkAccountUseCase:          linkAccountUseCase,
+		unlinkAccountUseCase:        unlinkAccountUseCase,
+		setKYCStatusUseCase:         setKYCStatusUseCase,
+	}
+}
+
+func (h *CustomerHandler) CreateCustomer(c echo.Context) error {
+	var request CustomerRequest
+	if err := c.Bind(&request); err != nil {
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+
+	output, err := h.createCustomerUseCase.Execute(c.Request().Context(), usecase.CreateCustomerInputDTO{
+		Name:  request.Name,
+		Email: request.Email,
+		Phone: request.Phone,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, output.Customer)
+}
+
+func (h *CustomerHandler) GetCustomer(c echo.Context) error {
+	if err := h.checkCustomer(c); err != nil {
+		return err
+	}
+
+	output, err := h.getCustomerUseCase.Execute(c.Request().Context(), usecase.GetCustomerInputDTO{
+		ID: c.Param("id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, output.Customer)
+}
+
+func (h *CustomerHandler) UpdateCustomer(c echo.Context) error {
+	var request CustomerRequest
+	if err := c.Bind(&request); err != nil {
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+	if err := h.checkCustomer(c); err != nil {
+		return err
+	}
+
+	output, err := h.updateCustomerUseCase.Execute(c.Request().Context(), usecase.UpdateCustomerInputDTO{
+		ID:    c.Param("id"),
+		Name:  request.Name,
+		Email: request.Email,
+		Phone: request.Phone,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, output.Customer)
+}
+
+func (h *CustomerHandler) DeleteCustomer(c echo.Context) error {
+	if err := h.checkCustomer(c); err != nil {
+		return err
+	}
+
+	err := h.deleteCustomerUseCase.Execute(c.Request().Context(), usecase.DeleteCustomerInputDTO{
+		ID: c.Param("id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CustomerHandler) ListCustomerAccounts(c echo.Context) error {
+	if err := h.checkCustomer(c); err != nil {
+		return err
+	}
+
+	output, err := h.listCustomerAccountsUseCase.Execute(c.Request().Context(), usecase.ListCustomerAccountsInputDTO{
+		CustomerID: c.Param("id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, account := range output.Accounts {
+		if err := auth.CheckAccount(c, account.ID); err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusOK, CustomerAccountsResponse{Accounts: output.Accounts})
+}
+
+func (h *CustomerHandler) LinkAccount(c echo.Context) error {
+	var request LinkAccountRequest
+	if err := c.Bind(&request); err != nil {
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+	if err := auth.CheckAccount(c, request.AccountID); err != nil {
+		return err
+	}
+	if err := h.checkCustomer(c); err != nil {
+		return err
+	}
+
+	output, err := h.linkAccountUseCase.Execute(c.Request().Context(), usecase.LinkAccountInputDTO{
+		CustomerID: c.Param("id"),
+		AccountID:  request.AccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, output.Customer)
+}
+
+func (h *CustomerHandler) UnlinkAccount(c echo.Context) error {
+	if err := auth.CheckAccount(c, c.Param("account_id")); err != nil {
+		return err
+	}
+	if err := h.checkCustomer(c); err != nil {
+		return err
+	}
+
+	err := h.unlinkAccountUseCase.Execute(c.Request().Context(), usecase.UnlinkAccountInputDTO{
+		CustomerID: c.Param("id"),
+		AccountID:  c.Param("account_id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SetKYCStatus records the identity verification of a customer, it is
+// reserved to the back office.
+func (h *CustomerHandler) SetKYCStatus(c echo.Context) error {
+	var request KYCStatusRequest
+	if err := c.Bind(&request); err != nil {
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+	if err := auth.CheckUnrestricted(c); err != nil {
+		return err
+	}
+
+	output, err := h.setKYCStatusUseCase.Execute(c.Request().Context(), usecase.SetKYCStatusInputDTO{
+		ID:        c.Param("id"),
+		KYCStatus: request.KYCStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, output.Customer)
+}
+
+// checkCustomer lets a restricted principal act on the customer of the route
+// when it acts on behalf of that customer or may access one of its accounts.
+// Unknown customers are refused alike, so they cannot be told apart.
+func (h *CustomerHandler) checkCustomer(c echo.Context) error {
+	principal := auth.PrincipalFromContext(c.Request().Context())
+	if principal == nil || !principal.IsRestricted() || principal.CustomerID == c.Param("id") {
+		return nil
+	}
+
+	output, err := h.getCustomerUseCase.Execute(c.Request().Context(), usecase.GetCustomerInputDTO{
+		ID: c.Param("id"),
+	})
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return auth.ErrAccountForbidden
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, accountID := range output.Customer.AccountIDs {
+		if principal.CanAccessAccount(accountID) {
+			return nil
+		}
+	}
+	return auth.ErrAccountForbidden
+}
+
+func (h *CustomerHandler) Setup(e *echo.Echo) {
+	read := auth.RequireScope(entity.ScopeCustomerRead)
+	write := auth.RequireScope(entity.ScopeCustomerWrite)
+
+	e.POST("/customers", h.CreateCustomer, write)
+	e.GET("/customers/:id", h.GetCustomer, read)
+	e.PUT("/customers/:id", h.UpdateCustomer, write)
+	e.DELETE("/customers/:id", h.DeleteCustomer, write)
+	e.GET("/customers/:id/accounts", h.ListCustomerAccounts, read)
+	e.POST("/customers/:id/accounts", h.LinkAccount, write)
+	e.DELETE("/customers/:id/accounts/:account_id", h.UnlinkAccount, write)
+	e.PUT("/customers/:id/kyc", h.SetKYCStatus, auth.RequireScope(entity.ScopeAdminKYC))
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+	"simple-bank/internal/infrastructure/http/openapi"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DocsHandler struct{}
+
+func NewDocsHandler() *DocsHandler {
+	return &DocsHandler{}
+}
+
+func (h *DocsHandler) OpenAPI(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, openapi.Spec)
+}
+
+func (h *DocsHandler) Docs(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, openapi.DocsPage)
+}
+
+func (h *DocsHandler) Setup(e *echo.Echo) {
+	e.GET("/openapi.json", h.OpenAPI)
+	e.GET("/docs", h.Docs)
+	e.GET("/docs/swagger-ui.css", echo.StaticFileHandler("swagger-ui/swagger-ui.css", openapi.SwaggerUI))
+	e.GET("/docs/swagger-ui-bundle.js", echo.StaticFileHandler("swagger-ui/swagger-ui-bundle.js", openapi.SwaggerUI))
+}
@@ -1,9 +1,15 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
 	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
 	usecase "simple-bank/internal/usecase/account"
 
 	"github.com/labstack/echo/v4"
@@ -13,13 +19,15 @@ type EventHandler struct {
 	depositUseCase  *usecase.DepositUseCase
 	withdrawUseCase *usecase.WithdrawUseCase
 	transferUseCase *usecase.TransferUseCase
+	audit           *AuditRecorder
 }
 
 type HandleEventRequest struct {
-	Type        string `json:"type"`
-	Destination string `json:"destination"`
-	Amount      int    `json:"amount"`
-	Origin      string `json:"origin"`
+	Type        string `json:"type" validate:"required,oneof=deposit withdraw transfer"`
+	Destination string `json:"destination" validate:"required_unless=Type withdraw,omitempty,account_id,nefield=Origin"`
+	Amount      int    `json:"amount" validate:"gt=0"`
+	Origin      string `json:"origin" validate:"required_unless=Type deposit,omitempty,account_id"`
+	Product     string `json:"product,omitempty"`
 }
 
 type HandleEventResponse struct {
@@ -39,58 +47,119 @@ func NewEventHandler(
 	}
 }
 
-func (h *EventHandler) HandleEvent(c echo.Context) error {
+// WithAudit records every event, including the rejected ones, on logger.
+func (h *EventHandler) WithAudit(logger audit.Logger) *EventHandler {
+	h.audit = NewAuditRecorder(logger)
+	return h
+}
+
+func (h *EventHandler) HandleEvent(c echo.Context) (err error) {
+	ctx, span := tracing.Start(c.Request().Context(), "EventHandler.HandleEvent")
+	c.SetRequest(c.Request().WithContext(ctx))
+
 	var request HandleEventRequest
+	var balances []audit.BalanceChange
+	defer func() {
+		outcome := "success"
+		if err != nil {
+			outcome = problem.FromError(err).Code
+		}
+		span.SetAttributes(tracing.KeyOutcome.String(outcome))
+		tracing.End(span, &err)
+		h.audit.Record(c, audit.Entry{Action: eventAction(request.Type), Balances: balances}, err)
+	}()
+
 	if err := c.Bind(&request); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+	span.SetAttributes(tracing.KeyEventType.String(request.Type))
+
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, balances, err := h.execute(c.Request().Context(), c, request)
+	if err != nil {
+		return err
 	}
 
+	return c.JSON(http.StatusCreated, response)
+}
+
+// execute runs a validated request on behalf of the principal of c, in ctx.
+// It returns the balances it changed for the audit log.
+func (h *EventHandler) execute(
+	ctx context.Context,
+	c echo.Context,
+	request HandleEventRequest,
+) (*HandleEventResponse, []audit.BalanceChange, error) {
 	switch request.Type {
 	case "deposit":
-		output, err := h.depositUseCase.Execute(usecase.DepositInputDTO{
+		if err := auth.CheckAccount(c, request.Destination); err != nil {
+			return nil, nil, err
+		}
+
+		output, err := h.depositUseCase.Execute(ctx, usecase.DepositInputDTO{
 			Destination: request.Destination,
 			Amount:      request.Amount,
+			Product:     request.Product,
 		})
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			return nil, nil, err
 		}
 
-		return c.JSON(http.StatusCreated, HandleEventResponse{Destination: &output.Destination})
+		return &HandleEventResponse{Destination: &output.Destination}, DepositBalances(output), nil
 	case "withdraw":
-		output, err := h.withdrawUseCase.Execute(usecase.WithdrawInputDTO{
+		if err := auth.CheckAccount(c, request.Origin); err != nil {
+			return nil, nil, err
+		}
+
+		output, err := h.withdrawUseCase.Execute(ctx, usecase.WithdrawInputDTO{
 			Origin: request.Origin,
 			Amount: request.Amount,
 		})
 		if err != nil {
-			if errors.Is(err, usecase.ErrWithdrawAccountNotExists) {
-				return c.String(http.StatusNotFound, "0")
-			}
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			return nil, nil, err
 		}
 
-		return c.JSON(http.StatusCreated, HandleEventResponse{Origin: &output.Origin})
+		return &HandleEventResponse{Origin: &output.Origin}, WithdrawBalances(output), nil
 	case "transfer":
-		output, err := h.transferUseCase.Execute(usecase.TransferInputDTO{
+		if err := auth.CheckAccount(c, request.Origin); err != nil {
+			return nil, nil, err
+		}
+
+		output, err := h.transferUseCase.Execute(ctx, usecase.TransferInputDTO{
 			Origin:      request.Origin,
 			Destination: request.Destination,
 			Amount:      request.Amount,
+			CustomerID:  auth.PrincipalFromContext(ctx).CustomerID,
 		})
-
 		if err != nil {
-			if errors.Is(err, usecase.ErrTransferOriginAccountNotExists) {
-				return c.String(http.StatusNotFound, "0")
-			}
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			return nil, nil, err
 		}
-		return c.JSON(http.StatusCreated, HandleEventResponse{
+
+		return &HandleEventResponse{
 			Origin:      &output.Origin,
 			Destination: &output.Destination,
-		})
+		}, TransferBalances(output), nil
+	default:
+		return nil, nil, problem.ErrInvalidEventType
+	}
+}
+
+func eventAction(eventType string) string {
+	switch eventType {
+	case "deposit":
+		return audit.ActionDeposit
+	case "withdraw":
+		return audit.ActionWithdraw
+	case "transfer":
+		return audit.ActionTransfer
 	default:
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid event type")
+		return audit.ActionInvalidEvent
 	}
 }
 
 func (h *EventHandler) Setup(e *echo.Echo) {
-	e.POST("/event", h.HandleEvent)
+	e.POST("/event", h.HandleEvent, auth.RequireScope(entity.ScopeEventWrite))
 }
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"simple-bank/internal/shared/dto"
+	usecase "simple-bank/internal/usecase/product"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProductHandler struct {
+	listProductsUseCase *usecase.ListProductsUseCase
+}
+
+type ListProductsResponse struct {
+	Products []dto.ProductDTO `json:"products"`
+}
+
+func NewProductHandler(listProductsUseCase *usecase.ListProductsUseCase) *ProductHandler {
+	return &ProductHandler{listProductsUseCase: listProductsUseCase}
+}
+
+func (h *ProductHandler) ListProducts(c echo.Context) error {
+	output, err := h.listProductsUseCase.Execute(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ListProductsResponse{Products: output.Products})
+}
+
+func (h *ProductHandler) Setup(e *echo.Echo) {
+	e.GET("/products", h.ListProducts)
+}
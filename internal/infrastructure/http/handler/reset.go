@@ -1,28 +1,60 @@
 package handlers
 
 import (
+	"crypto/subtle"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
 	usecase "simple-bank/internal/usecase/account"
 
 	"github.com/labstack/echo/v4"
 )
 
+const HeaderResetConfirmation = "X-Reset-Confirmation"
+
+// ResetConfig guards the reset route: callers must send ConfirmationToken in
+// the X-Reset-Confirmation header and every attempt is recorded on Audit.
+type ResetConfig struct {
+	ConfirmationToken string
+	Audit             audit.Logger
+}
+
 type ResetHandler struct {
 	resetUseCase *usecase.ResetUseCase
+	config       ResetConfig
+	audit        *AuditRecorder
 }
 
-func NewResetHandler(resetUseCase *usecase.ResetUseCase) *ResetHandler {
-	return &ResetHandler{resetUseCase: resetUseCase}
+func NewResetHandler(resetUseCase *usecase.ResetUseCase, config ResetConfig) *ResetHandler {
+	return &ResetHandler{resetUseCase: resetUseCase, config: config, audit: NewAuditRecorder(config.Audit)}
 }
 
 func (h *ResetHandler) Reset(c echo.Context) error {
-	err := h.resetUseCase.Execute()
+	if !h.confirmed(c) {
+		h.audit.Record(c, audit.Entry{
+			Action:  audit.ActionReset,
+			Outcome: audit.OutcomeDenied,
+			Detail:  "missing or invalid confirmation token",
+		}, nil)
+		return problem.ErrResetNotConfirmed
+	}
+
+	err := h.resetUseCase.Execute(c.Request().Context())
+	h.audit.Record(c, audit.Entry{Action: audit.ActionReset}, err)
 	if err != nil {
-		return echo.NewHTTPError(500, err.Error())
+		return err
 	}
 
 	return c.String(200, "OK")
 }
 
+func (h *ResetHandler) confirmed(c echo.Context) bool {
+	token := c.Request().Header.Get(HeaderResetConfirmation)
+	return h.config.ConfirmationToken != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(h.config.ConfirmationToken)) == 1
+}
+
 func (h *ResetHandler) Setup(e *echo.Echo) {
-	e.POST("/reset", h.Reset)
+	e.POST("/reset", h.Reset, auth.RequireScope(entity.ScopeAdminReset))
 }
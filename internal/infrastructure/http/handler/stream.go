@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/stream"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderLastEventID = "Last-Event-ID"
+
+	// DefaultHeartbeat is how often an idle stream sends a comment, so proxies
+	// do not close it.
+	DefaultHeartbeat = 15 * time.Second
+)
+
+// StreamHandler pushes balance changes as Server-Sent Events.
+type StreamHandler struct {
+	broker    *stream.Broker
+	heartbeat time.Duration
+}
+
+func NewStreamHandler(broker *stream.Broker) *StreamHandler {
+	return &StreamHandler{broker: broker, heartbeat: DefaultHeartbeat}
+}
+
+// WithHeartbeat sets how often an idle stream sends a comment.
+func (h *StreamHandler) WithHeartbeat(interval time.Duration) *StreamHandler {
+	h.heartbeat = interval
+	return h
+}
+
+func (h *StreamHandler) AccountStream(c echo.Context) error {
+	accountID := c.Param("id")
+	if err := auth.CheckAccount(c, accountID); err != nil {
+		return err
+	}
+
+	return h.serve(c, accountID)
+}
+
+func (h *StreamHandler) AdminStream(c echo.Context) error {
+	if err := auth.CheckUnrestricted(c); err != nil {
+		return err
+	}
+
+	return h.serve(c, "")
+}
+
+// serve streams the changes of account until the client goes away or the
+// broker drops the subscription, starting with the buffered changes following
+// the Last-Event-ID header, or a reset event when they are lost.
+func (h *StreamHandler) serve(c echo.Context, account string) error {
+	replay, subscription := h.broker.Subscribe(account, c.Request().Header.Get(HeaderLastEventID))
+	defer subscription.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	for _, message := range replay {
+		if err := writeMessage(res, message); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-subscription.C:
+			if !ok {
+				return nil
+			}
+			if err := writeMessage(res, message); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+func writeMessage(res *echo.Response, message stream.Message) error {
+	if message.Reset {
+		_, err := fmt.Fprintf(res, "id: %s\nevent: reset\ndata: {}\n\n", message.ID)
+		return err
+	}
+
+	data, err := json.Marshal(message.Change)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(res, "id: %s\nevent: balance\ndata: %s\n\n", message.ID, data)
+	return err
+}
+
+func (h *StreamHandler) Setup(e *echo.Echo) {
+	e.GET("/accounts/:id/stream", h.AccountStream, auth.RequireScope(entity.ScopeBalanceRead))
+	e.GET("/admin/stream", h.AdminStream, auth.RequireScope(entity.ScopeAdminStream))
+}
@@ -0,0 +1,148 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/http/auth"
+	handlers "simple-bank/internal/infrastructure/http/handler"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/shared/dto"
+	usecase "simple-bank/internal/usecase/account"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AccountHandler struct {
+	getAccountUseCase *usecase.GetAccountUseCase
+	depositUseCase    *usecase.DepositUseCase
+	withdrawUseCase   *usecase.WithdrawUseCase
+	audit             *handlers.AuditRecorder
+}
+
+type DepositRequest struct {
+	Account string `param:"id" json:"-" validate:"required,account_id"`
+	Amount  int    `json:"amount" validate:"gt=0"`
+	Product string `json:"product,omitempty"`
+}
+
+type DepositResponse struct {
+	Account dto.AccountDTO `json:"account"`
+}
+
+type WithdrawalRequest struct {
+	Account string `param:"id" json:"-" validate:"required,account_id"`
+	Amount  int    `json:"amount" validate:"gt=0"`
+}
+
+type WithdrawalResponse struct {
+	Account dto.AccountDTO `json:"account"`
+	Amount  int            `json:"amount"`
+	Fee     int            `json:"fee"`
+}
+
+func NewAccountHandler(
+	getAccountUseCase *usecase.GetAccountUseCase,
+	depositUseCase *usecase.DepositUseCase,
+	withdrawUseCase *usecase.WithdrawUseCase,
+) *AccountHandler {
+	return &AccountHandler{
+		getAccountUseCase: getAccountUseCase,
+		depositUseCase:    depositUseCase,
+		withdrawUseCase:   withdrawUseCase,
+	}
+}
+
+// WithAudit records every deposit and withdrawal on logger.
+func (h *AccountHandler) WithAudit(logger audit.Logger) *AccountHandler {
+	h.audit = handlers.NewAuditRecorder(logger)
+	return h
+}
+
+func (h *AccountHandler) GetAccount(c echo.Context) error {
+	if err := auth.CheckAccount(c, c.Param("id")); err != nil {
+		return err
+	}
+
+	output, err := h.getAccountUseCase.Execute(c.Request().Context(), usecase.GetAccountInputDTO{
+		ID: c.Param("id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, output.Account)
+}
+
+func (h *AccountHandler) Deposit(c echo.Context) (err error) {
+	var balances []audit.BalanceChange
+	defer func() {
+		h.audit.Record(c, audit.Entry{Action: audit.ActionDeposit, Balances: balances}, err)
+	}()
+
+	if err := auth.CheckAccount(c, c.Param("id")); err != nil {
+		return err
+	}
+
+	var request DepositRequest
+	if err := c.Bind(&request); err != nil {
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	output, err := h.depositUseCase.Execute(c.Request().Context(), usecase.DepositInputDTO{
+		Destination: request.Account,
+		Amount:      request.Amount,
+		Product:     request.Product,
+	})
+	if err != nil {
+		return err
+	}
+	balances = handlers.DepositBalances(output)
+
+	return c.JSON(http.StatusCreated, DepositResponse{Account: output.Destination})
+}
+
+func (h *AccountHandler) Withdraw(c echo.Context) (err error) {
+	var balances []audit.BalanceChange
+	defer func() {
+		h.audit.Record(c, audit.Entry{Action: audit.ActionWithdraw, Balances: balances}, err)
+	}()
+
+	if err := auth.CheckAccount(c, c.Param("id")); err != nil {
+		return err
+	}
+
+	var request WithdrawalRequest
+	if err := c.Bind(&request); err != nil {
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	output, err := h.withdrawUseCase.Execute(c.Request().Context(), usecase.WithdrawInputDTO{
+		Origin: request.Account,
+		Amount: request.Amount,
+	})
+	if err != nil {
+		return err
+	}
+	balances = handlers.WithdrawBalances(output)
+
+	return c.JSON(http.StatusCreated, WithdrawalResponse{
+		Account: output.Origin,
+		Amount:  output.Amount,
+		Fee:     output.Fee,
+	})
+}
+
+func (h *AccountHandler) Setup(e *echo.Echo) {
+	g := e.Group("/v2/accounts")
+	g.GET("/:id", h.GetAccount, auth.RequireScope(entity.ScopeBalanceRead))
+	g.POST("/:id/deposits", h.Deposit, auth.RequireScope(entity.ScopeEventWrite))
+	g.POST("/:id/withdrawals", h.Withdraw, auth.RequireScope(entity.ScopeEventWrite))
+}
@@ -0,0 +1,80 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/http/auth"
+	handlers "simple-bank/internal/infrastructure/http/handler"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/shared/dto"
+	usecase "simple-bank/internal/usecase/account"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TransferHandler struct {
+	transferUseCase *usecase.TransferUseCase
+	audit           *handlers.AuditRecorder
+}
+
+type TransferRequest struct {
+	Origin      string `json:"origin" validate:"required,account_id"`
+	Destination string `json:"destination" validate:"required,account_id,nefield=Origin"`
+	Amount      int    `json:"amount" validate:"gt=0"`
+}
+
+type TransferResponse struct {
+	Origin      dto.AccountDTO `json:"origin"`
+	Destination dto.AccountDTO `json:"destination"`
+}
+
+func NewTransferHandler(transferUseCase *usecase.TransferUseCase) *TransferHandler {
+	return &TransferHandler{transferUseCase: transferUseCase}
+}
+
+// WithAudit records every transfer on logger.
+func (h *TransferHandler) WithAudit(logger audit.Logger) *TransferHandler {
+	h.audit = handlers.NewAuditRecorder(logger)
+	return h
+}
+
+func (h *TransferHandler) Transfer(c echo.Context) (err error) {
+	var balances []audit.BalanceChange
+	defer func() {
+		h.audit.Record(c, audit.Entry{Action: audit.ActionTransfer, Balances: balances}, err)
+	}()
+
+	var request TransferRequest
+	if err := c.Bind(&request); err != nil {
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := auth.CheckAccount(c, request.Origin); err != nil {
+		return err
+	}
+
+	output, err := h.transferUseCase.Execute(c.Request().Context(), usecase.TransferInputDTO{
+		Origin:      request.Origin,
+		Destination: request.Destination,
+		Amount:      request.Amount,
+		CustomerID:  auth.PrincipalFromContext(c.Request().Context()).CustomerID,
+	})
+	if err != nil {
+		return err
+	}
+	balances = handlers.TransferBalances(output)
+
+	return c.JSON(http.StatusCreated, TransferResponse{
+		Origin:      output.Origin,
+		Destination: output.Destination,
+	})
+}
+
+func (h *TransferHandler) Setup(e *echo.Echo) {
+	e.POST("/v2/transfers", h.Transfer, auth.RequireScope(entity.ScopeEventWrite))
+}
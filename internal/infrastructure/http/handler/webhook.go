@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/shared/dto"
+	usecase "simple-bank/internal/usecase/webhook"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	createWebhookUseCase  *usecase.CreateWebhookUseCase
+	listWebhooksUseCase   *usecase.ListWebhooksUseCase
+	deleteWebhookUseCase  *usecase.DeleteWebhookUseCase
+	listDeliveriesUseCase *usecase.ListDeliveriesUseCase
+	redeliverUseCase      *usecase.RedeliverUseCase
+	audit                 *AuditRecorder
+}
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []dto.WebhookDTO `json:"webhooks"`
+}
+
+type ListDeliveriesResponse struct {
+	Deliveries []dto.WebhookDeliveryDTO `json:"deliveries"`
+}
+
+func NewWebhookHandler(
+	createWebhookUseCase *usecase.CreateWebhookUseCase,
+	listWebhooksUseCase *usecase.ListWebhooksUseCase,
+	deleteWebhookUseCase *usecase.DeleteWebhookUseCase,
+	listDeliveriesUseCase *usecase.ListDeliveriesUseCase,
+	redeliverUseCase *usecase.RedeliverUseCase,
+) *WebhookHandler {
+	return &WebhookHandler{
+		createWebhookUseCase:  createWebhookUseCase,
+		listWebhooksUseCase:   listWebhooksUseCase,
+		deleteWebhookUseCase:  deleteWebhookUseCase,
+		listDeliveriesUseCase: listDeliveriesUseCase,
+		redeliverUseCase:      redeliverUseCase,
+	}
+}
+
+// WithAudit records every webhook creation, deletion and redelivery on logger.
+func (h *WebhookHandler) WithAudit(logger audit.Logger) *WebhookHandler {
+	h.audit = NewAuditRecorder(logger)
+	return h
+}
+
+func (h *WebhookHandler) CreateWebhook(c echo.Context) (err error) {
+	var request CreateWebhookRequest
+	var webhookID string
+	defer func() {
+		h.audit.Record(c, audit.Entry{Action: audit.ActionWebhookCreate, Target: webhookID}, err)
+	}()
+
+	if err := c.Bind(&request); err != nil {
+		return errors.Join(problem.ErrInvalidRequestBody, err)
+	}
+
+	output, err := h.createWebhookUseCase.Execute(c.Request().Context(), usecase.CreateWebhookInputDTO{
+		URL:        request.URL,
+		EventTypes: request.EventTypes,
+		Secret:     request.Secret,
+	})
+	if err != nil {
+		return err
+	}
+	webhookID = output.Webhook.ID
+
+	return c.JSON(http.StatusCreated, output.Webhook)
+}
+
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	output, err := h.listWebhooksUseCase.Execute(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ListWebhooksResponse{Webhooks: output.Webhooks})
+}
+
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) (err error) {
+	defer func() {
+		h.audit.Record(c, audit.Entry{Action: audit.ActionWebhookDelete, Target: c.Param("id")}, err)
+	}()
+
+	err = h.deleteWebhookUseCase.Execute(c.Request().Context(), usecase.DeleteWebhookInputDTO{
+		ID: c.Param("id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListDeliveries lists the deliveries in the status given by the status
+// query parameter, status=dead being the dead letter list.
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	output, err := h.listDeliveriesUseCase.Execute(c.Request().Context(), usecase.ListDeliveriesInputDTO{
+		Status: c.QueryParam("status"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ListDeliveriesResponse{Deliveries: output.Deliveries})
+}
+
+// Redeliver schedules a delivery to be sent again right away, it is answered
+// before the attempt is made.
+func (h *WebhookHandler) Redeliver(c echo.Context) (err error) {
+	defer func() {
+		h.audit.Record(c, audit.Entry{Action: audit.ActionWebhookRedeliver, Target: c.Param("id")}, err)
+	}()
+
+	output, err := h.redeliverUseCase.Execute(c.Request().Context(), usecase.RedeliverInputDTO{
+		DeliveryID: c.Param("id"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, output.Delivery)
+}
+
+func (h *WebhookHandler) Setup(e *echo.Echo) {
+	g := e.Group("/admin/webhooks", auth.RequireScope(entity.ScopeAdminWebhooks))
+	g.POST("", h.CreateWebhook)
+	g.GET("", h.ListWebhooks)
+	g.DELETE("/:id", h.DeleteWebhook)
+	g.GET("/deliveries", h.ListDeliveries)
+	g.POST("/deliveries/:id/redeliver", h.Redeliver)
+}
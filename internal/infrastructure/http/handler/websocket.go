@@ -0,0 +1,516 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/infrastructure/http/ratelimit"
+	"simple-bank/internal/infrastructure/http/validation"
+	"simple-bank/internal/infrastructure/stream"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/logging"
+	"simple-bank/internal/shared/tracing"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	WebSocketMessageEvent       = "event"
+	WebSocketMessageSubscribe   = "subscribe"
+	WebSocketMessageUnsubscribe = "unsubscribe"
+
+	WebSocketMessageResult       = "result"
+	WebSocketMessageError        = "error"
+	WebSocketMessageSubscribed   = "subscribed"
+	WebSocketMessageUnsubscribed = "unsubscribed"
+	WebSocketMessageBalance      = "balance"
+	WebSocketMessageReset        = "reset"
+)
+
+// WebSocketRequest is a message sent by the client. ID is echoed in the
+// replies so the client can match them, as events run concurrently.
+type WebSocketRequest struct {
+	ID      string              `json:"id"`
+	Type    string              `json:"type"`
+	Event   *HandleEventRequest `json:"event,omitempty"`
+	Account string              `json:"account,omitempty"`
+	// LastID resumes a subscription after the seq of the last balance change
+	// received.
+	LastID string `json:"last_id,omitempty"`
+}
+
+// WebSocketResponse is a message sent by the server, either a reply to a
+// request or, for balance messages, a change on a subscribed account. A reset
+// message tells the changes following last_id are lost, the client reads the
+// balance again and resumes from its seq.
+type WebSocketResponse struct {
+	ID      string                `json:"id,omitempty"`
+	Type    string                `json:"type"`
+	Result  *HandleEventResponse  `json:"result,omitempty"`
+	Error   *problem.Problem      `json:"error,omitempty"`
+	Account string                `json:"account,omitempty"`
+	Seq     string                `json:"seq,omitempty"`
+	Balance *dto.BalanceChangeDTO `json:"balance,omitempty"`
+}
+
+// WebSocketConfig bounds what a single connection may hold, zero values take
+// the defaults.
+type WebSocketConfig struct {
+	// MaxMessageSize is the largest message read, the connection is closed
+	// on a larger one.
+	MaxMessageSize int64
+	// MaxInFlight is how many events run at once, reading stops until one
+	// finishes.
+	MaxInFlight int
+	// MaxSubscriptions is how many accounts may be subscribed to.
+	MaxSubscriptions int
+	// SendQueue is how many messages wait to be written. Replies wait for
+	// room, a subscription whose balances do not fit is dropped.
+	SendQueue    int
+	PingInterval time.Duration
+	WriteTimeout time.Duration
+	// StrictValidation rejects messages with unknown fields, like the HTTP
+	// server does in strict mode.
+	StrictValidation bool
+	// RateLimit charges every event to the write bucket of the same event
+	// on POST /event, nil leaves them unlimited.
+	RateLimit *ratelimit.Config
+}
+
+var DefaultWebSocketConfig = WebSocketConfig{
+	MaxMessageSize:   16 << 10,
+	MaxInFlight:      8,
+	MaxSubscriptions: 16,
+	SendQueue:        64,
+	PingInterval:     30 * time.Second,
+	WriteTimeout:     10 * time.Second,
+}
+
+// WebSocketHandler runs events and pushes balance changes over a persistent
+// connection, with the same authorization and audit as /event.
+type WebSocketHandler struct {
+	events   *EventHandler
+	broker   *stream.Broker
+	config   WebSocketConfig
+	upgrader websocket.Upgrader
+
+	mu          sync.Mutex
+	connections map[*webSocketConnection]struct{}
+	closed      bool
+}
+
+func NewWebSocketHandler(events *EventHandler, broker *stream.Broker, config WebSocketConfig) *WebSocketHandler {
+	defaults := DefaultWebSocketConfig
+	if config.MaxMessageSize <= 0 {
+		config.MaxMessageSize = defaults.MaxMessageSize
+	}
+	if config.MaxInFlight <= 0 {
+		config.MaxInFlight = defaults.MaxInFlight
+	}
+	if config.MaxSubscriptions <= 0 {
+		config.MaxSubscriptions = defaults.MaxSubscriptions
+	}
+	if config.SendQueue <= 0 {
+		config.SendQueue = defaults.SendQueue
+	}
+	if config.PingInterval <= 0 {
+		config.PingInterval = defaults.PingInterval
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = defaults.WriteTimeout
+	}
+
+	return &WebSocketHandler{
+		events:      events,
+		broker:      broker,
+		config:      config,
+		connections: map[*webSocketConnection]struct{}{},
+	}
+}
+
+func (h *WebSocketHandler) Connect(c echo.Context) error {
+	if auth.PrincipalFromContext(c.Request().Context()) == nil {
+		return auth.ErrUnauthenticated
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// the upgrader already answered the client.
+		return nil
+	}
+
+	connection := &webSocketConnection{
+		handler:       h,
+		c:             c,
+		conn:          conn,
+		send:          make(chan WebSocketResponse, h.config.SendQueue),
+		done:          make(chan struct{}),
+		inFlight:      make(chan struct{}, h.config.MaxInFlight),
+		subscriptions: map[string]*stream.Subscription{},
+	}
+	if !h.track(connection) {
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+			time.Now().Add(h.config.WriteTimeout))
+		return conn.Close()
+	}
+	defer h.untrack(connection)
+
+	connection.serve()
+	return nil
+}
+
+// Close ends every connection, so they do not hold the server open on
+// shutdown.
+func (h *WebSocketHandler) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for connection := range h.connections {
+		connection.stop()
+	}
+}
+
+func (h *WebSocketHandler) track(connection *webSocketConnection) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return false
+	}
+	h.connections[connection] = struct{}{}
+	return true
+}
+
+func (h *WebSocketHandler) untrack(connection *webSocketConnection) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.connections, connection)
+}
+
+func (h *WebSocketHandler) Setup(e *echo.Echo) {
+	e.GET("/ws", h.Connect)
+}
+
+type webSocketConnection struct {
+	handler  *WebSocketHandler
+	c        echo.Context
+	conn     *websocket.Conn
+	send     chan WebSocketResponse
+	done     chan struct{}
+	stopOnce sync.Once
+	inFlight chan struct{}
+	running  sync.WaitGroup
+
+	mu            sync.Mutex
+	subscriptions map[string]*stream.Subscription
+}
+
+// serve reads the requests until the connection fails or is stopped. A
+// single goroutine writes, the replies and balances reach it through send.
+func (w *webSocketConnection) serve() {
+	config := w.handler.config
+	w.conn.SetReadLimit(config.MaxMessageSize)
+	w.conn.SetReadDeadline(time.Now().Add(2 * config.PingInterval))
+	w.conn.SetPongHandler(func(string) error {
+		return w.conn.SetReadDeadline(time.Now().Add(2 * config.PingInterval))
+	})
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		w.write()
+	}()
+
+	for {
+		_, message, err := w.conn.ReadMessage()
+		if err != nil {
+			break
+		}
+
+		var request WebSocketRequest
+		if err := w.decode(message, &request); err != nil {
+			w.reply(WebSocketResponse{Type: WebSocketMessageError, Error: errorProblem(err)})
+			continue
+		}
+
+		if !w.dispatch(request) {
+			break
+		}
+	}
+
+	w.stop()
+	w.running.Wait()
+	w.unsubscribeAll()
+	<-written
+}
+
+// decode reads a request with the same rules as the HTTP request bodies.
+func (w *webSocketConnection) decode(message []byte, request *WebSocketRequest) error {
+	if !w.handler.config.StrictValidation {
+		if err := json.Unmarshal(message, request); err != nil {
+			return problem.ErrInvalidRequestBody
+		}
+		return nil
+	}
+
+	err := validation.DecodeStrict(bytes.NewReader(message), request)
+	var validationErr *problem.ValidationError
+	if err != nil && !errors.As(err, &validationErr) {
+		return problem.ErrInvalidRequestBody
+	}
+	return err
+}
+
+// dispatch handles a request, events run in their own goroutine once a slot
+// is free. It returns false once the connection is stopped.
+func (w *webSocketConnection) dispatch(request WebSocketRequest) bool {
+	if request.ID == "" {
+		return w.reply(WebSocketResponse{Type: WebSocketMessageError, Error: errorProblem(problem.ErrMissingMessageID)})
+	}
+
+	switch request.Type {
+	case WebSocketMessageEvent:
+		select {
+		case w.inFlight <- struct{}{}:
+		case <-w.done:
+			return false
+		}
+		w.running.Add(1)
+		go func() {
+			defer func() {
+				<-w.inFlight
+				w.running.Done()
+			}()
+			w.reply(w.handleEvent(request))
+		}()
+		return true
+	case WebSocketMessageSubscribe:
+		return w.reply(w.subscribe(request))
+	case WebSocketMessageUnsubscribe:
+		w.unsubscribe(request.Account)
+		return w.reply(WebSocketResponse{ID: request.ID, Type: WebSocketMessageUnsubscribed, Account: request.Account})
+	default:
+		return w.reply(WebSocketResponse{ID: request.ID, Type: WebSocketMessageError, Error: errorProblem(problem.ErrUnknownMessageType)})
+	}
+}
+
+func (w *webSocketConnection) handleEvent(request WebSocketRequest) (response WebSocketResponse) {
+	response.ID = request.ID
+
+	var err error
+	var event HandleEventRequest
+	var balances []audit.BalanceChange
+	ctx, span := tracing.Start(w.c.Request().Context(), "WebSocketHandler.HandleEvent")
+	defer func() {
+		outcome := "success"
+		if err != nil {
+			outcome = problem.FromError(err).Code
+			response.Type, response.Error = WebSocketMessageError, errorProblem(err)
+		}
+		span.SetAttributes(tracing.KeyOutcome.String(outcome))
+		tracing.End(span, &err)
+		w.handler.events.audit.Record(w.c, audit.Entry{Action: eventAction(event.Type), Balances: balances}, err)
+	}()
+
+	if err = w.takeRateLimit(request.Event); err != nil {
+		return response
+	}
+	if !auth.PrincipalFromContext(ctx).HasScope(entity.ScopeEventWrite) {
+		err = auth.ErrInsufficientScope
+		return response
+	}
+	if request.Event == nil {
+		err = problem.ErrInvalidRequestBody
+		return response
+	}
+	event = *request.Event
+	span.SetAttributes(tracing.KeyEventType.String(event.Type))
+
+	if err = w.c.Validate(&event); err != nil {
+		return response
+	}
+
+	response.Result, balances, err = w.handler.events.execute(ctx, w.c, event)
+	response.Type = WebSocketMessageResult
+	return response
+}
+
+// takeRateLimit charges event as the rate limit middleware charges the
+// requests, before anything else is checked.
+func (w *webSocketConnection) takeRateLimit(event *HandleEventRequest) error {
+	rateLimit := w.handler.config.RateLimit
+	if rateLimit == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return rateLimit.TakeEvent(w.c, body)
+}
+
+func (w *webSocketConnection) subscribe(request WebSocketRequest) WebSocketResponse {
+	principal := auth.PrincipalFromContext(w.c.Request().Context())
+	if !principal.HasScope(entity.ScopeBalanceRead) {
+		return WebSocketResponse{ID: request.ID, Type: WebSocketMessageError, Error: errorProblem(auth.ErrInsufficientScope)}
+	}
+	if request.Account == "" {
+		return WebSocketResponse{ID: request.ID, Type: WebSocketMessageError, Error: errorProblem(problem.NewValidationError(
+			problem.FieldError{Field: "account", Message: "is required"},
+		))}
+	}
+	if !principal.CanAccessAccount(request.Account) {
+		return WebSocketResponse{ID: request.ID, Type: WebSocketMessageError, Error: errorProblem(auth.ErrAccountForbidden)}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.subscriptions[request.Account]; !ok {
+		if len(w.subscriptions) >= w.handler.config.MaxSubscriptions {
+			return WebSocketResponse{ID: request.ID, Type: WebSocketMessageError, Error: errorProblem(problem.ErrTooManySubscriptions)}
+		}
+
+		replay, subscription := w.handler.broker.Subscribe(request.Account, request.LastID)
+		w.subscriptions[request.Account] = subscription
+		w.running.Add(1)
+		go func() {
+			defer w.running.Done()
+			w.forward(request.Account, replay, subscription)
+		}()
+	}
+
+	return WebSocketResponse{ID: request.ID, Type: WebSocketMessageSubscribed, Account: request.Account}
+}
+
+// forward sends the balance changes of a subscription, after the replay of
+// the subscribe request. When the broker drops it for lagging behind, the
+// client is told so it can subscribe again with the last seq it received.
+func (w *webSocketConnection) forward(account string, replay []stream.Message, subscription *stream.Subscription) {
+	for _, message := range replay {
+		response := balanceMessage(message)
+		if message.Reset {
+			response = WebSocketResponse{Type: WebSocketMessageReset, Account: account, Seq: message.ID}
+		}
+		if !w.reply(response) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case message, ok := <-subscription.C:
+			if !ok {
+				w.mu.Lock()
+				dropped := w.subscriptions[account] == subscription
+				if dropped {
+					delete(w.subscriptions, account)
+				}
+				w.mu.Unlock()
+
+				if dropped {
+					w.reply(WebSocketResponse{Type: WebSocketMessageUnsubscribed, Account: account})
+				}
+				return
+			}
+			select {
+			case w.send <- balanceMessage(message):
+			default:
+				// balances never hold the replies back, the subscription is
+				// dropped instead and the client resumes it from last_id.
+				subscription.Close()
+			}
+		}
+	}
+}
+
+func (w *webSocketConnection) unsubscribe(account string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if subscription, ok := w.subscriptions[account]; ok {
+		delete(w.subscriptions, account)
+		subscription.Close()
+	}
+}
+
+func (w *webSocketConnection) unsubscribeAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for account, subscription := range w.subscriptions {
+		delete(w.subscriptions, account)
+		subscription.Close()
+	}
+}
+
+// reply queues a message, waiting for room. It returns false once the
+// connection is stopped.
+func (w *webSocketConnection) reply(response WebSocketResponse) bool {
+	select {
+	case w.send <- response:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+func (w *webSocketConnection) write() {
+	config := w.handler.config
+	ping := time.NewTicker(config.PingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			w.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+				time.Now().Add(config.WriteTimeout))
+			w.conn.Close()
+			return
+		case response := <-w.send:
+			w.conn.SetWriteDeadline(time.Now().Add(config.WriteTimeout))
+			if err := w.conn.WriteJSON(response); err != nil {
+				slog.DebugContext(w.c.Request().Context(), "fail to write on websocket", logging.KeyError, err.Error())
+				w.stop()
+			}
+		case <-ping.C:
+			if err := w.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(config.WriteTimeout)); err != nil {
+				w.stop()
+			}
+		}
+	}
+}
+
+// stop makes every goroutine of the connection return, the writer closes it.
+func (w *webSocketConnection) stop() {
+	w.stopOnce.Do(func() { close(w.done) })
+}
+
+func balanceMessage(message stream.Message) WebSocketResponse {
+	return WebSocketResponse{
+		Type:    WebSocketMessageBalance,
+		Account: message.Change.Account,
+		Seq:     message.ID,
+		Balance: &message.Change,
+	}
+}
+
+func errorProblem(err error) *problem.Problem {
+	p := problem.FromError(err)
+	return &p
+}
@@ -0,0 +1,18 @@
+package openapi
+
+import "embed"
+
+// Spec is the OpenAPI 3 document describing every route of the HTTP server.
+// It is kept in sync with the handlers by test/integration/openapi_test.go.
+//
+//go:embed openapi.json
+var Spec []byte
+
+//go:embed docs.html
+var DocsPage []byte
+
+// SwaggerUI holds the swagger-ui 4.15.5 assets the docs page loads, served by
+// the binary rather than a CDN.
+//
+//go:embed swagger-ui/swagger-ui.css swagger-ui/swagger-ui-bundle.js
+var SwaggerUI embed.FS
@@ -0,0 +1,194 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/ratelimit"
+	"simple-bank/internal/usecase/account"
+	"simple-bank/internal/usecase/apikey"
+	"simple-bank/internal/usecase/customer"
+	"simple-bank/internal/usecase/webhook"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	CodeAccountClosed           = "account_closed"
+	CodeAccountForbidden        = "account_forbidden"
+	CodeAccountFrozen           = "account_frozen"
+	CodeAccountNotFound         = "account_not_found"
+	CodeAccountNotLinked        = "account_not_linked"
+	CodeAmountBelowFee          = "amount_below_fee"
+	CodeAccountNotOwned         = "account_not_owned"
+	CodeAPIKeyNotFound          = "api_key_not_found"
+	CodeAPIKeyRevoked           = "api_key_revoked"
+	CodeCustomerNotFound        = "customer_not_found"
+	CodeInsufficientFunds       = "insufficient_funds"
+	CodeInternalError           = "internal_error"
+	CodeInsufficientScope       = "insufficient_scope"
+	CodeInvalidAPIKey           = "invalid_api_key"
+	CodeInvalidAmount           = "invalid_amount"
+	CodeInvalidCredentials      = "invalid_credentials"
+	CodeInvalidCursor           = "invalid_cursor"
+	CodeInvalidCustomer         = "invalid_customer"
+	CodeInvalidEventType        = "invalid_event_type"
+	CodeInvalidFilter           = "invalid_filter"
+	CodeInvalidLimit            = "invalid_limit"
+	CodeInvalidProduct          = "invalid_product"
+	CodeInvalidRequestBody      = "invalid_request_body"
+	CodeInvalidSort             = "invalid_sort"
+	CodeInvalidWebhook          = "invalid_webhook"
+	CodeMissingMessageID        = "missing_message_id"
+	CodeUnauthenticated         = "unauthenticated"
+	CodeRateLimited             = "rate_limited"
+	CodeRequestTimeout          = "request_timeout"
+	CodeResetNotConfirmed       = "reset_not_confirmed"
+	CodeSameAccountTransfer     = "same_account_transfer"
+	CodeTooManySubscriptions    = "too_many_subscriptions"
+	CodeUnknownMessageType      = "unknown_message_type"
+	CodeValidationFailed        = "validation_failed"
+	CodeWebhookNotFound         = "webhook_not_found"
+	CodeWebhookDeliveryNotFound = "webhook_delivery_not_found"
+	CodeWithdrawalLimitExceeded = "withdrawal_limit_exceeded"
+)
+
+// Class tells expected business failures apart from malformed requests and
+// real server faults, so they can be monitored separately.
+type Class string
+
+const (
+	ClassBusiness Class = "business"
+	ClassClient   Class = "client"
+	ClassServer   Class = "server"
+)
+
+type mapping struct {
+	target error
+	status int
+	code   string
+	title  string
+}
+
+// mappings is the single place deciding how errors are exposed to clients.
+// It is evaluated in order, so use case specific errors must come before the
+// domain errors they wrap.
+var mappings = []mapping{
+	{context.DeadlineExceeded, http.StatusServiceUnavailable, CodeRequestTimeout, "Request timed out"},
+	{ErrInvalidRequestBody, http.StatusBadRequest, CodeInvalidRequestBody, "Invalid request body"},
+	{ErrInvalidEventType, http.StatusBadRequest, CodeInvalidEventType, "Invalid event type"},
+	{ratelimit.ErrRateLimited, http.StatusTooManyRequests, CodeRateLimited, "Too many requests"},
+	{ErrResetNotConfirmed, http.StatusPreconditionRequired, CodeResetNotConfirmed, "Reset requires a valid confirmation token"},
+	{ErrMissingMessageID, http.StatusBadRequest, CodeMissingMessageID, "Message ID is required"},
+	{ErrUnknownMessageType, http.StatusBadRequest, CodeUnknownMessageType, "Unknown message type"},
+	{ErrTooManySubscriptions, http.StatusBadRequest, CodeTooManySubscriptions, "Too many subscriptions on the connection"},
+
+	{auth.ErrUnauthenticated, http.StatusUnauthorized, CodeUnauthenticated, "Authentication required"},
+	{auth.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials, "Invalid credentials"},
+	{auth.ErrInsufficientScope, http.StatusForbidden, CodeInsufficientScope, "Insufficient scope"},
+	{auth.ErrAccountForbidden, http.StatusForbidden, CodeAccountForbidden, "Account not accessible with this credential"},
+
+	{apikey.ErrCreateAPIKeyInvalidName, http.StatusBadRequest, CodeInvalidAPIKey, "API key name is required"},
+	{apikey.ErrCreateAPIKeyInvalidScope, http.StatusBadRequest, CodeInvalidAPIKey, "Invalid API key scope"},
+	{apikey.ErrRotateAPIKeyScopeNotHeld, http.StatusForbidden, CodeInsufficientScope, "Insufficient scope"},
+
+	{webhook.ErrCreateWebhookInvalidURL, http.StatusBadRequest, CodeInvalidWebhook, "Webhook URL must be an absolute http or https URL"},
+	{webhook.ErrCreateWebhookInvalidEventType, http.StatusBadRequest, CodeInvalidWebhook, "Invalid webhook event type"},
+	{webhook.ErrCreateWebhookInvalidSecret, http.StatusBadRequest, CodeInvalidWebhook, "Webhook secret must be at least 16 characters"},
+	{webhook.ErrListDeliveriesInvalidStatus, http.StatusBadRequest, CodeInvalidFilter, "Invalid filter"},
+
+	{account.ErrListAccountsInvalidCursor, http.StatusBadRequest, CodeInvalidCursor, "Invalid cursor"},
+	{account.ErrListAccountsInvalidSort, http.StatusBadRequest, CodeInvalidSort, "Invalid sort"},
+	{account.ErrListAccountsInvalidFilter, http.StatusBadRequest, CodeInvalidFilter, "Invalid filter"},
+	{account.ErrListAccountsInvalidLimit, http.StatusBadRequest, CodeInvalidLimit, "Invalid limit"},
+	{account.ErrDepositProductNotExists, http.StatusBadRequest, CodeInvalidProduct, "Product does not exist"},
+
+	{customer.ErrCreateCustomerInvalidName, http.StatusBadRequest, CodeInvalidCustomer, "Customer name is required"},
+	{customer.ErrUpdateCustomerInvalidName, http.StatusBadRequest, CodeInvalidCustomer, "Customer name is required"},
+	{customer.ErrSetKYCStatusInvalidStatus, http.StatusBadRequest, CodeInvalidCustomer, "Invalid KYC status"},
+	{customer.ErrUnlinkAccountAccountNotLinked, http.StatusNotFound, CodeAccountNotLinked, "Account is not linked to customer"},
+
+	{domainErrs.ErrAccountNotOwned, http.StatusForbidden, CodeAccountNotOwned, "Account not owned by customer"},
+	{domainErrs.ErrAccountNotFound, http.StatusNotFound, CodeAccountNotFound, "Account not found"},
+	{domainErrs.ErrCustomerNotFound, http.StatusNotFound, CodeCustomerNotFound, "Customer not found"},
+	{domainErrs.ErrAPIKeyNotFound, http.StatusNotFound, CodeAPIKeyNotFound, "API key not found"},
+	{domainErrs.ErrWebhookNotFound, http.StatusNotFound, CodeWebhookNotFound, "Webhook not found"},
+	{domainErrs.ErrWebhookDeliveryNotFound, http.StatusNotFound, CodeWebhookDeliveryNotFound, "Webhook delivery not found"},
+	{domainErrs.ErrAPIKeyRevoked, http.StatusConflict, CodeAPIKeyRevoked, "API key revoked"},
+	{domainErrs.ErrAccountInsufficientBalance, http.StatusUnprocessableEntity, CodeInsufficientFunds, "Insufficient funds"},
+	{domainErrs.ErrAccountWithdrawalLimitExceeded, http.StatusUnprocessableEntity, CodeWithdrawalLimitExceeded, "Monthly withdrawal limit exceeded"},
+	{domainErrs.ErrAccountFrozen, http.StatusUnprocessableEntity, CodeAccountFrozen, "Account is frozen"},
+	{domainErrs.ErrAccountClosed, http.StatusUnprocessableEntity, CodeAccountClosed, "Account is closed"},
+	{domainErrs.ErrInvalidAmount, http.StatusUnprocessableEntity, CodeInvalidAmount, "Amount must be greater than zero"},
+	{domainErrs.ErrAmountBelowFee, http.StatusUnprocessableEntity, CodeAmountBelowFee, "Amount does not cover the fee"},
+	{domainErrs.ErrSameAccountTransfer, http.StatusUnprocessableEntity, CodeSameAccountTransfer, "Transfer origin and destination must differ"},
+}
+
+var businessCodes = map[string]bool{
+	CodeInsufficientFunds:       true,
+	CodeWithdrawalLimitExceeded: true,
+	CodeAccountFrozen:           true,
+	CodeAccountClosed:           true,
+	CodeInvalidAmount:           true,
+	CodeAmountBelowFee:          true,
+	CodeSameAccountTransfer:     true,
+}
+
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusRequestEntityTooLarge: "request_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusServiceUnavailable:    "service_unavailable",
+}
+
+func FromError(err error) Problem {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		p := New(http.StatusBadRequest, CodeValidationFailed, "Validation failed")
+		p.Errors = validationErr.Fields
+		return p
+	}
+
+	for _, m := range mappings {
+		if errors.Is(err, m.target) {
+			p := New(m.status, m.code, m.title)
+			var insufficientErr *domainErrs.InsufficientBalanceError
+			if errors.As(err, &insufficientErr) {
+				p.Balance = &insufficientErr.Balance
+				p.AvailableBalance = &insufficientErr.Available
+			}
+			return p
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		if code, ok := statusCodes[httpErr.Code]; ok {
+			p := New(httpErr.Code, code, http.StatusText(httpErr.Code))
+			if message, ok := httpErr.Message.(string); ok && httpErr.Code < http.StatusInternalServerError {
+				p.Detail = message
+			}
+			return p
+		}
+	}
+
+	return New(http.StatusInternalServerError, CodeInternalError, http.StatusText(http.StatusInternalServerError))
+}
+
+func (p Problem) Class() Class {
+	switch {
+	case p.Status >= http.StatusInternalServerError:
+		return ClassServer
+	case businessCodes[p.Code]:
+		return ClassBusiness
+	default:
+		return ClassClient
+	}
+}
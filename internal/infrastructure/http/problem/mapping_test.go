@@ -0,0 +1,103 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/usecase/account"
+	"simple-bank/internal/usecase/customer"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type FromErrorSuite struct {
+	suite.Suite
+}
+
+func (suite *FromErrorSuite) TestFromError() {
+	suite.Run("Should map wrapped domain errors", func() {
+		err := errors.Join(account.ErrWithdrawAccountNotExists, domainErrs.ErrAccountNotFound)
+
+		p := FromError(err)
+
+		suite.Equal(http.StatusNotFound, p.Status)
+		suite.Equal(CodeAccountNotFound, p.Code)
+		suite.Equal("/problems/account-not-found", p.Type)
+	})
+
+	suite.Run("Should prefer use case errors over the domain errors they wrap", func() {
+		err := errors.Join(customer.ErrUnlinkAccountAccountNotLinked, domainErrs.ErrAccountNotOwned)
+
+		p := FromError(err)
+
+		suite.Equal(http.StatusNotFound, p.Status)
+		suite.Equal(CodeAccountNotLinked, p.Code)
+	})
+
+	suite.Run("Should report the balance on insufficient funds", func() {
+		err := errors.Join(
+			account.ErrWithdrawFailToWithdraw,
+			&domainErrs.InsufficientBalanceError{Balance: 10, Available: 60},
+		)
+
+		p := FromError(err)
+
+		suite.Equal(http.StatusUnprocessableEntity, p.Status)
+		suite.Equal(CodeInsufficientFunds, p.Code)
+		suite.Equal(10, *p.Balance)
+		suite.Equal(60, *p.AvailableBalance)
+		suite.Equal(ClassBusiness, p.Class())
+	})
+
+	suite.Run("Should map timeouts to service unavailable", func() {
+		p := FromError(echo.ErrServiceUnavailable.WithInternal(context.DeadlineExceeded))
+
+		suite.Equal(http.StatusServiceUnavailable, p.Status)
+		suite.Equal(CodeRequestTimeout, p.Code)
+	})
+
+	suite.Run("Should not expose unknown error messages", func() {
+		p := FromError(errors.New("connection refused to 10.0.0.1"))
+
+		suite.Equal(http.StatusInternalServerError, p.Status)
+		suite.Equal(CodeInternalError, p.Code)
+		suite.Empty(p.Detail)
+		suite.NotContains(p.Title, "10.0.0.1")
+		suite.Equal(ClassServer, p.Class())
+	})
+
+	suite.Run("Should expose field errors of validation errors", func() {
+		p := FromError(NewValidationError(FieldError{Field: "limit", Message: "must be an integer"}))
+
+		suite.Equal(http.StatusBadRequest, p.Status)
+		suite.Equal(CodeValidationFailed, p.Code)
+		suite.Equal([]FieldError{{Field: "limit", Message: "must be an integer"}}, p.Errors)
+	})
+}
+
+func (suite *FromErrorSuite) TestStatusOf() {
+	newContext := func() echo.Context {
+		return echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	}
+
+	suite.Run("Should read the status of errors not answered yet", func() {
+		suite.Equal(http.StatusNotFound, StatusOf(newContext(), domainErrs.ErrAccountNotFound))
+		suite.Equal(http.StatusTooManyRequests, StatusOf(newContext(), echo.NewHTTPError(http.StatusTooManyRequests)))
+	})
+
+	suite.Run("Should read the status written once committed", func() {
+		c := newContext()
+		c.NoContent(http.StatusAccepted)
+
+		suite.Equal(http.StatusAccepted, StatusOf(c, errors.New("late failure")))
+		suite.Equal(http.StatusAccepted, StatusOf(c, nil))
+	})
+}
+
+func TestFromError(t *testing.T) {
+	suite.Run(t, new(FromErrorSuite))
+}
@@ -0,0 +1,125 @@
+package problem
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"simple-bank/internal/shared/logging"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+var (
+	ErrInvalidRequestBody = errors.New("Invalid request body")
+	ErrInvalidEventType   = errors.New("Invalid event type")
+	ErrResetNotConfirmed  = errors.New("Reset not confirmed")
+
+	ErrMissingMessageID     = errors.New("Message ID is required")
+	ErrUnknownMessageType   = errors.New("Unknown message type")
+	ErrTooManySubscriptions = errors.New("Too many subscriptions on the connection")
+)
+
+// Problem is the RFC 7807 error document returned by every route. Code is a
+// stable machine readable identifier clients are expected to switch on.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Code     string       `json:"code"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+
+	Balance          *int `json:"balance,omitempty"`
+	AvailableBalance *int `json:"available_balance,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return "Validation failed: " + strings.Join(messages, ", ")
+}
+
+func New(status int, code, title string) Problem {
+	return Problem{
+		Type:   "/problems/" + strings.ReplaceAll(code, "_", "-"),
+		Title:  title,
+		Status: status,
+		Code:   code,
+	}
+}
+
+// Recorder is notified of every problem written, so failures can be counted
+// by class and code.
+type Recorder interface {
+	RecordProblem(class Class, code string)
+}
+
+type Config struct {
+	// LegacyNotFound answers account not found errors with the plain text
+	// "0" body expected by clients of the original API.
+	LegacyNotFound bool
+	Recorder       Recorder
+}
+
+// StatusOf is the status a request answers with err: the one written when the
+// response is committed, otherwise the one the error handler will write.
+// Middleware reading the status use it to leave err to the error handler.
+func StatusOf(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	return FromError(err).Status
+}
+
+func NewErrorHandler(config Config) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		p := FromError(err)
+		p.Instance = c.Request().URL.Path
+
+		if config.Recorder != nil {
+			config.Recorder.RecordProblem(p.Class(), p.Code)
+		}
+
+		if p.Status >= http.StatusInternalServerError {
+			slog.ErrorContext(c.Request().Context(), "request failed", logging.KeyError, err.Error(), "code", p.Code)
+		}
+
+		var writeErr error
+		switch {
+		case config.LegacyNotFound && p.Code == CodeAccountNotFound:
+			writeErr = c.String(http.StatusNotFound, "0")
+		case c.Request().Method == http.MethodHead:
+			writeErr = c.NoContent(p.Status)
+		default:
+			c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+			writeErr = c.JSON(p.Status, p)
+		}
+
+		if writeErr != nil {
+			slog.ErrorContext(c.Request().Context(), "fail to write problem", logging.KeyError, writeErr.Error())
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"simple-bank/internal/infrastructure/http/auth"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+var ErrRateLimited = errors.New("Rate limit exceeded")
+
+const HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+
+// Call is what the bucket of a request is picked from. Calls made outside of
+// the HTTP server, such as over gRPC, are described the same way so they take
+// from the same buckets.
+type Call struct {
+	// Principal is the authenticated caller, nil when there is none.
+	Principal *auth.Principal
+	IP        string
+	// Account returns the account the call acts on, empty when it names
+	// none. It is only called when keying by account, nil names none.
+	Account func() string
+}
+
+// KeyFunc tells which bucket a call takes its token from.
+type KeyFunc func(call Call) string
+
+// KeyByIP limits each client address.
+func KeyByIP(call Call) string {
+	return "ip:" + call.IP
+}
+
+// KeyByCredential limits each authenticated caller, anonymous calls are
+// limited by client address.
+func KeyByCredential(call Call) string {
+	if call.Principal == nil || call.Principal == auth.Anonymous {
+		return KeyByIP(call)
+	}
+	return "principal:" + call.Principal.Subject
+}
+
+// KeyByAccount limits each account, taken from the route, the account_id
+// query parameter or the body of an event. Only authenticated callers allowed
+// on the account are charged to it, so nobody can exhaust the bucket of an
+// account by naming it. Other calls are limited by KeyByCredential.
+func KeyByAccount(call Call) string {
+	if call.Principal == nil || call.Principal == auth.Anonymous || call.Account == nil {
+		return KeyByCredential(call)
+	}
+
+	if id := call.Account(); id != "" && call.Principal.CanAccessAccount(id) {
+		return "account:" + id
+	}
+	return KeyByCredential(call)
+}
+
+// requestCall describes a request of the HTTP server.
+func requestCall(c echo.Context) Call {
+	return Call{
+		Principal: auth.PrincipalFromContext(c.Request().Context()),
+		IP:        c.RealIP(),
+		Account:   func() string { return requestAccount(c) },
+	}
+}
+
+// requestAccount is the account a request acts on, empty when it names none.
+func requestAccount(c echo.Context) string {
+	if id := c.Param("id"); id != "" {
+		return id
+	}
+	if id := c.QueryParam("account_id"); id != "" {
+		return id
+	}
+	return eventAccount(c)
+}
+
+// maxEventBody bounds the body read to find the account of an event, events
+// are far smaller.
+const maxEventBody = 64 << 10
+
+// eventAccount peeks the account money moves from, or into for deposits,
+// leaving the body in place for the handler. Bodies over maxEventBody are
+// not buffered, the handler fails reading them.
+func eventAccount(c echo.Context) string {
+	req := c.Request()
+	if req.Body == nil || req.Method == http.MethodGet {
+		return ""
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxEventBody))
+	if err != nil {
+		req.Body = io.NopCloser(failedBody{err: err})
+		return ""
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return bodyAccount(body)
+}
+
+// bodyAccount is the account money moves from in the JSON event body, or
+// into for deposits.
+func bodyAccount(body []byte) string {
+	var event struct {
+		Type        string `json:"type"`
+		Origin      string `json:"origin"`
+		Destination string `json:"destination"`
+	}
+	if json.Unmarshal(body, &event) != nil {
+		return ""
+	}
+	if event.Type == "deposit" {
+		return event.Destination
+	}
+	return event.Origin
+}
+
+// failedBody hands the error met peeking the body to the handler.
+type failedBody struct {
+	err error
+}
+
+func (b failedBody) Read([]byte) (int, error) {
+	return 0, b.err
+}
+
+// Config sets separate limits for reads (GET and HEAD) and writes, a zero
+// Limit leaves that kind of request unlimited.
+type Config struct {
+	Store Store
+	Key   KeyFunc
+	Read  Limit
+	Write Limit
+	// Skipper exempts requests, such as health probes, from the limits.
+	Skipper middleware.Skipper
+}
+
+func Middleware(config Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper != nil && config.Skipper(c) {
+				return next(c)
+			}
+
+			method := c.Request().Method
+			write := method != http.MethodGet && method != http.MethodHead
+			if limit, _ := config.limit(write); limit.IsZero() {
+				return next(c)
+			}
+
+			decision, err := config.Take(c.Request().Context(), write, requestCall(c))
+			if err != nil {
+				return err
+			}
+
+			if !decision.Allowed {
+				retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(max(retryAfter, 1)))
+				return ErrRateLimited
+			}
+
+			c.Response().Header().Set(HeaderRateLimitRemaining, strconv.Itoa(decision.Remaining))
+			return next(c)
+		}
+	}
+}
+
+// Take charges call to the write bucket of its key, or to the read bucket
+// when write is false. Kinds of calls without limit are always allowed.
+func (config Config) Take(ctx context.Context, write bool, call Call) (Decision, error) {
+	limit, class := config.limit(write)
+	if limit.IsZero() {
+		return Decision{Allowed: true}, nil
+	}
+
+	key := config.Key
+	if key == nil {
+		key = KeyByIP
+	}
+	return config.Store.Take(ctx, class+":"+key(call), limit)
+}
+
+// limit is the limit of writes or reads and the class of their buckets.
+func (config Config) limit(write bool) (Limit, string) {
+	if write {
+		return config.Write, "write"
+	}
+	return config.Read, "read"
+}
+
+// TakeEvent charges an event received outside of its own request, such as
+// over a websocket, to the write bucket POST /event takes with the same
+// body. c is the request that carried the event, it gives the caller and
+// client address to the key.
+func (config Config) TakeEvent(c echo.Context, body []byte) error {
+	call := requestCall(c)
+	call.Account = func() string { return bodyAccount(body) }
+
+	decision, err := config.Take(c.Request().Context(), true, call)
+	if err != nil {
+		return err
+	}
+	if !decision.Allowed {
+		return ErrRateLimited
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit is a token bucket refilled with Rate tokens per second up to Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l Limit) IsZero() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+type Decision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. It is an interface so limits can be shared between
+// instances through an external store.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket is refilled to the burst of its own limit.
+	full time.Time
+}
+
+// MemoryStore keeps the buckets of a single process.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	takes   int
+	now     func() time.Time
+}
+
+// sweepEvery is how many takes happen between removals of full buckets.
+const sweepEvery = 1024
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.takes++
+	if s.takes%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		b.full = now.Add(seconds((float64(limit.Burst) - b.tokens) / limit.Rate))
+		return Decision{RetryAfter: seconds((1 - b.tokens) / limit.Rate)}, nil
+	}
+
+	b.tokens--
+	b.full = now.Add(seconds((float64(limit.Burst) - b.tokens) / limit.Rate))
+	return Decision{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+// sweep drops the buckets full by now, they are recreated full on their next
+// take. Each bucket is judged by the limit it was last taken with, as keys
+// of different limits share the store.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
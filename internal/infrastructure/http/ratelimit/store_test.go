@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryStoreSuite struct {
+	suite.Suite
+	now   time.Time
+	store *MemoryStore
+}
+
+func (suite *MemoryStoreSuite) SetupSubTest() {
+	suite.now = time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	suite.store = NewMemoryStore()
+	suite.store.now = func() time.Time { return suite.now }
+}
+
+func (suite *MemoryStoreSuite) take(key string, limit Limit) Decision {
+	decision, err := suite.store.Take(context.Background(), key, limit)
+	suite.Require().NoError(err)
+	return decision
+}
+
+func (suite *MemoryStoreSuite) TestTake() {
+	limit := Limit{Rate: 1, Burst: 2}
+
+	suite.Run("Should allow a burst and then refuse", func() {
+		suite.True(suite.take("a", limit).Allowed)
+		suite.True(suite.take("a", limit).Allowed)
+
+		decision := suite.take("a", limit)
+		suite.False(decision.Allowed)
+		suite.Equal(time.Second, decision.RetryAfter)
+	})
+
+	suite.Run("Should refill tokens over time", func() {
+		suite.take("a", limit)
+		suite.take("a", limit)
+
+		suite.now = suite.now.Add(500 * time.Millisecond)
+		decision := suite.take("a", limit)
+		suite.False(decision.Allowed)
+		suite.Equal(500*time.Millisecond, decision.RetryAfter)
+
+		suite.now = suite.now.Add(500 * time.Millisecond)
+		suite.True(suite.take("a", limit).Allowed)
+	})
+
+	suite.Run("Should keep keys apart", func() {
+		suite.take("a", limit)
+		suite.take("a", limit)
+
+		suite.True(suite.take("b", limit).Allowed)
+	})
+
+	suite.Run("Should report the remaining tokens", func() {
+		suite.Equal(1, suite.take("a", limit).Remaining)
+		suite.Equal(0, suite.take("a", limit).Remaining)
+	})
+}
+
+func (suite *MemoryStoreSuite) TestSweep() {
+	read, write := Limit{Rate: 1, Burst: 2}, Limit{Rate: 100, Burst: 100}
+
+	suite.Run("Should judge each bucket by its own limit", func() {
+		suite.take("read:a", read)
+		suite.take("read:a", read)
+		suite.now = suite.now.Add(time.Second)
+
+		for i := 1; i < sweepEvery; i++ {
+			suite.take("write:b", write)
+		}
+
+		suite.Contains(suite.store.buckets, "read:a")
+		suite.Equal(0, suite.take("read:a", read).Remaining)
+	})
+
+	suite.Run("Should drop the buckets refilled to their burst", func() {
+		suite.take("read:a", read)
+		suite.now = suite.now.Add(time.Second)
+
+		for i := 1; i < sweepEvery; i++ {
+			suite.take("write:b", write)
+		}
+
+		suite.NotContains(suite.store.buckets, "read:a")
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	suite.Run(t, new(MemoryStoreSuite))
+}
@@ -3,6 +3,18 @@ package http
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"simple-bank/internal/infrastructure/health"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/infrastructure/http/ratelimit"
+	"simple-bank/internal/infrastructure/http/validation"
+	"simple-bank/internal/infrastructure/metrics"
+	"simple-bank/internal/infrastructure/telemetry"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -12,22 +24,100 @@ type HTTPHandler interface {
 	Setup(e *echo.Echo)
 }
 
+type HTTPServerConfig struct {
+	Port           string
+	RequestTimeout time.Duration
+	LegacyErrors   bool
+	ErrorRecorder  problem.Recorder
+	// StrictValidation rejects request bodies with unknown fields.
+	StrictValidation bool
+	// Authenticators resolve the caller of each request, authentication is
+	// disabled when none is given.
+	Authenticators []auth.Authenticator
+	// RateLimit throttles requests once they are authenticated, nil disables it.
+	RateLimit *ratelimit.Config
+	// TrustedProxies are the proxies whose X-Forwarded-For header tells the
+	// client address. The header is ignored when empty, the client address
+	// is then the peer of the connection.
+	TrustedProxies []*net.IPNet
+	// Metrics instruments every request and is served on /metrics when set.
+	Metrics *metrics.Metrics
+	// Logger writes the access log, slog.Default() is used when nil.
+	Logger *slog.Logger
+	// Readiness backs /readyz, which only reports the shutdown when nil.
+	Readiness *health.Readiness
+	// ShutdownDelay is how long /readyz reports not ready before Stop starts
+	// draining connections, so load balancers stop sending new requests.
+	ShutdownDelay time.Duration
+}
+
 type HTTPServer struct {
-	Engine *echo.Echo
-	port   string
+	Engine        *echo.Echo
+	port          string
+	readiness     *health.Readiness
+	shutdownDelay time.Duration
 }
 
-func NewHTTPServer(port string, handlers ...HTTPHandler) *HTTPServer {
+func NewHTTPServer(config HTTPServerConfig, handlers ...HTTPHandler) *HTTPServer {
 	server := &HTTPServer{
-		port:   port,
-		Engine: echo.New(),
+		port:          config.Port,
+		Engine:        echo.New(),
+		readiness:     config.Readiness,
+		shutdownDelay: config.ShutdownDelay,
+	}
+	if server.readiness == nil {
+		server.readiness = health.NewReadiness(0)
+	}
+
+	server.Engine.HTTPErrorHandler = problem.NewErrorHandler(problem.Config{
+		LegacyNotFound: config.LegacyErrors,
+		Recorder:       config.ErrorRecorder,
+	})
+	server.Engine.IPExtractor = ipExtractor(config.TrustedProxies)
+	server.Engine.Validator = validation.NewValidator()
+	if config.StrictValidation {
+		server.Engine.Binder = validation.NewStrictBinder()
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
 	}
 
 	server.Engine.Use(
-		middleware.Logger(),
+		requestID(),
+		telemetry.Middleware(),
+	)
+	if config.Metrics != nil {
+		server.Engine.Use(config.Metrics.Middleware())
+	}
+	server.Engine.Use(
+		requestLogger(logger),
 		middleware.Recover(),
 	)
 
+	if config.RequestTimeout > 0 {
+		server.Engine.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+			Skipper: isLongLived,
+			Timeout: config.RequestTimeout,
+		}))
+	}
+
+	server.Engine.Use(auth.Middleware(config.Authenticators...))
+	if config.RateLimit != nil {
+		rateLimit := *config.RateLimit
+		if rateLimit.Skipper == nil {
+			rateLimit.Skipper = isProbe
+		}
+		server.Engine.Use(ratelimit.Middleware(rateLimit))
+	}
+
+	server.Engine.GET("/healthz", server.healthz)
+	server.Engine.GET("/readyz", server.readyz)
+	if config.Metrics != nil {
+		server.Engine.GET("/metrics", echo.WrapHandler(config.Metrics.Handler()))
+	}
+
 	for _, h := range handlers {
 		h.Setup(server.Engine)
 	}
@@ -39,6 +129,50 @@ func (s *HTTPServer) Start() error {
 	return s.Engine.Start(fmt.Sprintf(":%s", s.port))
 }
 
+// Stop reports not ready, waits for the shutdown delay and then drains the
+// open connections.
 func (s *HTTPServer) Stop(ctx context.Context) error {
+	s.readiness.ShutDown()
+
+	select {
+	case <-time.After(s.shutdownDelay):
+	case <-ctx.Done():
+	}
 	return s.Engine.Shutdown(ctx)
 }
+
+func (s *HTTPServer) healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, health.Report{Status: health.StatusOK})
+}
+
+func (s *HTTPServer) readyz(c echo.Context) error {
+	report, ready := s.readiness.Check(c.Request().Context())
+	if !ready {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// ipExtractor only reads X-Forwarded-For behind trusted proxies, clients
+// could otherwise pick the address they are limited and audited by.
+func ipExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+func isProbe(c echo.Context) bool {
+	return c.Path() == "/healthz" || c.Path() == "/readyz"
+}
+
+// isLongLived tells the event streams and the websocket apart, they must
+// outlive the request timeout.
+func isLongLived(c echo.Context) bool {
+	return strings.HasSuffix(c.Path(), "/stream") || c.Path() == "/ws"
+}
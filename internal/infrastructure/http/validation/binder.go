@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"simple-bank/internal/infrastructure/http/problem"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StrictBinder behaves like echo.DefaultBinder but refuses JSON bodies with
+// fields the target type does not declare.
+type StrictBinder struct {
+	echo.DefaultBinder
+}
+
+func NewStrictBinder() *StrictBinder {
+	return &StrictBinder{}
+}
+
+func (b *StrictBinder) Bind(i interface{}, c echo.Context) error {
+	req := c.Request()
+	if req.ContentLength == 0 || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return b.DefaultBinder.Bind(i, c)
+	}
+
+	if err := b.BindPathParams(c, i); err != nil {
+		return err
+	}
+	return DecodeStrict(req.Body, i)
+}
+
+// DecodeStrict decodes a JSON document into i, refusing the fields i does not
+// declare. Unknown and mistyped fields are reported as
+// problem.ValidationError.
+func DecodeStrict(r io.Reader, i interface{}) error {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(i)
+	if err == nil {
+		return nil
+	}
+
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return problem.NewValidationError(problem.FieldError{
+			Field:   strings.Trim(field, `"`),
+			Message: "is not allowed",
+		})
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return problem.NewValidationError(problem.FieldError{
+			Field:   typeErr.Field,
+			Message: "must be " + typeErr.Type.String(),
+		})
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, "Malformed JSON body").SetInternal(err)
+}
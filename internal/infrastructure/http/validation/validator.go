@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"simple-bank/internal/infrastructure/http/problem"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var accountIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Validator implements echo.Validator using the `validate` struct tags of the
+// request types and reports failures as problem.ValidationError.
+type Validator struct {
+	validate *validator.Validate
+}
+
+func NewValidator() *Validator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(jsonFieldName)
+	_ = validate.RegisterValidation("account_id", func(fl validator.FieldLevel) bool {
+		return accountIDPattern.MatchString(fl.Field().String())
+	})
+
+	return &Validator{validate: validate}
+}
+
+func (v *Validator) Validate(i interface{}) error {
+	err := v.validate.Struct(i)
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	fields := make([]problem.FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, problem.FieldError{
+			Field:   fieldErr.Field(),
+			Message: message(fieldErr),
+		})
+	}
+	return problem.NewValidationError(fields...)
+}
+
+func message(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required", "required_if", "required_unless":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "gt":
+		return "must be greater than " + fieldErr.Param()
+	case "account_id":
+		return "must have 1 to 64 letters, digits, '-' or '_'"
+	case "nefield":
+		return "must differ from " + strings.ToLower(fieldErr.Param())
+	default:
+		return fmt.Sprintf("failed on %s validation", fieldErr.Tag())
+	}
+}
+
+// jsonFieldName names fields as clients send them, path parameters by their
+// param tag.
+func jsonFieldName(field reflect.StructField) string {
+	if param := field.Tag.Get("param"); param != "" {
+		return param
+	}
+
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/infrastructure/http/problem"
+	usecase "simple-bank/internal/usecase/account"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// AccountTotals reports the size of the bank, it is read on every scrape.
+type AccountTotals interface {
+	Totals(ctx context.Context) (accounts int, balance int, err error)
+}
+
+// Metrics owns a Prometheus registry with the HTTP, business and repository
+// metrics of the application.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	requests           *prometheus.CounterVec
+	requestDuration    *prometheus.HistogramVec
+	operations         *prometheus.CounterVec
+	operationAmounts   *prometheus.HistogramVec
+	events             *prometheus.CounterVec
+	repositoryDuration *prometheus.HistogramVec
+	problems           *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by method, route and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bank_operations_total",
+			Help: "Deposits, withdrawals and transfers by outcome.",
+		}, []string{"operation", "outcome"}),
+		operationAmounts: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "bank_operation_amount",
+			Help:    "Amounts of successful deposits, withdrawals and transfers.",
+			Buckets: prometheus.ExponentialBuckets(1, 10, 8),
+		}, []string{"operation"}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "bank_events_total",
+			Help: "Committed account events by type.",
+		}, []string{"type"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "repository_operation_duration_seconds",
+			Help:    "Repository call latency by repository, operation and result.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"repository", "operation", "result"}),
+		problems: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_problems_total",
+			Help: "Problems answered by class and code.",
+		}, []string{"class", "code"}),
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.operations,
+		m.operationAmounts,
+		m.events,
+		m.repositoryDuration,
+		m.problems,
+	)
+	return m
+}
+
+// WatchAccounts publishes the number of accounts and the money they hold.
+func (m *Metrics) WatchAccounts(totals AccountTotals) {
+	m.Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "bank_accounts",
+			Help: "Number of accounts.",
+		}, func() float64 {
+			accounts, _, _ := totals.Totals(context.Background())
+			return float64(accounts)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "bank_money_under_management",
+			Help: "Sum of the balances of every account.",
+		}, func() float64 {
+			_, balance, _ := totals.Totals(context.Background())
+			return float64(balance)
+		}),
+	)
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) RecordOperation(ctx context.Context, operation usecase.Operation, outcome usecase.Outcome, amount int) {
+	m.operations.WithLabelValues(string(operation), string(outcome)).Inc()
+	if outcome == usecase.OutcomeSuccess {
+		m.operationAmounts.WithLabelValues(string(operation)).Observe(float64(amount))
+	}
+}
+
+// RecordProblem is the problem.Recorder counting the problems answered.
+func (m *Metrics) RecordProblem(class problem.Class, code string) {
+	m.problems.WithLabelValues(string(class), code).Inc()
+}
+
+// HandleEvent is the event.Handler counting the committed events.
+func (m *Metrics) HandleEvent(ctx context.Context, e event.Event) error {
+	m.events.WithLabelValues(string(e.Type)).Inc()
+	return nil
+}
+
+// observeRepository is deferred by the repository decorators, err points to
+// their named result so it is read once the call is over. Calls are also
+// logged at debug level with the request ID of ctx.
+func (m *Metrics) observeRepository(ctx context.Context, repository, operation string, start time.Time, err *error) {
+	result := "ok"
+	switch {
+	case errors.Is(*err, domainErrs.ErrAccountNotFound),
+		errors.Is(*err, domainErrs.ErrCustomerNotFound),
+		errors.Is(*err, domainErrs.ErrProductNotFound):
+		result = "not_found"
+	case *err != nil:
+		result = "error"
+	}
+	duration := time.Since(start)
+	m.repositoryDuration.WithLabelValues(repository, operation, result).Observe(duration.Seconds())
+	slog.DebugContext(ctx, "repository call",
+		"repository", repository,
+		"operation", operation,
+		"result", result,
+		"duration", duration,
+	)
+}
+
+// Middleware counts and times requests by their route pattern, so account IDs
+// do not end up in label values. Errors are left to the error handler, their
+// status is read from the error.
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			labels := []string{c.Request().Method, route, strconv.Itoa(problem.StatusOf(c, err))}
+			m.requests.WithLabelValues(labels...).Inc()
+			m.requestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
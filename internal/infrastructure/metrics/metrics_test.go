@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	domainErrs "simple-bank/internal/domain/errors"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestMiddlewareSuite struct {
+	suite.Suite
+	metrics *Metrics
+}
+
+func (suite *TestMiddlewareSuite) SetupSubTest() {
+	suite.metrics = New()
+}
+
+func (suite *TestMiddlewareSuite) serve(handler echo.HandlerFunc) (echo.Context, error) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/balance", nil), httptest.NewRecorder())
+	c.SetPath("/balance")
+	return c, suite.metrics.Middleware()(handler)(c)
+}
+
+func (suite *TestMiddlewareSuite) TestMiddleware() {
+	suite.Run("Should return the error unchanged and count the status it maps to", func() {
+		c, err := suite.serve(func(c echo.Context) error { return domainErrs.ErrAccountNotFound })
+
+		suite.Equal(domainErrs.ErrAccountNotFound, err)
+		suite.False(c.Response().Committed)
+		suite.Equal(1.0, testutil.ToFloat64(suite.metrics.requests.WithLabelValues(http.MethodGet, "/balance", "404")))
+	})
+
+	suite.Run("Should count the status of HTTP errors", func() {
+		_, err := suite.serve(func(c echo.Context) error { return echo.ErrTooManyRequests })
+
+		suite.Equal(echo.ErrTooManyRequests, err)
+		suite.Equal(1.0, testutil.ToFloat64(suite.metrics.requests.WithLabelValues(http.MethodGet, "/balance", "429")))
+	})
+
+	suite.Run("Should count the status written", func() {
+		_, err := suite.serve(func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+		suite.NoError(err)
+		suite.Equal(1.0, testutil.ToFloat64(suite.metrics.requests.WithLabelValues(http.MethodGet, "/balance", "200")))
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	suite.Run(t, new(TestMiddlewareSuite))
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"time"
+)
+
+type accountRepository struct {
+	next    repository.AccountRepository
+	metrics *Metrics
+}
+
+// NewAccountRepository times every call made to next.
+func NewAccountRepository(next repository.AccountRepository, m *Metrics) repository.AccountRepository {
+	return &accountRepository{next: next, metrics: m}
+}
+
+func (r *accountRepository) GetAccountByID(ctx context.Context, id string) (account *entity.Account, err error) {
+	defer r.metrics.observeRepository(ctx, "account", "get_account_by_id", time.Now(), &err)
+	return r.next.GetAccountByID(ctx, id)
+}
+
+func (r *accountRepository) ListAccounts(ctx context.Context, query repository.ListAccountsQuery) (result *repository.ListAccountsResult, err error) {
+	defer r.metrics.observeRepository(ctx, "account", "list_accounts", time.Now(), &err)
+	return r.next.ListAccounts(ctx, query)
+}
+
+func (r *accountRepository) UpdateAccount(ctx context.Context, account *entity.Account, events ...event.Event) (err error) {
+	defer r.metrics.observeRepository(ctx, "account", "update_account", time.Now(), &err)
+	return r.next.UpdateAccount(ctx, account, events...)
+}
+
+func (r *accountRepository) SaveAccount(ctx context.Context, account *entity.Account, events ...event.Event) (err error) {
+	defer r.metrics.observeRepository(ctx, "account", "save_account", time.Now(), &err)
+	return r.next.SaveAccount(ctx, account, events...)
+}
+
+func (r *accountRepository) UpdateAccounts(
+	ctx context.Context,
+	ids []string,
+	update repository.AccountsUpdate,
+) (err error) {
+	defer r.metrics.observeRepository(ctx, "account", "update_accounts", time.Now(), &err)
+	return r.next.UpdateAccounts(ctx, ids, update)
+}
+
+func (r *accountRepository) DeleteAllAccounts(ctx context.Context) (err error) {
+	defer r.metrics.observeRepository(ctx, "account", "delete_all_accounts", time.Now(), &err)
+	return r.next.DeleteAllAccounts(ctx)
+}
+
+type customerRepository struct {
+	next    repository.CustomerRepository
+	metrics *Metrics
+}
+
+// NewCustomerRepository times every call made to next.
+func NewCustomerRepository(next repository.CustomerRepository, m *Metrics) repository.CustomerRepository {
+	return &customerRepository{next: next, metrics: m}
+}
+
+func (r *customerRepository) GetCustomerByID(ctx context.Context, id string) (customer *entity.Customer, err error) {
+	defer r.metrics.observeRepository(ctx, "customer", "get_customer_by_id", time.Now(), &err)
+	return r.next.GetCustomerByID(ctx, id)
+}
+
+func (r *customerRepository) GetCustomersByAccountID(ctx context.Context, accountID string) (customers []*entity.Customer, err error) {
+	defer r.metrics.observeRepository(ctx, "customer", "get_customers_by_account_id", time.Now(), &err)
+	return r.next.GetCustomersByAccountID(ctx, accountID)
+}
+
+func (r *customerRepository) SaveCustomer(ctx context.Context, customer *entity.Customer) (err error) {
+	defer r.metrics.observeRepository(ctx, "customer", "save_customer", time.Now(), &err)
+	return r.next.SaveCustomer(ctx, customer)
+}
+
+func (r *customerRepository) UpdateCustomer(ctx context.Context, customer *entity.Customer) (err error) {
+	defer r.metrics.observeRepository(ctx, "customer", "update_customer", time.Now(), &err)
+	return r.next.UpdateCustomer(ctx, customer)
+}
+
+func (r *customerRepository) DeleteCustomer(ctx context.Context, id string) (err error) {
+	defer r.metrics.observeRepository(ctx, "customer", "delete_customer", time.Now(), &err)
+	return r.next.DeleteCustomer(ctx, id)
+}
+
+type productRepository struct {
+	next    repository.ProductRepository
+	metrics *Metrics
+}
+
+// NewProductRepository times every call made to next.
+func NewProductRepository(next repository.ProductRepository, m *Metrics) repository.ProductRepository {
+	return &productRepository{next: next, metrics: m}
+}
+
+func (r *productRepository) GetProductByID(ctx context.Context, id string) (product *entity.Product, err error) {
+	defer r.metrics.observeRepository(ctx, "product", "get_product_by_id", time.Now(), &err)
+	return r.next.GetProductByID(ctx, id)
+}
+
+func (r *productRepository) GetDefaultProduct(ctx context.Context) (product *entity.Product, err error) {
+	defer r.metrics.observeRepository(ctx, "product", "get_default_product", time.Now(), &err)
+	return r.next.GetDefaultProduct(ctx)
+}
+
+func (r *productRepository) ListProducts(ctx context.Context) (products []*entity.Product, err error) {
+	defer r.metrics.observeRepository(ctx, "product", "list_products", time.Now(), &err)
+	return r.next.ListProducts(ctx)
+}
@@ -0,0 +1,143 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/logging"
+	"sync"
+	"time"
+)
+
+const batchSize = 100
+
+// Publisher is a sink of the outbox. Events are delivered at least once, a
+// crash between a delivery and the move of the cursor delivers the event
+// again: publishers must drop duplicates, the event ID being their
+// deduplication ID.
+type Publisher interface {
+	Publish(ctx context.Context, e event.Event) error
+}
+
+type sink struct {
+	name      string
+	publisher Publisher
+}
+
+// Relay delivers the events of the outbox to its sinks. Each sink moves
+// through the outbox at its own pace with a cursor stored next to it, and
+// entries are pruned once every sink got them.
+type Relay struct {
+	outbox repository.OutboxRepository
+	sinks  []sink
+}
+
+func NewRelay(outbox repository.OutboxRepository) *Relay {
+	return &Relay{outbox: outbox}
+}
+
+// WithSink delivers the events to publisher. The name identifies the cursor
+// of the sink in the outbox, so it must stay the same across restarts.
+func (r *Relay) WithSink(name string, publisher Publisher) *Relay {
+	r.sinks = append(r.sinks, sink{name: name, publisher: publisher})
+	return r
+}
+
+// Flush delivers the pending events to every sink and returns how many
+// deliveries it made. Sinks are flushed concurrently, each one in order up
+// to its first failure, which is retried on the next flush, so a sink never
+// receives events out of order nor holds back the others.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	if len(r.sinks) == 0 {
+		return 0, nil
+	}
+
+	delivered := make([]int, len(r.sinks))
+	cursors := make([]uint64, len(r.sinks))
+	errs := make([]error, len(r.sinks))
+
+	var wg sync.WaitGroup
+	for i := range r.sinks {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delivered[i], cursors[i], errs[i] = r.flush(ctx, r.sinks[i])
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("outbox sink %s: %w", r.sinks[i].name, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	oldest := cursors[0]
+	for i := range r.sinks {
+		total += delivered[i]
+		oldest = min(oldest, cursors[i])
+	}
+
+	if oldest > 0 {
+		errs = append(errs, r.outbox.PruneOutbox(ctx, oldest))
+	}
+	return total, errors.Join(errs...)
+}
+
+// flush returns how many events it delivered to s and the cursor it left s
+// at.
+func (r *Relay) flush(ctx context.Context, s sink) (int, uint64, error) {
+	cursor, err := r.outbox.OutboxCursor(ctx, s.name)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	delivered := 0
+	for {
+		entries, err := r.outbox.OutboxEntries(ctx, cursor, batchSize)
+		if err != nil || len(entries) == 0 {
+			return delivered, cursor, err
+		}
+
+		next, published := cursor, 0
+		var publishErr error
+		for _, entry := range entries {
+			if publishErr = s.publisher.Publish(ctx, entry.Event); publishErr != nil {
+				break
+			}
+			next = entry.Sequence
+			published++
+		}
+
+		if published > 0 {
+			if err := r.outbox.AdvanceOutboxCursor(ctx, s.name, next); err != nil {
+				return delivered, cursor, err
+			}
+			cursor = next
+			delivered += published
+		}
+
+		if publishErr != nil {
+			return delivered, cursor, publishErr
+		}
+	}
+}
+
+// Run flushes the outbox every interval until ctx is done.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "fail to relay the outbox", logging.KeyError, err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/infrastructure/repository/inmemory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type publisherSpy struct {
+	published []event.Event
+	failOn    string
+}
+
+func (p *publisherSpy) Publish(ctx context.Context, e event.Event) error {
+	if e.ID == p.failOn {
+		return errors.New("publisher unavailable")
+	}
+	p.published = append(p.published, e)
+	return nil
+}
+
+// cursorFailure crashes before any cursor moves.
+type cursorFailure struct {
+	*inmemory.AccountRepository
+}
+
+func (c *cursorFailure) AdvanceOutboxCursor(ctx context.Context, sink string, sequence uint64) error {
+	return errors.New("crashed")
+}
+
+type TestRelaySuite struct {
+	suite.Suite
+	repo     *inmemory.AccountRepository
+	webhooks *publisherSpy
+	archive  *publisherSpy
+	sut      *Relay
+}
+
+func (suite *TestRelaySuite) SetupSubTest() {
+	suite.repo = inmemory.NewAccountRepository()
+	suite.webhooks = &publisherSpy{}
+	suite.archive = &publisherSpy{}
+	suite.sut = NewRelay(suite.repo).
+		WithSink("webhooks", suite.webhooks).
+		WithSink("archive", suite.archive)
+}
+
+func (suite *TestRelaySuite) deposit(id string, balance int) event.Event {
+	e := event.New(id, time.Now(), event.FundsDeposited{Account: "100", Amount: 10, Balance: balance})
+	suite.Require().NoError(suite.repo.UpdateAccount(context.Background(), entity.NewAccount("100", balance), e))
+	return e
+}
+
+func (suite *TestRelaySuite) pending() int {
+	entries, err := suite.repo.OutboxEntries(context.Background(), 0, 10)
+	suite.Require().NoError(err)
+	return len(entries)
+}
+
+func (suite *TestRelaySuite) TestFlush() {
+	suite.Run("Should deliver pending events in order to every sink and prune them", func() {
+		first, second := suite.deposit("E1", 10), suite.deposit("E2", 20)
+
+		delivered, err := suite.sut.Flush(context.Background())
+
+		suite.NoError(err)
+		suite.Equal(4, delivered)
+		suite.Equal([]event.Event{first, second}, suite.webhooks.published)
+		suite.Equal([]event.Event{first, second}, suite.archive.published)
+		suite.Zero(suite.pending())
+	})
+
+	suite.Run("Should deliver nothing twice", func() {
+		first := suite.deposit("E1", 10)
+		suite.sut.Flush(context.Background())
+		second := suite.deposit("E2", 20)
+
+		delivered, err := suite.sut.Flush(context.Background())
+
+		suite.NoError(err)
+		suite.Equal(2, delivered)
+		suite.Equal([]event.Event{first, second}, suite.webhooks.published)
+	})
+
+	suite.Run("Should retry a failing sink from its first failure without holding back the others", func() {
+		first := suite.deposit("E1", 10)
+		failing, last := suite.deposit("E2", 20), suite.deposit("E3", 30)
+		suite.webhooks.failOn = "E2"
+
+		delivered, err := suite.sut.Flush(context.Background())
+
+		suite.ErrorContains(err, "outbox sink webhooks: publisher unavailable")
+		suite.Equal(4, delivered)
+		suite.Equal([]event.Event{first}, suite.webhooks.published)
+		suite.Equal([]event.Event{first, failing, last}, suite.archive.published)
+		suite.Equal(2, suite.pending())
+
+		suite.webhooks.failOn = ""
+		delivered, err = suite.sut.Flush(context.Background())
+
+		suite.NoError(err)
+		suite.Equal(2, delivered)
+		suite.Equal([]event.Event{first, failing, last}, suite.webhooks.published)
+		suite.Equal([]event.Event{first, failing, last}, suite.archive.published)
+		suite.Zero(suite.pending())
+	})
+
+	suite.Run("Should deliver again the events of a sink whose cursor did not move", func() {
+		e := suite.deposit("E1", 10)
+		crashing := &cursorFailure{AccountRepository: suite.repo}
+		_, err := NewRelay(crashing).WithSink("webhooks", suite.webhooks).Flush(context.Background())
+		suite.Require().Error(err)
+
+		delivered, err := suite.sut.Flush(context.Background())
+
+		suite.NoError(err)
+		suite.Equal(2, delivered)
+		suite.Equal([]event.Event{e, e}, suite.webhooks.published)
+		suite.Equal([]event.Event{e}, suite.archive.published)
+	})
+
+	suite.Run("Should keep the events without sinks", func() {
+		suite.deposit("E1", 10)
+
+		delivered, err := NewRelay(suite.repo).Flush(context.Background())
+
+		suite.NoError(err)
+		suite.Zero(delivered)
+		suite.Equal(1, suite.pending())
+	})
+}
+
+func TestRelay(t *testing.T) {
+	suite.Run(t, new(TestRelaySuite))
+}
@@ -0,0 +1,94 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"simple-bank/internal/domain/event"
+	"sync"
+	"time"
+)
+
+// HeaderIdempotencyKey carries the event ID of the requests of an HTTPSink.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// HTTPSink posts every event as JSON to a fixed URL, with the event ID as
+// idempotency key. Any 2xx answer acknowledges the event.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSink posts to url with client, a client with a 10 seconds timeout
+// is used when nil.
+func NewHTTPSink(url string, client *http.Client) *HTTPSink {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPSink{url: url, client: client}
+}
+
+func (s *HTTPSink) Publish(ctx context.Context, e event.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderIdempotencyKey, e.ID)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("sink answered %d", response.StatusCode)
+	}
+	return nil
+}
+
+// FileSink appends every event as a JSON line to a file, synced before the
+// event is acknowledged. Readers drop the lines of an event ID they
+// already read.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Publish(ctx context.Context, e event.Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
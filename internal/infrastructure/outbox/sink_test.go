@@ -0,0 +1,82 @@
+package outbox
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"simple-bank/internal/domain/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestSinkSuite struct {
+	suite.Suite
+	event event.Event
+}
+
+func (suite *TestSinkSuite) SetupSubTest() {
+	suite.event = event.New("E1", time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC),
+		event.FundsDeposited{Account: "100", Amount: 10, Balance: 10})
+}
+
+func (suite *TestSinkSuite) TestHTTPSink() {
+	suite.Run("Should post the event with its ID as idempotency key", func() {
+		var request *http.Request
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := NewHTTPSink(server.URL, nil).Publish(context.Background(), suite.event)
+
+		suite.NoError(err)
+		suite.Equal(http.MethodPost, request.Method)
+		suite.Equal("E1", request.Header.Get(HeaderIdempotencyKey))
+		suite.Equal("application/json", request.Header.Get("Content-Type"))
+		suite.JSONEq(`{"id":"E1","type":"funds.deposited","occurred_at":"2024-03-10T12:00:00Z",
+			"data":{"account":"100","amount":10,"fee":0,"balance":10}}`, string(body))
+	})
+
+	suite.Run("Should fail when the sink does not answer 2xx", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		err := NewHTTPSink(server.URL, nil).Publish(context.Background(), suite.event)
+
+		suite.EqualError(err, "sink answered 503")
+	})
+}
+
+func (suite *TestSinkSuite) TestFileSink() {
+	suite.Run("Should append the events as JSON lines", func() {
+		path := filepath.Join(suite.T().TempDir(), "events.jsonl")
+		sink, err := NewFileSink(path)
+		suite.Require().NoError(err)
+
+		suite.NoError(sink.Publish(context.Background(), suite.event))
+		suite.NoError(sink.Close())
+		sink, err = NewFileSink(path)
+		suite.Require().NoError(err)
+		suite.NoError(sink.Publish(context.Background(), suite.event))
+		suite.NoError(sink.Close())
+
+		content, err := os.ReadFile(path)
+		suite.NoError(err)
+		line := `{"id":"E1","type":"funds.deposited","occurred_at":"2024-03-10T12:00:00Z","data":{"account":"100","amount":10,"fee":0,"balance":10}}` + "\n"
+		suite.Equal(line+line, string(content))
+	})
+}
+
+func TestSink(t *testing.T) {
+	suite.Run(t, new(TestSinkSuite))
+}
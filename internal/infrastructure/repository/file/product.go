@@ -0,0 +1,121 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"sort"
+)
+
+var ErrInvalidProductCatalog = errors.New("[ProductRepository] Invalid product catalog")
+
+type productCatalogFile struct {
+	Default  string        `json:"default"`
+	Products []productFile `json:"products"`
+}
+
+type productFile struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	OverdraftLimit         int    `json:"overdraft_limit"`
+	MonthlyWithdrawalLimit int    `json:"monthly_withdrawal_limit"`
+	Fees                   struct {
+		Deposit    int `json:"deposit"`
+		Withdrawal int `json:"withdrawal"`
+		Transfer   int `json:"transfer"`
+	} `json:"fees"`
+}
+
+type ProductRepository struct {
+	products         map[string]entity.Product
+	defaultProductID string
+}
+
+func NewProductRepository(path string) (*ProductRepository, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProductRepositoryFromJSON(data)
+}
+
+func NewProductRepositoryFromJSON(data []byte) (*ProductRepository, error) {
+	var catalog productCatalogFile
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, errors.Join(ErrInvalidProductCatalog, err)
+	}
+
+	repo := &ProductRepository{
+		products:         make(map[string]entity.Product, len(catalog.Products)),
+		defaultProductID: catalog.Default,
+	}
+
+	for _, p := range catalog.Products {
+		if p.ID == "" {
+			return nil, fmt.Errorf("%w: product without id", ErrInvalidProductCatalog)
+		}
+		if _, ok := repo.products[p.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicated product %q", ErrInvalidProductCatalog, p.ID)
+		}
+		if p.OverdraftLimit < 0 || p.MonthlyWithdrawalLimit < 0 {
+			return nil, fmt.Errorf("%w: negative limit on product %q", ErrInvalidProductCatalog, p.ID)
+		}
+		if p.Fees.Deposit < 0 || p.Fees.Withdrawal < 0 || p.Fees.Transfer < 0 {
+			return nil, fmt.Errorf("%w: negative fee on product %q", ErrInvalidProductCatalog, p.ID)
+		}
+
+		repo.products[p.ID] = entity.Product{
+			ID:                     p.ID,
+			Name:                   p.Name,
+			OverdraftLimit:         p.OverdraftLimit,
+			MonthlyWithdrawalLimit: p.MonthlyWithdrawalLimit,
+			Fees: entity.FeeSchedule{
+				Deposit:    p.Fees.Deposit,
+				Withdrawal: p.Fees.Withdrawal,
+				Transfer:   p.Fees.Transfer,
+			},
+		}
+	}
+
+	if _, ok := repo.products[catalog.Default]; !ok {
+		return nil, fmt.Errorf("%w: unknown default product %q", ErrInvalidProductCatalog, catalog.Default)
+	}
+
+	return repo, nil
+}
+
+func (r *ProductRepository) GetProductByID(ctx context.Context, id string) (*entity.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	product, ok := r.products[id]
+	if !ok {
+		return nil, domainErrs.ErrProductNotFound
+	}
+	return &product, nil
+}
+
+func (r *ProductRepository) GetDefaultProduct(ctx context.Context) (*entity.Product, error) {
+	return r.GetProductByID(ctx, r.defaultProductID)
+}
+
+func (r *ProductRepository) ListProducts(ctx context.Context) ([]*entity.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	products := make([]*entity.Product, 0, len(r.products))
+	for id := range r.products {
+		product := r.products[id]
+		products = append(products, &product)
+	}
+
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+	return products, nil
+}
@@ -0,0 +1,77 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/test/contract"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const catalogFixture = `{
+	"default": "checking",
+	"products": [
+		{"id": "checking", "name": "Checking"},
+		{
+			"id": "savings",
+			"name": "Savings",
+			"monthly_withdrawal_limit": 6,
+			"fees": {"withdrawal": 2, "transfer": 2}
+		}
+	]
+}`
+
+func TestProductRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.json")
+	if err := os.WriteFile(path, []byte(catalogFixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	suite.Run(t, &contract.ProductRepositorySuite{
+		NewRepository: func() repository.ProductRepository {
+			repo, err := NewProductRepository(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return repo
+		},
+	})
+}
+
+func TestNewProductRepositoryFromJSON(t *testing.T) {
+	t.Run("Should return error when default product is unknown", func(t *testing.T) {
+		_, err := NewProductRepositoryFromJSON([]byte(`{"default": "gold", "products": [{"id": "checking"}]}`))
+
+		assert.ErrorIs(t, err, ErrInvalidProductCatalog)
+	})
+
+	t.Run("Should return error when a product is duplicated", func(t *testing.T) {
+		_, err := NewProductRepositoryFromJSON([]byte(`{
+			"default": "checking",
+			"products": [{"id": "checking"}, {"id": "checking"}]
+		}`))
+
+		assert.ErrorIs(t, err, ErrInvalidProductCatalog)
+	})
+
+	t.Run("Should return error when a fee is negative", func(t *testing.T) {
+		_, err := NewProductRepositoryFromJSON([]byte(`{
+			"default": "checking",
+			"products": [{"id": "checking", "fees": {"deposit": -1}}]
+		}`))
+
+		assert.ErrorIs(t, err, ErrInvalidProductCatalog)
+		assert.ErrorContains(t, err, `negative fee on product "checking"`)
+	})
+
+	t.Run("Should load the shipped catalog", func(t *testing.T) {
+		repo, err := NewProductRepository("../../../../config/products.json")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "checking", repo.defaultProductID)
+		assert.Len(t, repo.products, 3)
+	})
+}
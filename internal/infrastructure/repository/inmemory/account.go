@@ -1,36 +1,389 @@
 package inmemory
 
-import "simple-bank/internal/domain/entity"
+import (
+	"context"
+	"hash/fnv"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
 
+type balanceKey struct {
+	balance int
+	id      string
+}
+
+func (k balanceKey) less(other balanceKey) bool {
+	if k.balance != other.balance {
+		return k.balance < other.balance
+	}
+	return k.id < other.id
+}
+
+// accountLocks is how many locks UpdateAccounts spreads the accounts over.
+const accountLocks = 64
+
+// AccountRepository keeps the accounts, the outbox and its cursors in the
+// process, a restart loses all of them.
 type AccountRepository struct {
-	Accounts map[string]entity.Account
+	Accounts  map[string]entity.Account
+	mu        sync.RWMutex
+	locks     [accountLocks]sync.Mutex
+	byID      []string
+	byBalance []balanceKey
+	outbox    []repository.OutboxEntry
+	sequence  uint64
+	cursors   map[string]uint64
 }
 
 func NewAccountRepository() *AccountRepository {
 	return &AccountRepository{
 		Accounts: make(map[string]entity.Account),
+		cursors:  make(map[string]uint64),
 	}
 }
 
-func (r *AccountRepository) GetAccountByID(id string) (*entity.Account, error) {
+// Ping reports the repository as reachable, it lives in the process.
+func (r *AccountRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *AccountRepository) GetAccountByID(ctx context.Context, id string) (*entity.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	account, ok := r.Accounts[id]
 	if !ok {
-		return nil, nil
+		return nil, domainErrs.ErrAccountNotFound
 	}
 	return &account, nil
 }
 
-func (r *AccountRepository) UpdateAccount(account *entity.Account) error {
-	r.Accounts[account.ID] = *account
+func (r *AccountRepository) ListAccounts(
+	ctx context.Context,
+	query repository.ListAccountsQuery,
+) (*repository.ListAccountsResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := &repository.ListAccountsResult{Accounts: []*entity.Account{}}
+	visit := func(id string) bool {
+		account := r.Accounts[id]
+		if !matchesFilter(account, query.Filter) {
+			return true
+		}
+
+		if query.Limit > 0 && len(result.Accounts) == query.Limit {
+			last := result.Accounts[len(result.Accounts)-1]
+			result.Next = &repository.AccountCursor{ID: last.ID, Balance: last.Balance}
+			return false
+		}
+
+		result.Accounts = append(result.Accounts, &account)
+		return true
+	}
+
+	if query.SortBy == repository.AccountSortByBalance {
+		r.scanByBalance(query, visit)
+	} else {
+		r.scanByID(query, visit)
+	}
+
+	return result, nil
+}
+
+func (r *AccountRepository) UpdateAccount(ctx context.Context, account *entity.Account, events ...event.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.put(*account)
+	r.record(events)
+	return nil
+}
+
+func (r *AccountRepository) SaveAccount(ctx context.Context, account *entity.Account, events ...event.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.put(*account)
+	r.record(events)
 	return nil
 }
 
-func (r *AccountRepository) SaveAccount(account *entity.Account) error {
-	r.Accounts[account.ID] = *account
+func (r *AccountRepository) UpdateAccounts(
+	ctx context.Context,
+	ids []string,
+	update repository.AccountsUpdate,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	unlock := r.lockAccounts(ids)
+	defer unlock()
+
+	accounts := make([]*entity.Account, len(ids))
+	r.mu.RLock()
+	for i, id := range ids {
+		if account, ok := r.Accounts[id]; ok {
+			accounts[i] = &account
+		}
+	}
+	r.mu.RUnlock()
+
+	events, err := update(accounts)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, account := range accounts {
+		if account != nil {
+			r.put(*account)
+		}
+	}
+	r.record(events)
 	return nil
 }
 
-func (r *AccountRepository) DeleteAllAccounts() error {
+// lockAccounts takes the locks of the given accounts, always in the same
+// order so concurrent updates cannot deadlock, and returns their release.
+func (r *AccountRepository) lockAccounts(ids []string) (unlock func()) {
+	var indexes []int
+	for _, id := range ids {
+		hash := fnv.New32a()
+		hash.Write([]byte(id))
+		indexes = append(indexes, int(hash.Sum32()%accountLocks))
+	}
+	slices.Sort(indexes)
+	indexes = slices.Compact(indexes)
+
+	for _, i := range indexes {
+		r.locks[i].Lock()
+	}
+	return func() {
+		for _, i := range indexes {
+			r.locks[i].Unlock()
+		}
+	}
+}
+
+func (r *AccountRepository) DeleteAllAccounts(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.Accounts = make(map[string]entity.Account)
+	r.byID = nil
+	r.byBalance = nil
+	return nil
+}
+
+// OutboxEntries returns the oldest entries of the outbox after the given
+// sequence.
+func (r *AccountRepository) OutboxEntries(ctx context.Context, after uint64, limit int) ([]repository.OutboxEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := sort.Search(len(r.outbox), func(i int) bool { return r.outbox[i].Sequence > after })
+	return slices.Clone(r.outbox[i:min(i+limit, len(r.outbox))]), nil
+}
+
+func (r *AccountRepository) OutboxCursor(ctx context.Context, sink string) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cursors[sink], nil
+}
+
+func (r *AccountRepository) AdvanceOutboxCursor(ctx context.Context, sink string, sequence uint64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cursors[sink] = max(r.cursors[sink], sequence)
+	return nil
+}
+
+// PruneOutbox drops the entries up to the given sequence.
+func (r *AccountRepository) PruneOutbox(ctx context.Context, sequence uint64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := sort.Search(len(r.outbox), func(i int) bool { return r.outbox[i].Sequence > sequence })
+	r.outbox = slices.Delete(r.outbox, 0, i)
 	return nil
 }
+
+// Totals returns the number of accounts and the sum of their balances.
+func (r *AccountRepository) Totals(ctx context.Context) (accounts int, balance int, err error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, account := range r.Accounts {
+		balance += account.Balance
+	}
+	return len(r.Accounts), balance, nil
+}
+
+func (r *AccountRepository) put(account entity.Account) {
+	if previous, ok := r.Accounts[account.ID]; ok {
+		r.removeBalanceKey(balanceKey{balance: previous.Balance, id: previous.ID})
+	} else {
+		i := sort.SearchStrings(r.byID, account.ID)
+		r.byID = append(r.byID, "")
+		copy(r.byID[i+1:], r.byID[i:])
+		r.byID[i] = account.ID
+	}
+
+	key := balanceKey{balance: account.Balance, id: account.ID}
+	i := sort.Search(len(r.byBalance), func(i int) bool { return key.less(r.byBalance[i]) })
+	r.byBalance = append(r.byBalance, balanceKey{})
+	copy(r.byBalance[i+1:], r.byBalance[i:])
+	r.byBalance[i] = key
+
+	r.Accounts[account.ID] = account
+}
+
+// record appends events to the outbox, the caller holds the write lock.
+func (r *AccountRepository) record(events []event.Event) {
+	for _, e := range events {
+		r.sequence++
+		r.outbox = append(r.outbox, repository.OutboxEntry{Sequence: r.sequence, Event: e})
+	}
+}
+
+func (r *AccountRepository) removeBalanceKey(key balanceKey) {
+	i := sort.Search(len(r.byBalance), func(i int) bool { return !r.byBalance[i].less(key) })
+	if i < len(r.byBalance) && r.byBalance[i] == key {
+		r.byBalance = append(r.byBalance[:i], r.byBalance[i+1:]...)
+	}
+}
+
+func (r *AccountRepository) scanByID(query repository.ListAccountsQuery, visit func(id string) bool) {
+	prefix := query.Filter.IDPrefix
+
+	if query.Order == repository.SortOrderDesc {
+		end := sort.Search(len(r.byID), func(i int) bool {
+			return r.byID[i] > prefix && !strings.HasPrefix(r.byID[i], prefix)
+		})
+		if query.After != nil {
+			end = min(end, sort.SearchStrings(r.byID, query.After.ID))
+		}
+
+		for i := end - 1; i >= 0; i-- {
+			if !strings.HasPrefix(r.byID[i], prefix) || !visit(r.byID[i]) {
+				return
+			}
+		}
+		return
+	}
+
+	start := sort.SearchStrings(r.byID, prefix)
+	if query.After != nil {
+		start = max(start, sort.Search(len(r.byID), func(i int) bool { return r.byID[i] > query.After.ID }))
+	}
+
+	for i := start; i < len(r.byID); i++ {
+		if !strings.HasPrefix(r.byID[i], prefix) || !visit(r.byID[i]) {
+			return
+		}
+	}
+}
+
+func (r *AccountRepository) scanByBalance(query repository.ListAccountsQuery, visit func(id string) bool) {
+	minBalance, maxBalance := query.Filter.MinBalance, query.Filter.MaxBalance
+
+	if query.Order == repository.SortOrderDesc {
+		end := len(r.byBalance)
+		if maxBalance != nil {
+			end = sort.Search(len(r.byBalance), func(i int) bool { return r.byBalance[i].balance > *maxBalance })
+		}
+		if query.After != nil {
+			after := balanceKey{balance: query.After.Balance, id: query.After.ID}
+			end = min(end, sort.Search(len(r.byBalance), func(i int) bool { return !r.byBalance[i].less(after) }))
+		}
+
+		for i := end - 1; i >= 0; i-- {
+			key := r.byBalance[i]
+			if minBalance != nil && key.balance < *minBalance || !visit(key.id) {
+				return
+			}
+		}
+		return
+	}
+
+	start := 0
+	if minBalance != nil {
+		start = sort.Search(len(r.byBalance), func(i int) bool { return r.byBalance[i].balance >= *minBalance })
+	}
+	if query.After != nil {
+		after := balanceKey{balance: query.After.Balance, id: query.After.ID}
+		start = max(start, sort.Search(len(r.byBalance), func(i int) bool { return after.less(r.byBalance[i]) }))
+	}
+
+	for i := start; i < len(r.byBalance); i++ {
+		key := r.byBalance[i]
+		if maxBalance != nil && key.balance > *maxBalance || !visit(key.id) {
+			return
+		}
+	}
+}
+
+func matchesFilter(account entity.Account, filter repository.AccountFilter) bool {
+	if filter.MinBalance != nil && account.Balance < *filter.MinBalance {
+		return false
+	}
+	if filter.MaxBalance != nil && account.Balance > *filter.MaxBalance {
+		return false
+	}
+	if filter.Status != "" && account.Status != filter.Status {
+		return false
+	}
+	return strings.HasPrefix(account.ID, filter.IDPrefix)
+}
@@ -0,0 +1,42 @@
+package inmemory
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/test/contract"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestAccountRepository(t *testing.T) {
+	suite.Run(t, &contract.AccountRepositorySuite{
+		NewRepository: func() repository.AccountRepository {
+			return NewAccountRepository()
+		},
+	})
+}
+
+func TestAccountRepository_Totals(t *testing.T) {
+	t.Run("Should count accounts and sum their balances", func(t *testing.T) {
+		repo := NewAccountRepository()
+		repo.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+		repo.SaveAccount(context.Background(), entity.NewAccount("200", -20))
+
+		accounts, balance, err := repo.Totals(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, accounts)
+		assert.Equal(t, 80, balance)
+	})
+}
+
+func TestAccountRepository_Outbox(t *testing.T) {
+	suite.Run(t, &contract.OutboxRepositorySuite{
+		NewRepository: func() contract.OutboxStore {
+			return NewAccountRepository()
+		},
+	})
+}
@@ -0,0 +1,99 @@
+package inmemory
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"slices"
+	"sync"
+)
+
+type APIKeyRepository struct {
+	APIKeys map[string]entity.APIKey
+	byHash  map[string]string
+	mu      sync.RWMutex
+}
+
+func NewAPIKeyRepository() *APIKeyRepository {
+	return &APIKeyRepository{
+		APIKeys: make(map[string]entity.APIKey),
+		byHash:  make(map[string]string),
+	}
+}
+
+func (r *APIKeyRepository) GetAPIKeyByID(ctx context.Context, id string) (*entity.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.APIKeys[id]
+	if !ok {
+		return nil, domainErrs.ErrAPIKeyNotFound
+	}
+	return cloneAPIKey(key), nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.APIKeys[r.byHash[hash]]
+	if !ok {
+		return nil, domainErrs.ErrAPIKeyNotFound
+	}
+	return cloneAPIKey(key), nil
+}
+
+func (r *APIKeyRepository) SaveAPIKey(ctx context.Context, key *entity.APIKey) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.put(key)
+	return nil
+}
+
+func (r *APIKeyRepository) UpdateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.APIKeys[key.ID]; !ok {
+		return domainErrs.ErrAPIKeyNotFound
+	}
+
+	r.put(key)
+	return nil
+}
+
+func (r *APIKeyRepository) put(key *entity.APIKey) {
+	if previous, ok := r.APIKeys[key.ID]; ok {
+		delete(r.byHash, previous.Hash)
+	}
+
+	r.APIKeys[key.ID] = *cloneAPIKey(*key)
+	r.byHash[key.Hash] = key.ID
+}
+
+func cloneAPIKey(key entity.APIKey) *entity.APIKey {
+	key.Scopes = slices.Clone(key.Scopes)
+	key.AccountIDs = slices.Clone(key.AccountIDs)
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		key.RevokedAt = &revokedAt
+	}
+	return &key
+}
@@ -0,0 +1,17 @@
+package inmemory
+
+import (
+	"simple-bank/internal/domain/repository"
+	"simple-bank/test/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	suite.Run(t, &contract.APIKeyRepositorySuite{
+		NewRepository: func() repository.APIKeyRepository {
+			return NewAPIKeyRepository()
+		},
+	})
+}
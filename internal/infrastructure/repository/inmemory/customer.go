@@ -0,0 +1,119 @@
+package inmemory
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"slices"
+	"sync"
+)
+
+type CustomerRepository struct {
+	Customers map[string]entity.Customer
+	mu        sync.RWMutex
+}
+
+func NewCustomerRepository() *CustomerRepository {
+	return &CustomerRepository{
+		Customers: make(map[string]entity.Customer),
+	}
+}
+
+// Ping reports the repository as reachable, it lives in the process.
+func (r *CustomerRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (r *CustomerRepository) GetCustomerByID(ctx context.Context, id string) (*entity.Customer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	customer, ok := r.Customers[id]
+	if !ok {
+		return nil, domainErrs.ErrCustomerNotFound
+	}
+	return cloneCustomer(customer), nil
+}
+
+func (r *CustomerRepository) GetCustomersByAccountID(
+	ctx context.Context,
+	accountID string,
+) ([]*entity.Customer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	customers := []*entity.Customer{}
+	for _, customer := range r.Customers {
+		if customer.OwnsAccount(accountID) {
+			customers = append(customers, cloneCustomer(customer))
+		}
+	}
+
+	slices.SortFunc(customers, func(a, b *entity.Customer) int {
+		if a.ID < b.ID {
+			return -1
+		}
+		if a.ID > b.ID {
+			return 1
+		}
+		return 0
+	})
+	return customers, nil
+}
+
+func (r *CustomerRepository) SaveCustomer(ctx context.Context, customer *entity.Customer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Customers[customer.ID] = *cloneCustomer(*customer)
+	return nil
+}
+
+func (r *CustomerRepository) UpdateCustomer(ctx context.Context, customer *entity.Customer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Customers[customer.ID]; !ok {
+		return domainErrs.ErrCustomerNotFound
+	}
+
+	r.Customers[customer.ID] = *cloneCustomer(*customer)
+	return nil
+}
+
+func (r *CustomerRepository) DeleteCustomer(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Customers[id]; !ok {
+		return domainErrs.ErrCustomerNotFound
+	}
+
+	delete(r.Customers, id)
+	return nil
+}
+
+func cloneCustomer(customer entity.Customer) *entity.Customer {
+	customer.AccountIDs = slices.Clone(customer.AccountIDs)
+	return &customer
+}
@@ -0,0 +1,17 @@
+package inmemory
+
+import (
+	"simple-bank/internal/domain/repository"
+	"simple-bank/test/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCustomerRepository(t *testing.T) {
+	suite.Run(t, &contract.CustomerRepositorySuite{
+		NewRepository: func() repository.CustomerRepository {
+			return NewCustomerRepository()
+		},
+	})
+}
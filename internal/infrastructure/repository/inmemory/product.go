@@ -0,0 +1,57 @@
+package inmemory
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"sort"
+)
+
+type ProductRepository struct {
+	Products         map[string]entity.Product
+	DefaultProductID string
+}
+
+func NewProductRepository(defaultProductID string, products ...*entity.Product) *ProductRepository {
+	repo := &ProductRepository{
+		Products:         make(map[string]entity.Product),
+		DefaultProductID: defaultProductID,
+	}
+
+	for _, product := range products {
+		repo.Products[product.ID] = *product
+	}
+
+	return repo
+}
+
+func (r *ProductRepository) GetProductByID(ctx context.Context, id string) (*entity.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	product, ok := r.Products[id]
+	if !ok {
+		return nil, domainErrs.ErrProductNotFound
+	}
+	return &product, nil
+}
+
+func (r *ProductRepository) GetDefaultProduct(ctx context.Context) (*entity.Product, error) {
+	return r.GetProductByID(ctx, r.DefaultProductID)
+}
+
+func (r *ProductRepository) ListProducts(ctx context.Context) ([]*entity.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	products := make([]*entity.Product, 0, len(r.Products))
+	for id := range r.Products {
+		product := r.Products[id]
+		products = append(products, &product)
+	}
+
+	sort.Slice(products, func(i, j int) bool { return products[i].ID < products[j].ID })
+	return products, nil
+}
@@ -0,0 +1,17 @@
+package inmemory
+
+import (
+	"simple-bank/internal/domain/repository"
+	"simple-bank/test/contract"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestProductRepository(t *testing.T) {
+	suite.Run(t, &contract.ProductRepositorySuite{
+		NewRepository: func() repository.ProductRepository {
+			return NewProductRepository("checking", contract.ProductFixtures()...)
+		},
+	})
+}
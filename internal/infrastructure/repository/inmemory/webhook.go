@@ -0,0 +1,223 @@
+package inmemory
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type WebhookRepository struct {
+	Webhooks   map[string]entity.Webhook
+	Deliveries map[string]entity.WebhookDelivery
+	// order keeps delivery IDs in creation order.
+	order []string
+	mu    sync.RWMutex
+}
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{
+		Webhooks:   make(map[string]entity.Webhook),
+		Deliveries: make(map[string]entity.WebhookDelivery),
+	}
+}
+
+func (r *WebhookRepository) GetWebhookByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.Webhooks[id]
+	if !ok {
+		return nil, domainErrs.ErrWebhookNotFound
+	}
+	return cloneWebhook(webhook), nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]*entity.Webhook, 0, len(r.Webhooks))
+	for _, webhook := range r.Webhooks {
+		webhooks = append(webhooks, cloneWebhook(webhook))
+	}
+	slices.SortFunc(webhooks, func(a, b *entity.Webhook) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) SaveWebhook(ctx context.Context, webhook *entity.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Webhooks[webhook.ID] = *cloneWebhook(*webhook)
+	return nil
+}
+
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Webhooks[id]; !ok {
+		return domainErrs.ErrWebhookNotFound
+	}
+	delete(r.Webhooks, id)
+	return nil
+}
+
+func (r *WebhookRepository) GetDeliveryByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, ok := r.Deliveries[id]
+	if !ok {
+		return nil, domainErrs.ErrWebhookDeliveryNotFound
+	}
+	return cloneDelivery(delivery), nil
+}
+
+func (r *WebhookRepository) ListDeliveries(
+	ctx context.Context,
+	status entity.DeliveryStatus,
+) ([]*entity.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := []*entity.WebhookDelivery{}
+	for _, id := range r.order {
+		delivery := r.Deliveries[id]
+		if status == "" || delivery.Status == status {
+			deliveries = append(deliveries, cloneDelivery(delivery))
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) DueDeliveries(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*entity.WebhookDelivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := []*entity.WebhookDelivery{}
+	for _, id := range r.order {
+		if len(deliveries) == limit {
+			break
+		}
+		delivery := r.Deliveries[id]
+		if delivery.Status == entity.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, cloneDelivery(delivery))
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) SaveDeliveries(ctx context.Context, deliveries ...*entity.WebhookDelivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		if _, ok := r.Deliveries[delivery.ID]; ok {
+			continue
+		}
+		r.order = append(r.order, delivery.ID)
+		r.Deliveries[delivery.ID] = *cloneDelivery(*delivery)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.Deliveries[delivery.ID]; !ok {
+		return domainErrs.ErrWebhookDeliveryNotFound
+	}
+	r.Deliveries[delivery.ID] = *cloneDelivery(*delivery)
+	return nil
+}
+
+func (r *WebhookRepository) PruneDeliveries(ctx context.Context, before time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order := r.order[:0]
+	for _, id := range r.order {
+		delivery := r.Deliveries[id]
+		if delivery.Status != entity.DeliveryStatusPending && delivery.FinishedAt != nil && delivery.FinishedAt.Before(before) {
+			delete(r.Deliveries, id)
+			continue
+		}
+		order = append(order, id)
+	}
+	pruned := len(r.order) - len(order)
+	r.order = order
+	return pruned, nil
+}
+
+func cloneWebhook(webhook entity.Webhook) *entity.Webhook {
+	webhook.EventTypes = slices.Clone(webhook.EventTypes)
+	return &webhook
+}
+
+func cloneDelivery(delivery entity.WebhookDelivery) *entity.WebhookDelivery {
+	delivery.Payload = slices.Clone(delivery.Payload)
+	if delivery.DeliveredAt != nil {
+		deliveredAt := *delivery.DeliveredAt
+		delivery.DeliveredAt = &deliveredAt
+	}
+	if delivery.FinishedAt != nil {
+		finishedAt := *delivery.FinishedAt
+		delivery.FinishedAt = &finishedAt
+	}
+	return &delivery
+}
@@ -0,0 +1,196 @@
+package stream
+
+import (
+	"context"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/shared/dto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBufferSize is how many balance changes are kept for resuming
+// streams when no size is given.
+const DefaultBufferSize = 1024
+
+// subscriptionBuffer is how many changes a subscriber may lag behind before
+// it is dropped.
+const subscriptionBuffer = 64
+
+// Message is a balance change numbered in the order it was published. Its ID
+// is "<epoch>-<seq>", the epoch of the broker keeping IDs unique across
+// restarts.
+type Message struct {
+	ID string
+	// Reset opens a replay when the changes following the last ID are lost,
+	// the subscriber reads the balances again and resumes from ID. Its
+	// Change is empty.
+	Reset  bool
+	Change dto.BalanceChangeDTO
+	seq    uint64
+}
+
+// Broker turns the published events into balance changes and fans them out
+// to the subscribed streams. It keeps the last changes in a bounded buffer so
+// a stream can resume after the last change it received.
+type Broker struct {
+	mu            sync.Mutex
+	epoch         string
+	buffer        []Message
+	size          int
+	lastID        uint64
+	subscriptions map[*Subscription]struct{}
+	closed        bool
+}
+
+// NewBroker starts a broker whose epoch is its boot time, so the IDs of a
+// previous run are never taken for its own.
+func NewBroker(size int) *Broker {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	return &Broker{
+		epoch:         strconv.FormatInt(time.Now().UnixMilli(), 10),
+		size:          size,
+		subscriptions: map[*Subscription]struct{}{},
+	}
+}
+
+// Epoch prefixes the IDs of the messages of the broker.
+func (b *Broker) Epoch() string {
+	return b.epoch
+}
+
+// Subscription receives the changes of one account, or of every account when
+// the account is empty. C is closed when the subscriber falls behind or the
+// broker is closed.
+type Subscription struct {
+	C       <-chan Message
+	c       chan Message
+	account string
+	broker  *Broker
+}
+
+// Handle is the event.Handler feeding the broker. It never blocks on the
+// subscribers, so it is subscribed synchronously: a change reaches the
+// streams before the operation that made it answers.
+func (b *Broker) Handle(ctx context.Context, e event.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, balance := range e.Data.Balances() {
+		b.lastID++
+		message := Message{ID: b.id(b.lastID), seq: b.lastID, Change: dto.BalanceChangeDTO{
+			EventID:    e.ID,
+			EventType:  string(e.Type),
+			Account:    balance.Account,
+			Balance:    balance.Balance,
+			OccurredAt: e.OccurredAt,
+		}}
+
+		if len(b.buffer) == b.size {
+			b.buffer = b.buffer[1:]
+		}
+		b.buffer = append(b.buffer, message)
+
+		for s := range b.subscriptions {
+			if !s.accepts(message) {
+				continue
+			}
+			select {
+			case s.c <- message:
+			default:
+				// a slow stream must not hold the money movements back, its
+				// client reconnects and resumes from the buffer.
+				b.drop(s)
+			}
+		}
+	}
+	return nil
+}
+
+// Subscribe starts a subscription to the changes of account. The buffered
+// changes published after lastID are returned to be sent first, none when
+// lastID is empty. When the changes following lastID are no longer buffered,
+// or it was published by another broker, the replay is a single reset
+// message instead.
+func (b *Broker) Subscribe(account string, lastID string) (replay []Message, s *Subscription) {
+	c := make(chan Message, subscriptionBuffer)
+	s = &Subscription{C: c, c: c, account: account, broker: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(c)
+		return nil, s
+	}
+	b.subscriptions[s] = struct{}{}
+
+	if lastID == "" {
+		return nil, s
+	}
+	seq, ok := b.resume(lastID)
+	if !ok {
+		return []Message{{ID: b.id(b.lastID), Reset: true}}, s
+	}
+	for _, message := range b.buffer {
+		if message.seq > seq && s.accepts(message) {
+			replay = append(replay, message)
+		}
+	}
+	return replay, s
+}
+
+// resume returns the seq of lastID when every change following it is still
+// buffered.
+func (b *Broker) resume(lastID string) (uint64, bool) {
+	epoch, number, found := strings.Cut(lastID, "-")
+	if !found || epoch != b.epoch {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(number, 10, 64)
+	if err != nil || seq > b.lastID {
+		return 0, false
+	}
+	if len(b.buffer) > 0 && seq+1 < b.buffer[0].seq {
+		return 0, false
+	}
+	return seq, true
+}
+
+func (b *Broker) id(seq uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// Close ends every subscription and refuses the new ones, so streams do not
+// hold the server open on shutdown.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for s := range b.subscriptions {
+		b.drop(s)
+	}
+}
+
+func (b *Broker) drop(s *Subscription) {
+	delete(b.subscriptions, s)
+	close(s.c)
+}
+
+func (s *Subscription) accepts(message Message) bool {
+	return s.account == "" || s.account == message.Change.Account
+}
+
+// Close ends the subscription, it is safe to call it more than once.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	if _, ok := s.broker.subscriptions[s]; ok {
+		s.broker.drop(s)
+	}
+}
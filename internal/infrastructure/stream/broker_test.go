@@ -0,0 +1,144 @@
+package stream
+
+import (
+	"context"
+	"simple-bank/internal/domain/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestBrokerSuite struct {
+	suite.Suite
+	broker *Broker
+}
+
+func (suite *TestBrokerSuite) SetupSubTest() {
+	suite.broker = NewBroker(3)
+	suite.broker.epoch = "7"
+}
+
+func (suite *TestBrokerSuite) deposit(account string, balance int) {
+	suite.broker.Handle(context.Background(), event.New("E", time.Now(), event.FundsDeposited{
+		Account: account,
+		Amount:  10,
+		Balance: balance,
+	}))
+}
+
+func ids(messages []Message) []string {
+	result := []string{}
+	for _, message := range messages {
+		result = append(result, message.ID)
+	}
+	return result
+}
+
+func (suite *TestBrokerSuite) TestSubscribe() {
+	suite.Run("Should send the changes of the subscribed account", func() {
+		_, s := suite.broker.Subscribe("100", "")
+		defer s.Close()
+
+		suite.deposit("200", 10)
+		suite.deposit("100", 20)
+
+		message := <-s.C
+		suite.Equal("7-2", message.ID)
+		suite.Equal("100", message.Change.Account)
+		suite.Equal(20, message.Change.Balance)
+		suite.Equal("funds.deposited", message.Change.EventType)
+		suite.Empty(s.C)
+	})
+
+	suite.Run("Should send both sides of a transfer to the global stream", func() {
+		_, s := suite.broker.Subscribe("", "")
+		defer s.Close()
+
+		suite.broker.Handle(context.Background(), event.New("E", time.Now(), event.TransferCompleted{
+			Origin:             "100",
+			Destination:        "200",
+			Amount:             10,
+			OriginBalance:      90,
+			DestinationBalance: 10,
+		}))
+
+		suite.Equal("100", (<-s.C).Change.Account)
+		suite.Equal("200", (<-s.C).Change.Account)
+	})
+
+	suite.Run("Should replay the buffered changes after the last received one", func() {
+		suite.deposit("100", 10)
+		suite.deposit("200", 10)
+		suite.deposit("100", 20)
+
+		replay, s := suite.broker.Subscribe("100", "7-1")
+		defer s.Close()
+
+		suite.Equal([]string{"7-3"}, ids(replay))
+	})
+
+	suite.Run("Should only keep the last changes", func() {
+		for i := 1; i <= 5; i++ {
+			suite.deposit("100", i)
+		}
+
+		replay, s := suite.broker.Subscribe("", "7-2")
+		defer s.Close()
+
+		suite.Equal([]string{"7-3", "7-4", "7-5"}, ids(replay))
+	})
+
+	suite.Run("Should reset a subscription whose next change is no longer buffered", func() {
+		for i := 1; i <= 5; i++ {
+			suite.deposit("100", i)
+		}
+
+		replay, s := suite.broker.Subscribe("", "7-1")
+		defer s.Close()
+
+		suite.Equal([]Message{{ID: "7-5", Reset: true}}, replay)
+	})
+
+	suite.Run("Should reset a subscription resuming from another epoch", func() {
+		suite.deposit("100", 10)
+
+		for _, lastID := range []string{"6-1", "7-2", "1"} {
+			replay, s := suite.broker.Subscribe("", lastID)
+			s.Close()
+
+			suite.Equal([]Message{{ID: "7-1", Reset: true}}, replay, lastID)
+		}
+	})
+
+	suite.Run("Should drop subscribers falling behind", func() {
+		_, s := suite.broker.Subscribe("", "")
+
+		for i := 0; i <= subscriptionBuffer; i++ {
+			suite.deposit("100", i)
+		}
+
+		received := 0
+		for range s.C {
+			received++
+		}
+		suite.Equal(subscriptionBuffer, received)
+		s.Close()
+	})
+
+	suite.Run("Should end the subscriptions on close", func() {
+		_, s := suite.broker.Subscribe("", "")
+
+		suite.broker.Close()
+		_, late := suite.broker.Subscribe("", "")
+
+		_, open := <-s.C
+		suite.False(open)
+		_, open = <-late.C
+		suite.False(open)
+	})
+}
+
+func TestBroker(t *testing.T) {
+	suite.Run(t, new(TestBrokerSuite))
+}
@@ -0,0 +1,55 @@
+package telemetry
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	domainErrs "simple-bank/internal/domain/errors"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestMiddleware(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	serve := func(handler echo.HandlerFunc) (sdktrace.ReadOnlySpan, error) {
+		spans := tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/balance", nil), httptest.NewRecorder())
+		c.SetPath("/balance")
+		err := Middleware()(handler)(c)
+
+		require.Len(t, spans.Ended(), 1)
+		return spans.Ended()[0], err
+	}
+
+	t.Run("Should return the error and record the server failure on the span", func(t *testing.T) {
+		failure := errors.New("connection refused")
+
+		span, err := serve(func(c echo.Context) error { return failure })
+
+		assert.Equal(t, failure, err)
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Contains(t, span.Attributes(), semconv.HTTPResponseStatusCode(http.StatusInternalServerError))
+		require.Len(t, span.Events(), 1)
+		assert.Equal(t, "exception", span.Events()[0].Name)
+	})
+
+	t.Run("Should not mark client failures as span errors", func(t *testing.T) {
+		span, err := serve(func(c echo.Context) error { return domainErrs.ErrAccountNotFound })
+
+		assert.Equal(t, domainErrs.ErrAccountNotFound, err)
+		assert.Equal(t, codes.Unset, span.Status().Code)
+		assert.Contains(t, span.Attributes(), semconv.HTTPResponseStatusCode(http.StatusNotFound))
+	})
+}
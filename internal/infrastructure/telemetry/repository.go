@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const keyAccountID = attribute.Key("bank.account_id")
+
+type accountRepository struct {
+	next repository.AccountRepository
+}
+
+// NewAccountRepository opens a span around every call made to next.
+func NewAccountRepository(next repository.AccountRepository) repository.AccountRepository {
+	return &accountRepository{next: next}
+}
+
+func (r *accountRepository) GetAccountByID(ctx context.Context, id string) (account *entity.Account, err error) {
+	ctx, span := tracing.Start(ctx, "AccountRepository.GetAccountByID", keyAccountID.String(id))
+	defer tracing.End(span, &err)
+	return r.next.GetAccountByID(ctx, id)
+}
+
+func (r *accountRepository) ListAccounts(
+	ctx context.Context,
+	query repository.ListAccountsQuery,
+) (result *repository.ListAccountsResult, err error) {
+	ctx, span := tracing.Start(ctx, "AccountRepository.ListAccounts")
+	defer tracing.End(span, &err)
+	return r.next.ListAccounts(ctx, query)
+}
+
+func (r *accountRepository) UpdateAccount(ctx context.Context, account *entity.Account, events ...event.Event) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountRepository.UpdateAccount", keyAccountID.String(account.ID))
+	defer tracing.End(span, &err)
+	return r.next.UpdateAccount(ctx, account, events...)
+}
+
+func (r *accountRepository) SaveAccount(ctx context.Context, account *entity.Account, events ...event.Event) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountRepository.SaveAccount", keyAccountID.String(account.ID))
+	defer tracing.End(span, &err)
+	return r.next.SaveAccount(ctx, account, events...)
+}
+
+func (r *accountRepository) UpdateAccounts(
+	ctx context.Context,
+	ids []string,
+	update repository.AccountsUpdate,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountRepository.UpdateAccounts", keyAccountID.StringSlice(ids))
+	defer tracing.End(span, &err)
+	return r.next.UpdateAccounts(ctx, ids, update)
+}
+
+func (r *accountRepository) DeleteAllAccounts(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountRepository.DeleteAllAccounts")
+	defer tracing.End(span, &err)
+	return r.next.DeleteAllAccounts(ctx)
+}
@@ -0,0 +1,49 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+)
+
+const serviceName = "simple-bank"
+
+// Setup installs the global tracer provider for exporter and the W3C trace
+// context propagator. The returned function flushes pending spans.
+func Setup(exporter string, w io.Writer) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	switch exporter {
+	case ExporterNone:
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		spanExporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, err
+		}
+		provider := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(spanExporter),
+			sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+		)
+		otel.SetTracerProvider(provider)
+		return provider.Shutdown, nil
+	}
+	return nil, fmt.Errorf("unknown trace exporter %q", exporter)
+}
@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"simple-bank/internal/shared/tracing"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	t.Run("Should write spans with the stdout exporter", func(t *testing.T) {
+		var buf bytes.Buffer
+		shutdown, err := Setup(ExporterStdout, &buf)
+		require.NoError(t, err)
+
+		_, span := tracing.Start(context.Background(), "DepositUseCase.Execute")
+		span.End()
+		require.NoError(t, shutdown(context.Background()))
+
+		assert.Contains(t, buf.String(), `"Name":"DepositUseCase.Execute"`)
+	})
+
+	t.Run("Should record nothing with the none exporter", func(t *testing.T) {
+		shutdown, err := Setup(ExporterNone, nil)
+		require.NoError(t, err)
+
+		_, span := tracing.Start(context.Background(), "DepositUseCase.Execute")
+		span.End()
+
+		assert.False(t, span.SpanContext().IsValid())
+		assert.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("Should refuse unknown exporters", func(t *testing.T) {
+		_, err := Setup("jaeger", nil)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,247 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/egress"
+	"simple-bank/internal/shared/logging"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	batchSize   = 100
+	concurrency = 8
+	// pruneInterval spaces the deletions of the expired deliveries, which
+	// scan every delivery.
+	pruneInterval = time.Hour
+)
+
+// DefaultRetention keeps the finished deliveries a week, long enough to
+// inspect and redeliver them.
+const DefaultRetention = 7 * 24 * time.Hour
+
+// RetryPolicy spaces the attempts of a delivery exponentially: the n-th retry
+// waits BaseDelay * 2^(n-1), at most MaxDelay. A delivery failing
+// MaxAttempts times goes to the dead letters.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 8, BaseDelay: time.Second, MaxDelay: 10 * time.Minute}
+
+func (p RetryPolicy) next(attempts int, now time.Time) *time.Time {
+	if attempts >= p.MaxAttempts {
+		return nil
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	retryAt := now.Add(min(delay, p.MaxDelay))
+	return &retryAt
+}
+
+type Config struct {
+	// Client sends the deliveries, a client with a 10s timeout refusing the
+	// addresses Targets refuses when nil.
+	Client  *http.Client
+	Targets egress.Policy
+	Retry   RetryPolicy
+	// Retention is how long the delivered and dead deliveries are kept,
+	// DefaultRetention when zero.
+	Retention time.Duration
+}
+
+// Dispatcher turns events into signed deliveries to the subscribed webhooks
+// and sends them until they succeed or run out of attempts.
+type Dispatcher struct {
+	repo      repository.WebhookRepository
+	client    *http.Client
+	retry     RetryPolicy
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewDispatcher(repo repository.WebhookRepository, config Config) *Dispatcher {
+	if config.Client == nil {
+		config.Client = config.Targets.Client(10 * time.Second)
+	}
+	if config.Retry.MaxAttempts == 0 {
+		config.Retry = DefaultRetryPolicy
+	}
+	if config.Retention == 0 {
+		config.Retention = DefaultRetention
+	}
+	return &Dispatcher{repo: repo, client: config.Client, retry: config.Retry, retention: config.Retention, now: time.Now}
+}
+
+// Publish queues a delivery of e to every webhook subscribed to its type.
+// Publishing the same event again queues nothing new.
+func (d *Dispatcher) Publish(ctx context.Context, e event.Event) error {
+	webhooks, err := d.repo.ListWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	now := d.now().UTC()
+	var deliveries []*entity.WebhookDelivery
+	for _, webhook := range webhooks {
+		if webhook.Accepts(e.Type) {
+			deliveries = append(deliveries, entity.NewWebhookDelivery(deliveryID(webhook.ID, e.ID), webhook, e, payload, now))
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return d.repo.SaveDeliveries(ctx, deliveries...)
+}
+
+// DeliverDue attempts the deliveries due now and returns how many it
+// attempted.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.repo.DueDeliveries(ctx, d.now(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(deliveries))
+	slots := make(chan struct{}, concurrency)
+	for i, delivery := range deliveries {
+		i, delivery := i, delivery
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = d.deliver(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries), errors.Join(errs...)
+}
+
+// Prune deletes the deliveries delivered or dead for longer than the
+// retention and returns how many it deleted.
+func (d *Dispatcher) Prune(ctx context.Context) (int, error) {
+	return d.repo.PruneDeliveries(ctx, d.now().Add(-d.retention))
+}
+
+// Run delivers the due deliveries every interval, and prunes the expired
+// ones every hour, until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	pruner := time.NewTicker(pruneInterval)
+	defer pruner.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "fail to deliver webhooks", logging.KeyError, err.Error())
+			}
+		case <-pruner.C:
+			if _, err := d.Prune(ctx); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "fail to prune webhook deliveries", logging.KeyError, err.Error())
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	webhook, err := d.repo.GetWebhookByID(ctx, delivery.WebhookID)
+	if errors.Is(err, domainErrs.ErrWebhookNotFound) {
+		delivery.Fail("webhook deleted", d.now().UTC(), nil)
+		return d.repo.UpdateDelivery(ctx, delivery)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := d.send(ctx, webhook, delivery); err != nil {
+		now := d.now().UTC()
+		retryAt := d.retry.next(delivery.Attempts+1, now)
+		delivery.Fail(err.Error(), now, retryAt)
+		logDeliveryFailure(ctx, delivery, retryAt == nil)
+	} else {
+		delivery.Succeed(d.now().UTC())
+	}
+
+	return d.repo.UpdateDelivery(ctx, delivery)
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "simple-bank-webhooks")
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+func logDeliveryFailure(ctx context.Context, delivery *entity.WebhookDelivery, dead bool) {
+	attrs := []any{
+		slog.String("delivery_id", delivery.ID),
+		slog.String("webhook_id", delivery.WebhookID),
+		slog.Int("attempts", delivery.Attempts),
+		slog.String(logging.KeyError, delivery.LastError),
+	}
+	if dead {
+		slog.ErrorContext(ctx, "webhook delivery moved to the dead letters", attrs...)
+		return
+	}
+	slog.WarnContext(ctx, "webhook delivery failed", attrs...)
+}
+
+// deliveryID is derived from the webhook and the event, so receivers can
+// also use it to drop duplicates.
+func deliveryID(webhookID, eventID string) string {
+	sum := sha256.Sum256([]byte(webhookID + "/" + eventID))
+	return hex.EncodeToString(sum[:8])
+}
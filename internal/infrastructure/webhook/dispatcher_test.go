@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/infrastructure/repository/inmemory"
+	"simple-bank/internal/shared/egress"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testSecret = "0123456789abcdef"
+
+type TestDispatcherSuite struct {
+	suite.Suite
+	repo       *inmemory.WebhookRepository
+	dispatcher *Dispatcher
+	now        time.Time
+	status     atomic.Int32
+	received   chan *http.Request
+	bodies     chan []byte
+	server     *httptest.Server
+}
+
+func (suite *TestDispatcherSuite) SetupSubTest() {
+	suite.status.Store(http.StatusNoContent)
+	suite.received = make(chan *http.Request, 10)
+	suite.bodies = make(chan []byte, 10)
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		suite.received <- r
+		suite.bodies <- body
+		w.WriteHeader(int(suite.status.Load()))
+	}))
+
+	suite.now = time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	suite.repo = inmemory.NewWebhookRepository()
+	suite.dispatcher = NewDispatcher(suite.repo, Config{
+		Targets:   egress.Policy{AllowPrivate: true},
+		Retry:     RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute},
+		Retention: time.Hour,
+	})
+	suite.dispatcher.now = func() time.Time { return suite.now }
+}
+
+func (suite *TestDispatcherSuite) TearDownSubTest() {
+	suite.server.Close()
+}
+
+func (suite *TestDispatcherSuite) subscribe(eventTypes ...event.Type) *entity.Webhook {
+	webhook := entity.NewWebhook("W1", suite.server.URL, eventTypes, testSecret, suite.now)
+	suite.Require().NoError(suite.repo.SaveWebhook(context.Background(), webhook))
+	return webhook
+}
+
+func deposited() event.Event {
+	return event.New("E1", time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC), event.FundsDeposited{
+		Account: "100",
+		Amount:  10,
+		Balance: 110,
+	})
+}
+
+func (suite *TestDispatcherSuite) TestDeliver() {
+	suite.Run("Should send signed deliveries to subscribed webhooks", func() {
+		suite.subscribe(event.TypeFundsDeposited)
+		suite.Require().NoError(suite.dispatcher.Publish(context.Background(), deposited()))
+
+		attempted, err := suite.dispatcher.DeliverDue(context.Background())
+
+		suite.NoError(err)
+		suite.Equal(1, attempted)
+		req, body := <-suite.received, <-suite.bodies
+		timestamp, _ := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+		suite.Equal(suite.now.Unix(), timestamp)
+		suite.True(Verify(testSecret, timestamp, body, req.Header.Get(HeaderSignature)))
+		suite.Equal("funds.deposited", req.Header.Get(HeaderEvent))
+		suite.NotEmpty(req.Header.Get(HeaderDelivery))
+		suite.JSONEq(`{
+			"id": "E1",
+			"type": "funds.deposited",
+			"occurred_at": "2024-03-10T00:00:00Z",
+			"data": {"account": "100", "amount": 10, "fee": 0, "balance": 110}
+		}`, string(body))
+
+		deliveries, _ := suite.repo.ListDeliveries(context.Background(), entity.DeliveryStatusDelivered)
+		suite.Len(deliveries, 1)
+	})
+
+	suite.Run("Should skip webhooks not subscribed to the event", func() {
+		suite.subscribe(event.TypeFundsWithdrawn)
+		suite.Require().NoError(suite.dispatcher.Publish(context.Background(), deposited()))
+
+		attempted, err := suite.dispatcher.DeliverDue(context.Background())
+
+		suite.NoError(err)
+		suite.Zero(attempted)
+	})
+
+	suite.Run("Should queue an event published twice once", func() {
+		suite.subscribe(event.TypeFundsDeposited)
+		suite.Require().NoError(suite.dispatcher.Publish(context.Background(), deposited()))
+		suite.Require().NoError(suite.dispatcher.Publish(context.Background(), deposited()))
+
+		deliveries, _ := suite.repo.ListDeliveries(context.Background(), "")
+		suite.Len(deliveries, 1)
+	})
+}
+
+func (suite *TestDispatcherSuite) TestRetry() {
+	suite.Run("Should retry with exponential backoff then give up", func() {
+		suite.status.Store(http.StatusServiceUnavailable)
+		suite.subscribe(event.TypeFundsDeposited)
+		suite.Require().NoError(suite.dispatcher.Publish(context.Background(), deposited()))
+		start := suite.now
+
+		suite.dispatcher.DeliverDue(context.Background())
+		pending, _ := suite.repo.ListDeliveries(context.Background(), entity.DeliveryStatusPending)
+		suite.Require().Len(pending, 1)
+		suite.Equal(start.Add(time.Second), pending[0].NextAttemptAt)
+		suite.Equal("unexpected status 503 Service Unavailable", pending[0].LastError)
+
+		attempted, _ := suite.dispatcher.DeliverDue(context.Background())
+		suite.Zero(attempted, "not due yet")
+
+		suite.now = start.Add(time.Second)
+		suite.dispatcher.DeliverDue(context.Background())
+		pending, _ = suite.repo.ListDeliveries(context.Background(), entity.DeliveryStatusPending)
+		suite.Require().Len(pending, 1)
+		suite.Equal(suite.now.Add(2*time.Second), pending[0].NextAttemptAt)
+
+		suite.now = suite.now.Add(2 * time.Second)
+		suite.dispatcher.DeliverDue(context.Background())
+		dead, _ := suite.repo.ListDeliveries(context.Background(), entity.DeliveryStatusDead)
+		suite.Require().Len(dead, 1)
+		suite.Equal(3, dead[0].Attempts)
+	})
+
+	suite.Run("Should give up on deliveries of deleted webhooks", func() {
+		suite.subscribe(event.TypeFundsDeposited)
+		suite.Require().NoError(suite.dispatcher.Publish(context.Background(), deposited()))
+		suite.Require().NoError(suite.repo.DeleteWebhook(context.Background(), "W1"))
+
+		suite.dispatcher.DeliverDue(context.Background())
+
+		dead, _ := suite.repo.ListDeliveries(context.Background(), entity.DeliveryStatusDead)
+		suite.Require().Len(dead, 1)
+		suite.Equal("webhook deleted", dead[0].LastError)
+	})
+}
+
+func (suite *TestDispatcherSuite) TestTargets() {
+	suite.Run("Should refuse to deliver to the bank network", func() {
+		suite.dispatcher = NewDispatcher(suite.repo, Config{})
+		suite.dispatcher.now = func() time.Time { return suite.now }
+		suite.subscribe(event.TypeFundsDeposited)
+		suite.Require().NoError(suite.dispatcher.Publish(context.Background(), deposited()))
+
+		suite.dispatcher.DeliverDue(context.Background())
+
+		pending, _ := suite.repo.ListDeliveries(context.Background(), entity.DeliveryStatusPending)
+		suite.Require().Len(pending, 1)
+		suite.Contains(pending[0].LastError, egress.ErrForbiddenAddress.Error())
+		suite.Empty(suite.received)
+	})
+}
+
+func (suite *TestDispatcherSuite) TestPrune() {
+	suite.Run("Should delete the finished deliveries older than the retention", func() {
+		suite.subscribe(event.TypeFundsDeposited)
+		suite.Require().NoError(suite.dispatcher.Publish(context.Background(), deposited()))
+		suite.dispatcher.DeliverDue(context.Background())
+
+		pruned, err := suite.dispatcher.Prune(context.Background())
+		suite.NoError(err)
+		suite.Zero(pruned, "within the retention")
+
+		suite.now = suite.now.Add(time.Hour + time.Second)
+		pruned, err = suite.dispatcher.Prune(context.Background())
+		suite.NoError(err)
+		suite.Equal(1, pruned)
+		deliveries, _ := suite.repo.ListDeliveries(context.Background(), "")
+		suite.Empty(deliveries)
+	})
+}
+
+func TestDispatcher(t *testing.T) {
+	suite.Run(t, new(TestDispatcherSuite))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	for attempts, delay := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second} {
+		retryAt := policy.next(attempts, now)
+		if retryAt == nil || !retryAt.Equal(now.Add(delay)) {
+			t.Errorf("attempt %d: expected a retry after %s, got %v", attempts, delay, retryAt)
+		}
+	}
+
+	if policy.next(10, now) != nil {
+		t.Error("expected no retry after the last attempt")
+	}
+}
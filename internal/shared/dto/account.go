@@ -4,3 +4,10 @@ type AccountDTO struct {
 	ID      string `json:"id"`
 	Balance int    `json:"balance"`
 }
+
+type AccountDetailsDTO struct {
+	ID      string `json:"id"`
+	Balance int    `json:"balance"`
+	Status  string `json:"status"`
+	Product string `json:"product"`
+}
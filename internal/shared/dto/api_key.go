@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type APIKeyDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	AccountIDs []string   `json:"account_ids"`
+	CustomerID string     `json:"customer_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// BalanceChangeDTO is the balance an event left on an account.
+type BalanceChangeDTO struct {
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Account    string    `json:"account"`
+	Balance    int       `json:"balance"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type CustomerDTO struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Email      string   `json:"email"`
+	Phone      string   `json:"phone"`
+	KYCStatus  string   `json:"kyc_status"`
+	AccountIDs []string `json:"account_ids"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+type FeeScheduleDTO struct {
+	Deposit    int `json:"deposit"`
+	Withdrawal int `json:"withdrawal"`
+	Transfer   int `json:"transfer"`
+}
+
+type ProductDTO struct {
+	ID                     string         `json:"id"`
+	Name                   string         `json:"name"`
+	OverdraftLimit         int            `json:"overdraft_limit"`
+	MonthlyWithdrawalLimit int            `json:"monthly_withdrawal_limit"`
+	Fees                   FeeScheduleDTO `json:"fees"`
+}
@@ -0,0 +1,126 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress refuses the requests to the network of the bank, so a
+// URL chosen by a client, such as a webhook, cannot reach internal services.
+var ErrForbiddenAddress = errors.New("Address is not globally reachable")
+
+// specialPrefixes are the global unicast ranges that are not globally
+// reachable or that translate to other addresses, from the IANA special
+// purpose registries.
+var specialPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // this network
+	netip.MustParsePrefix("100.64.0.0/10"),   // shared address space, carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local NAT64
+	netip.MustParsePrefix("100::/64"),        // discard
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments, Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4
+}
+
+// Resolver looks up the addresses of a host, net.Resolver implements it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// Policy tells which addresses outgoing requests may reach. The zero Policy
+// only lets globally reachable addresses through.
+type Policy struct {
+	// AllowPrivate lets requests reach every address, for local development
+	// and tests.
+	AllowPrivate bool
+	// Resolver looks up host names, net.DefaultResolver when nil.
+	Resolver Resolver
+}
+
+// CheckAddr refuses the addresses that are not globally reachable, which
+// covers the bank network. Rather than listing what to refuse, it only lets
+// global unicast addresses outside of the private and special ranges through.
+func (p Policy) CheckAddr(addr netip.Addr) error {
+	if p.AllowPrivate {
+		return nil
+	}
+
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+	for _, prefix := range specialPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+		}
+	}
+	return nil
+}
+
+// CheckHost refuses host when it is, or resolves to, an address of the bank
+// network. Names that do not resolve are let through: they are checked again
+// when dialed, as they may resolve elsewhere by then.
+func (p Policy) CheckHost(ctx context.Context, host string) error {
+	if p.AllowPrivate {
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return p.CheckAddr(addr)
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(host, "."))
+	if name == "localhost" || strings.HasSuffix(name, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+
+	var resolver Resolver = net.DefaultResolver
+	if p.Resolver != nil {
+		resolver = p.Resolver
+	}
+	addrs, err := resolver.LookupNetIP(ctx, "ip", name)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if err := p.CheckAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control refusing the connections to the bank
+// network, it sees the address once resolved so no name can get around it.
+func (p Policy) Control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	return p.CheckAddr(addrPort.Addr())
+}
+
+// Client is an HTTP client whose connections are checked by Control. It does
+// not go through proxies, which would be checked instead of the target.
+func (p Policy) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: p.Control}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
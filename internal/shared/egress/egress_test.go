@@ -0,0 +1,76 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type resolverStub map[string][]netip.Addr
+
+func (r resolverStub) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func TestPolicy(t *testing.T) {
+	policy := Policy{Resolver: resolverStub{
+		"example.com":  {netip.MustParseAddr("93.184.215.14")},
+		"internal.com": {netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("10.0.0.1")},
+	}}
+
+	t.Run("Should refuse the addresses of the bank network", func(t *testing.T) {
+		for _, host := range []string{
+			"127.0.0.1", "[::1]", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+			"[fe80::1]", "[fd00::1]", "0.0.0.0", "[::ffff:127.0.0.1]", "localhost", "api.localhost",
+		} {
+			assert.ErrorIs(t, policy.CheckHost(context.Background(), host), ErrForbiddenAddress, host)
+		}
+	})
+
+	t.Run("Should refuse the addresses that are not globally reachable", func(t *testing.T) {
+		for _, host := range []string{
+			"0.1.2.3", "100.64.0.1", "100.127.255.254", "192.0.0.8", "198.18.0.1", "240.0.0.1",
+			"255.255.255.255", "224.0.0.1", "[64:ff9b::a00:1]", "[64:ff9b:1::1]", "[2002:a00:1::1]",
+			"[2001::1]", "[2001:db8::1]", "[ff02::1]",
+		} {
+			assert.ErrorIs(t, policy.CheckHost(context.Background(), host), ErrForbiddenAddress, host)
+		}
+	})
+
+	t.Run("Should refuse names resolving to the bank network", func(t *testing.T) {
+		assert.ErrorIs(t, policy.CheckHost(context.Background(), "internal.com"), ErrForbiddenAddress)
+	})
+
+	t.Run("Should let public and unresolved hosts through", func(t *testing.T) {
+		for _, host := range []string{"93.184.215.14", "[2606:2800:21f:cb07:6820:80da:af6b:8b2c]", "example.com", "unknown.com"} {
+			assert.NoError(t, policy.CheckHost(context.Background(), host), host)
+		}
+	})
+
+	t.Run("Should let every address through when private ones are allowed", func(t *testing.T) {
+		assert.NoError(t, Policy{AllowPrivate: true}.CheckHost(context.Background(), "127.0.0.1"))
+	})
+
+	t.Run("Should refuse to dial the bank network", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		_, err := Policy{}.Client(time.Second).Get(server.URL)
+		assert.ErrorIs(t, err, ErrForbiddenAddress)
+
+		res, err := Policy{AllowPrivate: true}.Client(time.Second).Get(server.URL)
+		if assert.NoError(t, err) {
+			res.Body.Close()
+		}
+	})
+}
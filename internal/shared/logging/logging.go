@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	KeyRequestID   = "request_id"
+	KeyTraceID     = "trace_id"
+	KeyEventType   = "event_type"
+	KeyOrigin      = "origin"
+	KeyDestination = "destination"
+	KeyAmount      = "amount"
+	KeyOutcome     = "outcome"
+	KeyError       = "error"
+)
+
+const redacted = "[REDACTED]"
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type Options struct {
+	Level slog.Leveler
+	// RedactAmounts replaces the value of every amount attribute.
+	RedactAmounts bool
+}
+
+// NewHandler returns a JSON handler adding the request ID and the trace ID
+// found in the context to every record.
+func NewHandler(w io.Writer, options Options) slog.Handler {
+	handlerOptions := &slog.HandlerOptions{Level: options.Level}
+	if options.RedactAmounts {
+		handlerOptions.ReplaceAttr = redactAmounts
+	}
+	return &contextHandler{Handler: slog.NewJSONHandler(w, handlerOptions)}
+}
+
+func redactAmounts(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Key == KeyAmount {
+		return slog.String(KeyAmount, redacted)
+	}
+	return attr
+}
+
+// ParseLevel reads debug, info, warn or error.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(name))); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+	return level, nil
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestIDFromContext(ctx); id != "" {
+		record.AddAttrs(slog.String(KeyRequestID, id))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		record.AddAttrs(slog.String(KeyTraceID, spanContext.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logLine(t *testing.T, options Options, log func(logger *slog.Logger)) map[string]any {
+	var buf bytes.Buffer
+	log(slog.New(NewHandler(&buf, options)))
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	return line
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("Should add the request ID of the context", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "req-1")
+
+		line := logLine(t, Options{}, func(logger *slog.Logger) {
+			logger.InfoContext(ctx, "deposit", KeyAmount, 100)
+		})
+
+		assert.Equal(t, "req-1", line[KeyRequestID])
+		assert.Equal(t, float64(100), line[KeyAmount])
+	})
+
+	t.Run("Should redact amounts", func(t *testing.T) {
+		line := logLine(t, Options{RedactAmounts: true}, func(logger *slog.Logger) {
+			logger.With(KeyOrigin, "100").Info("withdraw", KeyAmount, 100)
+		})
+
+		assert.Equal(t, "[REDACTED]", line[KeyAmount])
+		assert.Equal(t, "100", line[KeyOrigin])
+	})
+
+	t.Run("Should drop records below the level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(NewHandler(&buf, Options{Level: slog.LevelWarn}))
+
+		logger.Info("ignored")
+
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Run("Should parse level names", func(t *testing.T) {
+		level, err := ParseLevel("debug")
+
+		assert.NoError(t, err)
+		assert.Equal(t, slog.LevelDebug, level)
+
+		_, err = ParseLevel("verbose")
+		assert.Error(t, err)
+	})
+}
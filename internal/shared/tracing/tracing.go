@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	KeyEventType = attribute.Key("bank.event_type")
+	KeyOutcome   = attribute.Key("bank.outcome")
+)
+
+const tracerName = "simple-bank"
+
+// Start opens a span with the global tracer provider, which does nothing
+// until an exporter is configured.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error err points to, if any, and ends span. It is meant to
+// be deferred with the address of a named error result.
+func End(span trace.Span, err *error) {
+	if *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
@@ -1,14 +1,24 @@
 package account
 
 import (
+	"context"
 	"errors"
+	"log/slog"
 	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
 	"simple-bank/internal/domain/repository"
 	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/logging"
+	"simple-bank/internal/shared/tracing"
+	"time"
 )
 
 var (
 	ErrDepositFailToRetrieveAccount = errors.New("[DepositUseCase] Fail to retrieve account")
+	ErrDepositFailToRetrieveProduct = errors.New("[DepositUseCase] Fail to retrieve product")
+	ErrDepositProductNotExists      = errors.New("[DepositUseCase] Product not exists")
+	ErrDepositFailToDeposit         = errors.New("[DepositUseCase] Fail to deposit")
 	ErrDepositFailToUpdateAccount   = errors.New("[DepositUseCase] Fail to update account")
 	ErrDepositFailToSaveAccount     = errors.New("[DepositUseCase] Fail to save account")
 )
@@ -16,31 +26,90 @@ var (
 type DepositInputDTO struct {
 	Destination string
 	Amount      int
+	Product     string
 }
 
 type DepositOutputDTO struct {
 	Destination dto.AccountDTO
+	// DestinationPreviousBalance is the balance before the deposit, 0 when
+	// the account was created by it.
+	DestinationPreviousBalance int
 }
 
 type DepositUseCase struct {
 	accountRepository repository.AccountRepository
+	productRepository repository.ProductRepository
+	now               func() time.Time
+	recorder          OperationRecorder
+	publisher         event.Publisher
 }
 
-func NewDepositUseCase(accountRepository repository.AccountRepository) *DepositUseCase {
-	return &DepositUseCase{accountRepository: accountRepository}
+func NewDepositUseCase(
+	accountRepository repository.AccountRepository,
+	productRepository repository.ProductRepository,
+) *DepositUseCase {
+	return &DepositUseCase{
+		accountRepository: accountRepository,
+		productRepository: productRepository,
+		now:               time.Now,
+		recorder:          noopRecorder{},
+		publisher:         event.Discard,
+	}
 }
 
-func (uc *DepositUseCase) Execute(input DepositInputDTO) (*DepositOutputDTO, error) {
-	account, err := uc.accountRepository.GetAccountByID(input.Destination)
-	if err != nil {
+// WithRecorder reports every execution to recorder.
+func (uc *DepositUseCase) WithRecorder(recorder OperationRecorder) *DepositUseCase {
+	uc.recorder = recorder
+	return uc
+}
+
+// WithPublisher publishes the events of every committed deposit on publisher.
+func (uc *DepositUseCase) WithPublisher(publisher event.Publisher) *DepositUseCase {
+	uc.publisher = publisher
+	return uc
+}
+
+func (uc *DepositUseCase) Execute(ctx context.Context, input DepositInputDTO) (*DepositOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "DepositUseCase.Execute")
+	output, err := uc.execute(ctx, input)
+	report(ctx, uc.recorder, OperationDeposit, input.Amount, err, slog.String(logging.KeyDestination, input.Destination))
+	tracing.End(span, &err)
+	return output, err
+}
+
+func (uc *DepositUseCase) execute(ctx context.Context, input DepositInputDTO) (*DepositOutputDTO, error) {
+	account, err := uc.accountRepository.GetAccountByID(ctx, input.Destination)
+	accountNotFound := errors.Is(err, domainErrs.ErrAccountNotFound)
+	if err != nil && !accountNotFound {
 		return nil, errors.Join(ErrDepositFailToRetrieveAccount, err)
 	}
 
-	if account == nil {
-		account = entity.NewAccount(input.Destination, input.Amount)
-		if err = uc.accountRepository.SaveAccount(account); err != nil {
+	productID := input.Product
+	if !accountNotFound {
+		productID = account.ProductID
+	}
+
+	product, err := findProduct(ctx, uc.productRepository, productID)
+	if errors.Is(err, domainErrs.ErrProductNotFound) {
+		return nil, errors.Join(ErrDepositProductNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrDepositFailToRetrieveProduct, err)
+	}
+
+	if accountNotFound {
+		account = entity.NewAccount(input.Destination, 0)
+		account.ProductID = product.ID
+		if err = account.Credit(input.Amount, product.Fees.Deposit); err != nil {
+			return nil, errors.Join(ErrDepositFailToDeposit, err)
+		}
+		created := newEvent(uc.now(), event.AccountCreated{Account: account.ID, Product: product.ID})
+		deposited := uc.depositedEvent(account, input.Amount, product.Fees.Deposit)
+		if err = uc.accountRepository.SaveAccount(ctx, account, created, deposited); err != nil {
 			return nil, ErrDepositFailToSaveAccount
 		}
+		uc.publisher.Publish(ctx, created, deposited)
 		return &DepositOutputDTO{
 			Destination: dto.AccountDTO{
 				ID:      account.ID,
@@ -49,16 +118,31 @@ func (uc *DepositUseCase) Execute(input DepositInputDTO) (*DepositOutputDTO, err
 		}, nil
 	}
 
-	account.Deposit(input.Amount)
+	previousBalance := account.Balance
+	if err = account.Credit(input.Amount, product.Fees.Deposit); err != nil {
+		return nil, errors.Join(ErrDepositFailToDeposit, err)
+	}
 
-	if err = uc.accountRepository.UpdateAccount(account); err != nil {
+	deposited := uc.depositedEvent(account, input.Amount, product.Fees.Deposit)
+	if err = uc.accountRepository.UpdateAccount(ctx, account, deposited); err != nil {
 		return nil, ErrDepositFailToUpdateAccount
 	}
+	uc.publisher.Publish(ctx, deposited)
 
 	return &DepositOutputDTO{
 		Destination: dto.AccountDTO{
 			ID:      account.ID,
 			Balance: account.Balance,
 		},
+		DestinationPreviousBalance: previousBalance,
 	}, nil
 }
+
+func (uc *DepositUseCase) depositedEvent(account *entity.Account, amount int, fee int) event.Event {
+	return newEvent(uc.now(), event.FundsDeposited{
+		Account: account.ID,
+		Amount:  amount,
+		Fee:     fee,
+		Balance: account.Balance,
+	})
+}
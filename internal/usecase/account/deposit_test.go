@@ -1,8 +1,11 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
 	"simple-bank/internal/domain/repository/mocks"
 	"testing"
 
@@ -12,15 +15,17 @@ import (
 
 type TestDepositUseCaseSuite struct {
 	suite.Suite
-	ctrl *gomock.Controller
-	repo *mocks.MockAccountRepository
-	sut  *DepositUseCase
+	ctrl        *gomock.Controller
+	repo        *mocks.MockAccountRepository
+	productRepo *mocks.MockProductRepository
+	sut         *DepositUseCase
 }
 
 func (suite *TestDepositUseCaseSuite) SetupSubTest() {
 	suite.ctrl = gomock.NewController(suite.T())
 	suite.repo = mocks.NewMockAccountRepository(suite.ctrl)
-	suite.sut = NewDepositUseCase(suite.repo)
+	suite.productRepo = newProductRepositoryMock(suite.ctrl)
+	suite.sut = NewDepositUseCase(suite.repo, suite.productRepo)
 }
 
 func (suite *TestDepositUseCaseSuite) TearDownSubTest() {
@@ -31,10 +36,10 @@ func (suite *TestDepositUseCaseSuite) TestDeposit() {
 	suite.Run("Should deposit amount to account", func() {
 		account := entity.NewAccount("ID", 100)
 
-		suite.repo.EXPECT().GetAccountByID("ID").Return(account, nil)
-		suite.repo.EXPECT().UpdateAccount(account).Return(nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(account, nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), account, anEvent(event.TypeFundsDeposited)).Return(nil)
 
-		output, err := suite.sut.Execute(DepositInputDTO{
+		output, err := suite.sut.Execute(context.Background(), DepositInputDTO{
 			Destination: "ID",
 			Amount:      100,
 		})
@@ -42,12 +47,13 @@ func (suite *TestDepositUseCaseSuite) TestDeposit() {
 		suite.NoError(err)
 		suite.Equal(output.Destination.Balance, 200)
 		suite.Equal(output.Destination.ID, "ID")
+		suite.Equal(100, output.DestinationPreviousBalance)
 	})
 
 	suite.Run("Should return error when fails to retrieve account", func() {
-		suite.repo.EXPECT().GetAccountByID("ID").Return(nil, errors.New("[AccountRepository] internal error"))
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, errors.New("[AccountRepository] internal error"))
 
-		_, err := suite.sut.Execute(DepositInputDTO{
+		_, err := suite.sut.Execute(context.Background(), DepositInputDTO{
 			Destination: "ID",
 			Amount:      100,
 		})
@@ -57,10 +63,10 @@ func (suite *TestDepositUseCaseSuite) TestDeposit() {
 
 	suite.Run("Should return error when fails to update account", func() {
 		account := entity.NewAccount("ID", 100)
-		suite.repo.EXPECT().GetAccountByID("ID").Return(account, nil)
-		suite.repo.EXPECT().UpdateAccount(account).Return(errors.New("[AccountRepository] internal error"))
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(account, nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), account, anEvent(event.TypeFundsDeposited)).Return(errors.New("[AccountRepository] internal error"))
 
-		_, err := suite.sut.Execute(DepositInputDTO{
+		_, err := suite.sut.Execute(context.Background(), DepositInputDTO{
 			Destination: "ID",
 			Amount:      100,
 		})
@@ -68,13 +74,38 @@ func (suite *TestDepositUseCaseSuite) TestDeposit() {
 		suite.ErrorIs(err, ErrDepositFailToUpdateAccount)
 	})
 
+	suite.Run("Should return error when account is frozen", func() {
+		account := entity.NewAccount("ID", 100)
+		account.Status = entity.AccountStatusFrozen
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(account, nil)
+
+		_, err := suite.sut.Execute(context.Background(), DepositInputDTO{
+			Destination: "ID",
+			Amount:      100,
+		})
+
+		suite.ErrorIs(err, ErrDepositFailToDeposit)
+		suite.ErrorIs(err, domainErrs.ErrAccountFrozen)
+	})
+
+	suite.Run("Should not create an account when amount is invalid", func() {
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, domainErrs.ErrAccountNotFound)
+
+		_, err := suite.sut.Execute(context.Background(), DepositInputDTO{
+			Destination: "ID",
+			Amount:      -100,
+		})
+
+		suite.ErrorIs(err, domainErrs.ErrInvalidAmount)
+	})
+
 	suite.Run("Should create an account when not exists", func() {
-		suite.repo.EXPECT().GetAccountByID("ID").Return(nil, nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, domainErrs.ErrAccountNotFound)
 		suite.repo.EXPECT().
-			SaveAccount(&entity.Account{ID: "ID", Balance: 100}).
+			SaveAccount(gomock.Any(), newCheckingAccount("ID", 100), anEvent(event.TypeAccountCreated), anEvent(event.TypeFundsDeposited)).
 			Return(nil)
 
-		output, err := suite.sut.Execute(DepositInputDTO{
+		output, err := suite.sut.Execute(context.Background(), DepositInputDTO{
 			Destination: "ID",
 			Amount:      100,
 		})
@@ -82,21 +113,124 @@ func (suite *TestDepositUseCaseSuite) TestDeposit() {
 		suite.NoError(err)
 		suite.Equal(output.Destination.Balance, 100)
 		suite.Equal(output.Destination.ID, "ID")
+		suite.Equal(0, output.DestinationPreviousBalance)
 	})
 
 	suite.Run("Should return error when fails to save account", func() {
-		suite.repo.EXPECT().GetAccountByID("ID").Return(nil, nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, domainErrs.ErrAccountNotFound)
 		suite.repo.EXPECT().
-			SaveAccount(&entity.Account{ID: "ID", Balance: 100}).
+			SaveAccount(gomock.Any(), newCheckingAccount("ID", 100), anEvent(event.TypeAccountCreated), anEvent(event.TypeFundsDeposited)).
 			Return(errors.New("[AccountRepository] internal error"))
 
-		_, err := suite.sut.Execute(DepositInputDTO{
+		_, err := suite.sut.Execute(context.Background(), DepositInputDTO{
 			Destination: "ID",
 			Amount:      100,
 		})
 
 		suite.ErrorIs(err, ErrDepositFailToSaveAccount)
 	})
+
+	suite.Run("Should create account with the requested product", func() {
+		savings := entity.NewProduct("savings", "Savings")
+		expected := entity.NewAccount("ID", 100)
+		expected.ProductID = "savings"
+
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, domainErrs.ErrAccountNotFound)
+		suite.productRepo.EXPECT().GetProductByID(gomock.Any(), "savings").Return(savings, nil)
+		suite.repo.EXPECT().SaveAccount(gomock.Any(), expected, anEvent(event.TypeAccountCreated), anEvent(event.TypeFundsDeposited)).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), DepositInputDTO{
+			Destination: "ID",
+			Amount:      100,
+			Product:     "savings",
+		})
+
+		suite.NoError(err)
+		suite.Equal(100, output.Destination.Balance)
+	})
+
+	suite.Run("Should return error when requested product does not exist", func() {
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, domainErrs.ErrAccountNotFound)
+		suite.productRepo.EXPECT().GetProductByID(gomock.Any(), "gold").Return(nil, domainErrs.ErrProductNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), DepositInputDTO{
+			Destination: "ID",
+			Amount:      100,
+			Product:     "gold",
+		})
+
+		suite.ErrorIs(err, ErrDepositProductNotExists)
+		suite.ErrorIs(err, domainErrs.ErrProductNotFound)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should charge the product deposit fee", func() {
+		account := entity.NewAccount("ID", 100)
+		account.ProductID = "business"
+		business := entity.NewProduct("business", "Business")
+		business.Fees.Deposit = 1
+
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(account, nil)
+		suite.productRepo.EXPECT().GetProductByID(gomock.Any(), "business").Return(business, nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), account, anEvent(event.TypeFundsDeposited)).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), DepositInputDTO{
+			Destination: "ID",
+			Amount:      100,
+		})
+
+		suite.NoError(err)
+		suite.Equal(199, output.Destination.Balance)
+	})
+}
+
+func (suite *TestDepositUseCaseSuite) TestRecordOperation() {
+	suite.Run("Should record the outcome and amount of each deposit", func() {
+		recorder := &operationRecorderSpy{}
+		suite.sut.WithRecorder(recorder)
+		account := entity.NewAccount("ID", 100)
+		account.Status = entity.AccountStatusFrozen
+
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(entity.NewAccount("ID", 100), nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), gomock.Any(), anEvent(event.TypeFundsDeposited)).Return(nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(account, nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, errors.New("[AccountRepository] internal error"))
+
+		for i := 0; i < 3; i++ {
+			suite.sut.Execute(context.Background(), DepositInputDTO{Destination: "ID", Amount: 100})
+		}
+
+		suite.Equal([]recordedOperation{
+			{OperationDeposit, OutcomeSuccess, 100},
+			{OperationDeposit, OutcomeRejected, 100},
+			{OperationDeposit, OutcomeFailed, 100},
+		}, recorder.operations)
+	})
+}
+
+func (suite *TestDepositUseCaseSuite) TestPublish() {
+	suite.Run("Should publish the events of a committed deposit", func() {
+		publisher := &publisherSpy{}
+		suite.sut.WithPublisher(publisher)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, domainErrs.ErrAccountNotFound)
+		suite.repo.EXPECT().SaveAccount(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		suite.sut.Execute(context.Background(), DepositInputDTO{Destination: "ID", Amount: 100})
+
+		suite.Equal([]event.Type{event.TypeAccountCreated, event.TypeFundsDeposited}, publisher.types())
+		suite.Equal(event.FundsDeposited{Account: "ID", Amount: 100, Balance: 100}, publisher.events[1].Data)
+	})
+
+	suite.Run("Should not publish when the deposit is not stored", func() {
+		publisher := &publisherSpy{}
+		suite.sut.WithPublisher(publisher)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(entity.NewAccount("ID", 100), nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("[AccountRepository] internal error"))
+
+		suite.sut.Execute(context.Background(), DepositInputDTO{Destination: "ID", Amount: 100})
+
+		suite.Empty(publisher.events)
+	})
 }
 
 func TestDeposit(t *testing.T) {
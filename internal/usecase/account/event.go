@@ -0,0 +1,14 @@
+package account
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"simple-bank/internal/domain/event"
+	"time"
+)
+
+func newEvent(at time.Time, data event.Data) event.Event {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return event.New(hex.EncodeToString(id), at.UTC(), data)
+}
@@ -0,0 +1,32 @@
+package account
+
+import (
+	"context"
+	"simple-bank/internal/domain/event"
+
+	"go.uber.org/mock/gomock"
+)
+
+// anEvent matches an event of eventType.
+func anEvent(eventType event.Type) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		e, ok := x.(event.Event)
+		return ok && e.Type == eventType
+	})
+}
+
+type publisherSpy struct {
+	events []event.Event
+}
+
+func (s *publisherSpy) Publish(ctx context.Context, events ...event.Event) {
+	s.events = append(s.events, events...)
+}
+
+func (s *publisherSpy) types() []event.Type {
+	types := []event.Type{}
+	for _, e := range s.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
@@ -0,0 +1,54 @@
+package account
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrGetAccountFailToRetrieveAccount = errors.New("[GetAccountUseCase] Fail to retrieve account")
+	ErrGetAccountAccountNotExists      = errors.New("[GetAccountUseCase] Account not exists")
+)
+
+type GetAccountInputDTO struct {
+	ID string
+}
+
+type GetAccountOutputDTO struct {
+	Account dto.AccountDetailsDTO
+}
+
+type GetAccountUseCase struct {
+	accountRepository repository.AccountRepository
+}
+
+func NewGetAccountUseCase(accountRepository repository.AccountRepository) *GetAccountUseCase {
+	return &GetAccountUseCase{accountRepository: accountRepository}
+}
+
+func (uc *GetAccountUseCase) Execute(ctx context.Context, input GetAccountInputDTO) (output *GetAccountOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "GetAccountUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	account, err := uc.accountRepository.GetAccountByID(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrAccountNotFound) {
+		return nil, errors.Join(ErrGetAccountAccountNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrGetAccountFailToRetrieveAccount, err)
+	}
+
+	return &GetAccountOutputDTO{
+		Account: dto.AccountDetailsDTO{
+			ID:      account.ID,
+			Balance: account.Balance,
+			Status:  string(account.Status),
+			Product: account.ProductID,
+		},
+	}, nil
+}
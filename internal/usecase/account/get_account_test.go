@@ -0,0 +1,66 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"simple-bank/internal/shared/dto"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestGetAccountUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockAccountRepository
+	sut  *GetAccountUseCase
+}
+
+func (suite *TestGetAccountUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockAccountRepository(suite.ctrl)
+	suite.sut = NewGetAccountUseCase(suite.repo)
+}
+
+func (suite *TestGetAccountUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestGetAccountUseCaseSuite) TestGetAccount() {
+	suite.Run("Should return account details when account exists", func() {
+		account := entity.NewAccount("ID", 100)
+		account.ProductID = "checking"
+
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(account, nil)
+
+		output, err := suite.sut.Execute(context.Background(), GetAccountInputDTO{ID: "ID"})
+
+		suite.NoError(err)
+		suite.Equal(dto.AccountDetailsDTO{ID: "ID", Balance: 100, Status: "active", Product: "checking"}, output.Account)
+	})
+
+	suite.Run("Should return error when fails to retrieve account", func() {
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, errors.New("[AccountRepository] internal error"))
+
+		_, err := suite.sut.Execute(context.Background(), GetAccountInputDTO{ID: "ID"})
+
+		suite.ErrorIs(err, ErrGetAccountFailToRetrieveAccount)
+	})
+
+	suite.Run("Should return error when account does not exist", func() {
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, domainErrs.ErrAccountNotFound)
+
+		_, err := suite.sut.Execute(context.Background(), GetAccountInputDTO{ID: "ID"})
+
+		suite.ErrorIs(err, ErrGetAccountAccountNotExists)
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
+	})
+}
+
+func TestGetAccount(t *testing.T) {
+	suite.Run(t, new(TestGetAccountUseCaseSuite))
+}
@@ -1,8 +1,11 @@
 package account
 
 import (
+	"context"
 	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
 	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/tracing"
 )
 
 var (
@@ -26,14 +29,17 @@ func NewGetBalanceUseCase(accountRepository repository.AccountRepository) *GetBa
 	return &GetBalanceUseCase{accountRepository: accountRepository}
 }
 
-func (uc *GetBalanceUseCase) Execute(input GetBalanceInputDTO) (*GetBalanceOutputDTO, error) {
-	account, err := uc.accountRepository.GetAccountByID(input.ID)
-	if err != nil {
-		return nil, errors.Join(ErrGetBalanceFailToRetrieveAccount, err)
+func (uc *GetBalanceUseCase) Execute(ctx context.Context, input GetBalanceInputDTO) (output *GetBalanceOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "GetBalanceUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	account, err := uc.accountRepository.GetAccountByID(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrAccountNotFound) {
+		return nil, errors.Join(ErrGetBalanceAccountNotExists, err)
 	}
 
-	if account == nil {
-		return nil, ErrGetBalanceAccountNotExists
+	if err != nil {
+		return nil, errors.Join(ErrGetBalanceFailToRetrieveAccount, err)
 	}
 
 	return &GetBalanceOutputDTO{Balance: account.Balance}, nil
@@ -1,8 +1,10 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
 	"simple-bank/internal/domain/repository/mocks"
 	"testing"
 
@@ -31,28 +33,29 @@ func (suite *TestGetBalanceUseCaseSuite) TestGetBalance() {
 	suite.Run("Should return account balance when account exists", func() {
 		account := entity.NewAccount("ID", 100)
 
-		suite.repo.EXPECT().GetAccountByID("ID").Return(account, nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(account, nil)
 
-		output, err := suite.sut.Execute(GetBalanceInputDTO{ID: "ID"})
+		output, err := suite.sut.Execute(context.Background(), GetBalanceInputDTO{ID: "ID"})
 
 		suite.NoError(err)
 		suite.Equal(account.Balance, output.Balance)
 	})
 
 	suite.Run("Should return error when fails to retrieve account", func() {
-		suite.repo.EXPECT().GetAccountByID("ID").Return(nil, errors.New("[AccountRepository] internal error"))
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, errors.New("[AccountRepository] internal error"))
 
-		_, err := suite.sut.Execute(GetBalanceInputDTO{ID: "ID"})
+		_, err := suite.sut.Execute(context.Background(), GetBalanceInputDTO{ID: "ID"})
 
 		suite.ErrorIs(err, ErrGetBalanceFailToRetrieveAccount)
 	})
 
 	suite.Run("Should return error when account does not exist", func() {
-		suite.repo.EXPECT().GetAccountByID("ID").Return(nil, nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "ID").Return(nil, domainErrs.ErrAccountNotFound)
 
-		_, err := suite.sut.Execute(GetBalanceInputDTO{ID: "ID"})
+		_, err := suite.sut.Execute(context.Background(), GetBalanceInputDTO{ID: "ID"})
 
 		suite.ErrorIs(err, ErrGetBalanceAccountNotExists)
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
 	})
 }
 
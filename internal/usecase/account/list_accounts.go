@@ -0,0 +1,162 @@
+package account
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+const (
+	DefaultListAccountsLimit = 50
+	MaxListAccountsLimit     = 100
+)
+
+var (
+	ErrListAccountsInvalidCursor      = errors.New("[ListAccountsUseCase] Invalid cursor")
+	ErrListAccountsInvalidSort        = errors.New("[ListAccountsUseCase] Invalid sort")
+	ErrListAccountsInvalidFilter      = errors.New("[ListAccountsUseCase] Invalid filter")
+	ErrListAccountsInvalidLimit       = errors.New("[ListAccountsUseCase] Invalid limit")
+	ErrListAccountsFailToListAccounts = errors.New("[ListAccountsUseCase] Fail to list accounts")
+)
+
+type ListAccountsInputDTO struct {
+	SortBy     string
+	Order      string
+	Cursor     string
+	Limit      int
+	MinBalance *int
+	MaxBalance *int
+	Status     string
+	IDPrefix   string
+}
+
+type ListAccountsOutputDTO struct {
+	Accounts   []dto.AccountDetailsDTO
+	NextCursor string
+}
+
+type listAccountsCursor struct {
+	SortBy  repository.AccountSortField `json:"s"`
+	Order   repository.SortOrder        `json:"o"`
+	ID      string                      `json:"i"`
+	Balance int                         `json:"b"`
+}
+
+type ListAccountsUseCase struct {
+	accountRepository repository.AccountRepository
+}
+
+func NewListAccountsUseCase(accountRepository repository.AccountRepository) *ListAccountsUseCase {
+	return &ListAccountsUseCase{accountRepository: accountRepository}
+}
+
+func (uc *ListAccountsUseCase) Execute(ctx context.Context, input ListAccountsInputDTO) (output *ListAccountsOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListAccountsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	query, err := uc.buildQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := uc.accountRepository.ListAccounts(ctx, query)
+	if err != nil {
+		return nil, errors.Join(ErrListAccountsFailToListAccounts, err)
+	}
+
+	output = &ListAccountsOutputDTO{Accounts: make([]dto.AccountDetailsDTO, 0, len(result.Accounts))}
+	for _, account := range result.Accounts {
+		output.Accounts = append(output.Accounts, dto.AccountDetailsDTO{
+			ID:      account.ID,
+			Balance: account.Balance,
+			Status:  string(account.Status),
+			Product: account.ProductID,
+		})
+	}
+
+	if result.Next != nil {
+		output.NextCursor = encodeListAccountsCursor(listAccountsCursor{
+			SortBy:  query.SortBy,
+			Order:   query.Order,
+			ID:      result.Next.ID,
+			Balance: result.Next.Balance,
+		})
+	}
+
+	return output, nil
+}
+
+func (uc *ListAccountsUseCase) buildQuery(input ListAccountsInputDTO) (repository.ListAccountsQuery, error) {
+	query := repository.ListAccountsQuery{
+		SortBy: repository.AccountSortField(input.SortBy),
+		Order:  repository.SortOrder(input.Order),
+		Limit:  input.Limit,
+		Filter: repository.AccountFilter{
+			MinBalance: input.MinBalance,
+			MaxBalance: input.MaxBalance,
+			Status:     entity.AccountStatus(input.Status),
+			IDPrefix:   input.IDPrefix,
+		},
+	}
+
+	if query.SortBy == "" {
+		query.SortBy = repository.AccountSortByID
+	}
+	if query.Order == "" {
+		query.Order = repository.SortOrderAsc
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultListAccountsLimit
+	}
+
+	if query.SortBy != repository.AccountSortByID && query.SortBy != repository.AccountSortByBalance {
+		return query, ErrListAccountsInvalidSort
+	}
+	if query.Order != repository.SortOrderAsc && query.Order != repository.SortOrderDesc {
+		return query, ErrListAccountsInvalidSort
+	}
+	if query.Limit < 0 || query.Limit > MaxListAccountsLimit {
+		return query, ErrListAccountsInvalidLimit
+	}
+
+	switch query.Filter.Status {
+	case "", entity.AccountStatusActive, entity.AccountStatusFrozen, entity.AccountStatusClosed:
+	default:
+		return query, ErrListAccountsInvalidFilter
+	}
+	if input.MinBalance != nil && input.MaxBalance != nil && *input.MinBalance > *input.MaxBalance {
+		return query, ErrListAccountsInvalidFilter
+	}
+
+	if input.Cursor != "" {
+		cursor, err := decodeListAccountsCursor(input.Cursor)
+		if err != nil || cursor.SortBy != query.SortBy || cursor.Order != query.Order {
+			return query, ErrListAccountsInvalidCursor
+		}
+		query.After = &repository.AccountCursor{ID: cursor.ID, Balance: cursor.Balance}
+	}
+
+	return query, nil
+}
+
+func encodeListAccountsCursor(cursor listAccountsCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListAccountsCursor(value string) (listAccountsCursor, error) {
+	var cursor listAccountsCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+
+	err = json.Unmarshal(data, &cursor)
+	return cursor, err
+}
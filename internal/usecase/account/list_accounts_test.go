@@ -0,0 +1,143 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestListAccountsUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockAccountRepository
+	sut  *ListAccountsUseCase
+}
+
+func (suite *TestListAccountsUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockAccountRepository(suite.ctrl)
+	suite.sut = NewListAccountsUseCase(suite.repo)
+}
+
+func (suite *TestListAccountsUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestListAccountsUseCaseSuite) TestListAccounts() {
+	suite.Run("Should list accounts using default sort and limit", func() {
+		suite.repo.
+			EXPECT().
+			ListAccounts(gomock.Any(), repository.ListAccountsQuery{
+				SortBy: repository.AccountSortByID,
+				Order:  repository.SortOrderAsc,
+				Limit:  DefaultListAccountsLimit,
+			}).
+			Return(&repository.ListAccountsResult{
+				Accounts: []*entity.Account{entity.NewAccount("ID1", 100)},
+			}, nil)
+
+		output, err := suite.sut.Execute(context.Background(), ListAccountsInputDTO{})
+
+		suite.NoError(err)
+		suite.Len(output.Accounts, 1)
+		suite.Equal("ID1", output.Accounts[0].ID)
+		suite.Equal(100, output.Accounts[0].Balance)
+		suite.Equal("active", output.Accounts[0].Status)
+		suite.Empty(output.NextCursor)
+	})
+
+	suite.Run("Should return a cursor that resumes after the last account", func() {
+		suite.repo.
+			EXPECT().
+			ListAccounts(gomock.Any(), gomock.Any()).
+			Return(&repository.ListAccountsResult{
+				Accounts: []*entity.Account{entity.NewAccount("ID1", 100)},
+				Next:     &repository.AccountCursor{ID: "ID1", Balance: 100},
+			}, nil)
+
+		output, err := suite.sut.Execute(context.Background(), ListAccountsInputDTO{SortBy: "balance", Limit: 1})
+		suite.Require().NoError(err)
+		suite.NotEmpty(output.NextCursor)
+
+		suite.repo.
+			EXPECT().
+			ListAccounts(gomock.Any(), repository.ListAccountsQuery{
+				SortBy: repository.AccountSortByBalance,
+				Order:  repository.SortOrderAsc,
+				Limit:  1,
+				After:  &repository.AccountCursor{ID: "ID1", Balance: 100},
+			}).
+			Return(&repository.ListAccountsResult{}, nil)
+
+		_, err = suite.sut.Execute(context.Background(), ListAccountsInputDTO{
+			SortBy: "balance",
+			Limit:  1,
+			Cursor: output.NextCursor,
+		})
+
+		suite.NoError(err)
+	})
+
+	suite.Run("Should return error when cursor is malformed", func() {
+		_, err := suite.sut.Execute(context.Background(), ListAccountsInputDTO{Cursor: "not-a-cursor"})
+
+		suite.ErrorIs(err, ErrListAccountsInvalidCursor)
+	})
+
+	suite.Run("Should return error when cursor was issued for another sort", func() {
+		cursor := encodeListAccountsCursor(listAccountsCursor{
+			SortBy: repository.AccountSortByBalance,
+			Order:  repository.SortOrderAsc,
+			ID:     "ID1",
+		})
+
+		_, err := suite.sut.Execute(context.Background(), ListAccountsInputDTO{Cursor: cursor})
+
+		suite.ErrorIs(err, ErrListAccountsInvalidCursor)
+	})
+
+	suite.Run("Should return error when sort is invalid", func() {
+		_, err := suite.sut.Execute(context.Background(), ListAccountsInputDTO{SortBy: "name"})
+
+		suite.ErrorIs(err, ErrListAccountsInvalidSort)
+	})
+
+	suite.Run("Should return error when limit is above the maximum", func() {
+		_, err := suite.sut.Execute(context.Background(), ListAccountsInputDTO{Limit: MaxListAccountsLimit + 1})
+
+		suite.ErrorIs(err, ErrListAccountsInvalidLimit)
+	})
+
+	suite.Run("Should return error when balance range is inverted", func() {
+		minBalance, maxBalance := 100, 50
+
+		_, err := suite.sut.Execute(context.Background(), ListAccountsInputDTO{
+			MinBalance: &minBalance,
+			MaxBalance: &maxBalance,
+		})
+
+		suite.ErrorIs(err, ErrListAccountsInvalidFilter)
+	})
+
+	suite.Run("Should return error when fails to list accounts", func() {
+		suite.repo.
+			EXPECT().
+			ListAccounts(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("[AccountRepository] internal error"))
+
+		output, err := suite.sut.Execute(context.Background(), ListAccountsInputDTO{})
+
+		suite.ErrorIs(err, ErrListAccountsFailToListAccounts)
+		suite.Nil(output)
+	})
+}
+
+func TestListAccounts(t *testing.T) {
+	suite.Run(t, new(TestListAccountsUseCaseSuite))
+}
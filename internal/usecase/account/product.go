@@ -0,0 +1,14 @@
+package account
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository"
+)
+
+func findProduct(ctx context.Context, productRepository repository.ProductRepository, id string) (*entity.Product, error) {
+	if id == "" {
+		return productRepository.GetDefaultProduct(ctx)
+	}
+	return productRepository.GetProductByID(ctx, id)
+}
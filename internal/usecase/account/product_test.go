@@ -0,0 +1,20 @@
+package account
+
+import (
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository/mocks"
+
+	"go.uber.org/mock/gomock"
+)
+
+func newProductRepositoryMock(ctrl *gomock.Controller) *mocks.MockProductRepository {
+	repo := mocks.NewMockProductRepository(ctrl)
+	repo.EXPECT().GetDefaultProduct(gomock.Any()).Return(entity.NewProduct("checking", "Checking"), nil).AnyTimes()
+	return repo
+}
+
+func newCheckingAccount(id string, balance int) *entity.Account {
+	account := entity.NewAccount(id, balance)
+	account.ProductID = "checking"
+	return account
+}
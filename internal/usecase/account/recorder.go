@@ -0,0 +1,92 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/shared/logging"
+	"simple-bank/internal/shared/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Operation string
+
+const (
+	OperationDeposit  Operation = "deposit"
+	OperationWithdraw Operation = "withdraw"
+	OperationTransfer Operation = "transfer"
+)
+
+// Outcome tells successful operations apart from the ones refused by the
+// domain rules and the ones that failed unexpectedly.
+type Outcome string
+
+const (
+	OutcomeSuccess  Outcome = "success"
+	OutcomeRejected Outcome = "rejected"
+	OutcomeFailed   Outcome = "failed"
+)
+
+// OperationRecorder is told about every money movement once it is over.
+type OperationRecorder interface {
+	RecordOperation(ctx context.Context, operation Operation, outcome Outcome, amount int)
+}
+
+type noopRecorder struct{}
+
+func (noopRecorder) RecordOperation(context.Context, Operation, Outcome, int) {}
+
+var rejections = []error{
+	domainErrs.ErrAccountInsufficientBalance,
+	domainErrs.ErrAccountWithdrawalLimitExceeded,
+	domainErrs.ErrAccountFrozen,
+	domainErrs.ErrAccountClosed,
+	domainErrs.ErrInvalidAmount,
+	domainErrs.ErrAmountBelowFee,
+	domainErrs.ErrAccountNotFound,
+	domainErrs.ErrAccountNotOwned,
+	domainErrs.ErrProductNotFound,
+}
+
+func outcomeOf(err error) Outcome {
+	if err == nil {
+		return OutcomeSuccess
+	}
+	for _, rejection := range rejections {
+		if errors.Is(err, rejection) {
+			return OutcomeRejected
+		}
+	}
+	return OutcomeFailed
+}
+
+// report tells the recorder, the log and the current span how an operation ended, accounts are
+// the origin and destination attributes of the operation.
+func report(ctx context.Context, recorder OperationRecorder, operation Operation, amount int, err error, accounts ...slog.Attr) {
+	outcome := outcomeOf(err)
+	recorder.RecordOperation(ctx, operation, outcome, amount)
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.KeyEventType.String(string(operation)),
+		tracing.KeyOutcome.String(string(outcome)),
+	)
+
+	level := slog.LevelInfo
+	switch outcome {
+	case OutcomeRejected:
+		level = slog.LevelWarn
+	case OutcomeFailed:
+		level = slog.LevelError
+	}
+
+	attrs := append(accounts,
+		slog.String(logging.KeyEventType, string(operation)),
+		slog.Int(logging.KeyAmount, amount),
+		slog.String(logging.KeyOutcome, string(outcome)),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String(logging.KeyError, err.Error()))
+	}
+	slog.LogAttrs(ctx, level, "operation", attrs...)
+}
@@ -0,0 +1,33 @@
+package account
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedOperation struct {
+	operation Operation
+	outcome   Outcome
+	amount    int
+}
+
+type operationRecorderSpy struct {
+	operations []recordedOperation
+}
+
+func (s *operationRecorderSpy) RecordOperation(ctx context.Context, operation Operation, outcome Outcome, amount int) {
+	s.operations = append(s.operations, recordedOperation{operation, outcome, amount})
+}
+
+func TestOutcomeOf(t *testing.T) {
+	t.Run("Should tell rejections apart from failures", func(t *testing.T) {
+		assert.Equal(t, OutcomeSuccess, outcomeOf(nil))
+		assert.Equal(t, OutcomeRejected, outcomeOf(errors.Join(ErrWithdrawFailToWithdraw, domainErrs.ErrAccountInsufficientBalance)))
+		assert.Equal(t, OutcomeRejected, outcomeOf(&domainErrs.InsufficientBalanceError{}))
+		assert.Equal(t, OutcomeFailed, outcomeOf(ErrWithdrawFailToUpdateAccount))
+	})
+}
@@ -1,8 +1,10 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/tracing"
 )
 
 var ErrResetFailToDeleteAllAccounts = errors.New("[ResetUseCase] Fail to delete all accounts")
@@ -15,8 +17,11 @@ func NewResetUseCase(accountRepository repository.AccountRepository) *ResetUseCa
 	return &ResetUseCase{accountRepository: accountRepository}
 }
 
-func (uc *ResetUseCase) Execute() error {
-	if err := uc.accountRepository.DeleteAllAccounts(); err != nil {
+func (uc *ResetUseCase) Execute(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "ResetUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := uc.accountRepository.DeleteAllAccounts(ctx); err != nil {
 		return errors.Join(ErrResetFailToDeleteAllAccounts, err)
 	}
 	return nil
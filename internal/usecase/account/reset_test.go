@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"simple-bank/internal/domain/repository/mocks"
 	"testing"
@@ -28,9 +29,9 @@ func (suite *TestResetUseCaseSuite) TearDownSubTest() {
 
 func (suite *TestResetUseCaseSuite) TestReset() {
 	suite.Run("Should reset all accounts", func() {
-		suite.repo.EXPECT().DeleteAllAccounts().Return(nil)
+		suite.repo.EXPECT().DeleteAllAccounts(gomock.Any()).Return(nil)
 
-		err := suite.sut.Execute()
+		err := suite.sut.Execute(context.Background())
 
 		suite.NoError(err)
 	})
@@ -38,10 +39,10 @@ func (suite *TestResetUseCaseSuite) TestReset() {
 	suite.Run("Should return error when fails to reset all accounts", func() {
 		suite.repo.
 			EXPECT().
-			DeleteAllAccounts().
+			DeleteAllAccounts(gomock.Any()).
 			Return(errors.New("[AccountRepository] internal error"))
 
-		err := suite.sut.Execute()
+		err := suite.sut.Execute(context.Background())
 
 		suite.ErrorIs(err, ErrResetFailToDeleteAllAccounts)
 	})
@@ -1,117 +1,229 @@
 package account
 
 import (
+	"context"
 	"errors"
+	"log/slog"
 	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
 	"simple-bank/internal/domain/repository"
 	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/logging"
+	"simple-bank/internal/shared/tracing"
+	"time"
 )
 
 var (
-	ErrTransferFailToRetrieveOriginAccount     = errors.New("[TransferUseCase] fail to retrieve origin account")
+	ErrTransferFailToUpdateAccounts            = errors.New("[TransferUseCase] fail to update accounts")
 	ErrTransferOriginAccountNotExists          = errors.New("[TransferUseCase] origin account not exists")
 	ErrTransferFailToWithdrawOriginAccount     = errors.New("[TransferUseCase] fail to withdraw from origin account")
-	ErrWithdrawFailDestinationAccountNotExists = errors.New("[TransferUseCase] fail to retrieve destination account")
-	ErrTransferFailToUpdateOriginAccount       = errors.New("[TransferUseCase] fail to update origin account")
 	ErrTransferFailToCreateDestinationAccount  = errors.New("[TransferUseCase] fail to create destination account")
-	ErrTransferFailToRollbackOriginAccount     = errors.New("[TransferUseCase] fail to rollback origin account")
 	ErrTransferFailToDepositDestinationAccount = errors.New("[TransferUseCase] fail to deposit destination account")
+	ErrTransferFailToRetrieveCustomer          = errors.New("[TransferUseCase] fail to retrieve customer")
+	ErrTransferOriginAccountNotOwned           = errors.New("[TransferUseCase] origin account not owned by customer")
+	ErrTransferFailToRetrieveProduct           = errors.New("[TransferUseCase] fail to retrieve product")
+	ErrTransferSameAccount                     = errors.New("[TransferUseCase] origin and destination are the same account")
 )
 
 type TransferInputDTO struct {
 	Origin      string
 	Destination string
 	Amount      int
+	CustomerID  string
 }
 
 type TransferOutputDTO struct {
 	Origin      dto.AccountDTO
 	Destination dto.AccountDTO
+	// OriginPreviousBalance and DestinationPreviousBalance are the balances
+	// before the transfer, the latter is 0 when the transfer created the
+	// destination account.
+	OriginPreviousBalance      int
+	DestinationPreviousBalance int
 }
 
 type TransferUseCase struct {
-	accountRepository repository.AccountRepository
+	accountRepository  repository.AccountRepository
+	customerRepository repository.CustomerRepository
+	productRepository  repository.ProductRepository
+	now                func() time.Time
+	recorder           OperationRecorder
+	publisher          event.Publisher
 }
 
-func NewTransferUseCase(repo repository.AccountRepository) *TransferUseCase {
-	return &TransferUseCase{accountRepository: repo}
+func NewTransferUseCase(
+	repo repository.AccountRepository,
+	customerRepository repository.CustomerRepository,
+	productRepository repository.ProductRepository,
+) *TransferUseCase {
+	return &TransferUseCase{
+		accountRepository:  repo,
+		customerRepository: customerRepository,
+		productRepository:  productRepository,
+		now:                time.Now,
+		recorder:           noopRecorder{},
+		publisher:          event.Discard,
+	}
 }
 
-func (uc *TransferUseCase) Execute(input TransferInputDTO) (*TransferOutputDTO, error) {
-	origin, err := uc.accountRepository.GetAccountByID(input.Origin)
-	if err != nil {
-		return nil, errors.Join(ErrTransferFailToRetrieveOriginAccount, err)
+// WithRecorder reports every execution to recorder.
+func (uc *TransferUseCase) WithRecorder(recorder OperationRecorder) *TransferUseCase {
+	uc.recorder = recorder
+	return uc
+}
+
+// WithPublisher publishes the events of every committed transfer on publisher.
+func (uc *TransferUseCase) WithPublisher(publisher event.Publisher) *TransferUseCase {
+	uc.publisher = publisher
+	return uc
+}
+
+func (uc *TransferUseCase) Execute(ctx context.Context, input TransferInputDTO) (*TransferOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "TransferUseCase.Execute")
+	output, err := uc.execute(ctx, input)
+	report(ctx, uc.recorder, OperationTransfer, input.Amount, err,
+		slog.String(logging.KeyOrigin, input.Origin),
+		slog.String(logging.KeyDestination, input.Destination),
+	)
+	tracing.End(span, &err)
+	return output, err
+}
+
+func (uc *TransferUseCase) execute(ctx context.Context, input TransferInputDTO) (*TransferOutputDTO, error) {
+	if input.Origin == input.Destination {
+		return nil, errors.Join(ErrTransferSameAccount, domainErrs.ErrSameAccountTransfer)
 	}
 
-	if origin == nil {
-		return nil, ErrTransferOriginAccountNotExists
+	if input.CustomerID != "" {
+		if err := uc.checkOriginOwnership(ctx, input.CustomerID, input.Origin); err != nil {
+			return nil, err
+		}
 	}
 
-	destination, err := uc.accountRepository.GetAccountByID(input.Destination)
+	// both accounts are written with the event at once, under the locks of
+	// the accounts, so concurrent movements cannot interleave with the
+	// transfer and a failed transfer leaves nothing to roll back.
+	var (
+		output   *TransferOutputDTO
+		events   []event.Event
+		applyErr error
+	)
+	err := uc.accountRepository.UpdateAccounts(
+		ctx,
+		[]string{input.Origin, input.Destination},
+		func(accounts []*entity.Account) ([]event.Event, error) {
+			output, events, applyErr = uc.apply(ctx, input, accounts)
+			return events, applyErr
+		},
+	)
+	if applyErr != nil {
+		return nil, applyErr
+	}
 	if err != nil {
-		return nil, errors.Join(ErrWithdrawFailDestinationAccountNotExists, err)
+		return nil, errors.Join(ErrTransferFailToUpdateAccounts, err)
 	}
 
-	err = origin.Withdraw(input.Amount)
-	if err != nil {
-		return nil, errors.Join(ErrTransferFailToWithdrawOriginAccount, err)
+	uc.publisher.Publish(ctx, events...)
+	return output, nil
+}
+
+// apply moves the amount from the origin to the destination of accounts,
+// creating the destination when it is nil, and returns the events of the
+// transfer.
+func (uc *TransferUseCase) apply(
+	ctx context.Context,
+	input TransferInputDTO,
+	accounts []*entity.Account,
+) (*TransferOutputDTO, []event.Event, error) {
+	origin, destination := accounts[0], accounts[1]
+	if origin == nil {
+		return nil, nil, errors.Join(ErrTransferOriginAccountNotExists, domainErrs.ErrAccountNotFound)
 	}
 
-	err = uc.accountRepository.UpdateAccount(origin)
+	product, err := findProduct(ctx, uc.productRepository, origin.ProductID)
 	if err != nil {
-		return nil, errors.Join(ErrTransferFailToUpdateOriginAccount, err)
+		return nil, nil, errors.Join(ErrTransferFailToRetrieveProduct, err)
 	}
 
+	originPreviousBalance := origin.Balance
+	if err := origin.Debit(product, input.Amount, product.Fees.Transfer, uc.now()); err != nil {
+		return nil, nil, errors.Join(ErrTransferFailToWithdrawOriginAccount, err)
+	}
+
+	transfer := event.TransferCompleted{
+		Origin:        origin.ID,
+		Destination:   input.Destination,
+		Amount:        input.Amount,
+		Fee:           product.Fees.Transfer,
+		OriginBalance: origin.Balance,
+	}
+	var events []event.Event
+	destinationPreviousBalance := 0
 	if destination == nil {
-		destination, err = uc.createDestinationAccount(input.Destination, input.Amount)
+		destination, err = uc.newDestinationAccount(ctx, input.Destination)
+		if err != nil {
+			return nil, nil, err
+		}
+		accounts[1] = destination
+		events = append(events, newEvent(uc.now(), event.AccountCreated{
+			Account: destination.ID,
+			Product: destination.ProductID,
+		}))
 	} else {
-		err = uc.depositOnDestinationAccount(destination, input.Amount)
+		destinationPreviousBalance = destination.Balance
 	}
 
-	if err != nil {
-		rollbackErr := uc.rollbackOriginAccount(origin, input.Amount)
-		return nil, errors.Join(rollbackErr, err)
+	if err := destination.Credit(input.Amount, 0); err != nil {
+		return nil, nil, errors.Join(ErrTransferFailToDepositDestinationAccount, err)
 	}
+	transfer.DestinationBalance = destination.Balance
+	events = append(events, newEvent(uc.now(), transfer))
 
 	return &TransferOutputDTO{
-		Origin:      dto.AccountDTO(*origin),
-		Destination: dto.AccountDTO(*destination),
-	}, nil
+		Origin: dto.AccountDTO{
+			ID:      origin.ID,
+			Balance: origin.Balance,
+		},
+		Destination: dto.AccountDTO{
+			ID:      destination.ID,
+			Balance: destination.Balance,
+		},
+		OriginPreviousBalance:      originPreviousBalance,
+		DestinationPreviousBalance: destinationPreviousBalance,
+	}, events, nil
 }
 
-func (uc *TransferUseCase) createDestinationAccount(
-	id string,
-	amount int,
-) (*entity.Account, error) {
-	destination := entity.NewAccount(id, amount)
-	err := uc.accountRepository.SaveAccount(destination)
+func (uc *TransferUseCase) checkOriginOwnership(
+	ctx context.Context,
+	customerID string,
+	origin string,
+) error {
+	customer, err := uc.customerRepository.GetCustomerByID(ctx, customerID)
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return errors.Join(ErrTransferOriginAccountNotOwned, domainErrs.ErrAccountNotOwned, err)
+	}
 
 	if err != nil {
-		return nil, errors.Join(ErrTransferFailToCreateDestinationAccount, err)
+		return errors.Join(ErrTransferFailToRetrieveCustomer, err)
 	}
-	return destination, nil
-}
 
-func (uc *TransferUseCase) depositOnDestinationAccount(
-	destination *entity.Account,
-	amount int,
-) error {
-	destination.Deposit(amount)
-	if err := uc.accountRepository.UpdateAccount(destination); err != nil {
-		return errors.Join(ErrTransferFailToDepositDestinationAccount, err)
+	if !customer.OwnsAccount(origin) {
+		return errors.Join(ErrTransferOriginAccountNotOwned, domainErrs.ErrAccountNotOwned)
 	}
 
 	return nil
 }
 
-func (uc *TransferUseCase) rollbackOriginAccount(
-	origin *entity.Account,
-	amount int,
-) error {
-	origin.Deposit(amount)
-	if err := uc.accountRepository.UpdateAccount(origin); err != nil {
-		return errors.Join(ErrTransferFailToRollbackOriginAccount, err)
+// newDestinationAccount opens the destination of a transfer with the default
+// product and an empty balance.
+func (uc *TransferUseCase) newDestinationAccount(ctx context.Context, id string) (*entity.Account, error) {
+	product, err := uc.productRepository.GetDefaultProduct(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrTransferFailToCreateDestinationAccount, err)
 	}
 
-	return nil
+	destination := entity.NewAccount(id, 0)
+	destination.ProductID = product.ID
+	return destination, nil
 }
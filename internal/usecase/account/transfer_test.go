@@ -1,8 +1,12 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
 	"simple-bank/internal/domain/repository/mocks"
 	"testing"
 
@@ -12,36 +16,78 @@ import (
 
 type TestTransferUseCaseSuite struct {
 	suite.Suite
-	ctrl *gomock.Controller
-	repo *mocks.MockAccountRepository
-	sut  *TransferUseCase
+	ctrl         *gomock.Controller
+	repo         *mocks.MockAccountRepository
+	customerRepo *mocks.MockCustomerRepository
+	productRepo  *mocks.MockProductRepository
+	sut          *TransferUseCase
 }
 
 func (suite *TestTransferUseCaseSuite) SetupSubTest() {
 	suite.ctrl = gomock.NewController(suite.T())
 	suite.repo = mocks.NewMockAccountRepository(suite.ctrl)
-	suite.sut = NewTransferUseCase(suite.repo)
+	suite.customerRepo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.productRepo = newProductRepositoryMock(suite.ctrl)
+	suite.sut = NewTransferUseCase(suite.repo, suite.customerRepo, suite.productRepo)
 }
 
 func (suite *TestTransferUseCaseSuite) TearDownSubTest() {
 	suite.ctrl.Finish()
 }
 
+// accountsWrite is what UpdateAccounts writes, it stays empty when nothing is.
+type accountsWrite struct {
+	accounts []*entity.Account
+	events   []event.Event
+}
+
+func (w *accountsWrite) balances() []int {
+	balances := []int{}
+	for _, account := range w.accounts {
+		balances = append(balances, account.Balance)
+	}
+	return balances
+}
+
+func (w *accountsWrite) types() []event.Type {
+	types := []event.Type{}
+	for _, e := range w.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+// expectUpdate runs the update of the transfer from ID1 to ID2 on origin and
+// destination, nil when they do not exist. The write fails with err.
+func (suite *TestTransferUseCaseSuite) expectUpdate(origin, destination *entity.Account, err error) *accountsWrite {
+	written := &accountsWrite{}
+	suite.repo.
+		EXPECT().
+		UpdateAccounts(gomock.Any(), []string{"ID1", "ID2"}, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, ids []string, update repository.AccountsUpdate) error {
+			accounts := []*entity.Account{origin, destination}
+			events, updateErr := update(accounts)
+			if updateErr != nil {
+				return updateErr
+			}
+			if err != nil {
+				return err
+			}
+
+			written.accounts, written.events = accounts, events
+			return nil
+		})
+	return written
+}
+
 func (suite *TestTransferUseCaseSuite) TestTransfer() {
 	suite.Run("Should transfer amount from origin to destination", func() {
-		origin := entity.NewAccount("ID1", 100)
-		destination := entity.NewAccount("ID2", 100)
-		amount := 50
+		written := suite.expectUpdate(entity.NewAccount("ID1", 100), entity.NewAccount("ID2", 100), nil)
 
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.EXPECT().GetAccountByID("ID2").Return(destination, nil)
-		suite.repo.EXPECT().UpdateAccount(origin).Return(nil)
-		suite.repo.EXPECT().UpdateAccount(destination).Return(nil)
-
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
 		})
 
 		suite.NoError(err)
@@ -49,101 +95,90 @@ func (suite *TestTransferUseCaseSuite) TestTransfer() {
 		suite.Equal(output.Destination.Balance, 150)
 		suite.Equal(output.Origin.ID, "ID1")
 		suite.Equal(output.Destination.ID, "ID2")
+		suite.Equal(100, output.OriginPreviousBalance)
+		suite.Equal(100, output.DestinationPreviousBalance)
+		suite.Equal([]int{50, 150}, written.balances())
+		suite.Equal([]event.Type{event.TypeTransferCompleted}, written.types())
 	})
 
-	suite.Run("Should return error when fails to retrieve origin account", func() {
-		amount := 50
+	suite.Run("Should refuse a transfer to the origin account", func() {
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
+			Origin:      "ID1",
+			Destination: "ID1",
+			Amount:      10,
+		})
+
+		suite.ErrorIs(err, ErrTransferSameAccount)
+		suite.ErrorIs(err, domainErrs.ErrSameAccountTransfer)
+		suite.Nil(output)
+	})
 
+	suite.Run("Should return error when fails to update the accounts", func() {
 		suite.repo.
 			EXPECT().
-			GetAccountByID("ID1").
-			Return(nil, errors.New("[AccountRepository] internal error"))
+			UpdateAccounts(gomock.Any(), []string{"ID1", "ID2"}, gomock.Any()).
+			Return(errors.New("[AccountRepository] internal error"))
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
 		})
 
-		suite.ErrorIs(err, ErrTransferFailToRetrieveOriginAccount)
+		suite.ErrorIs(err, ErrTransferFailToUpdateAccounts)
 		suite.Nil(output)
 	})
 
-	suite.Run("Should return error when origin account does not exists", func() {
-		amount := 50
-
-		suite.repo.
-			EXPECT().
-			GetAccountByID("ID1").
-			Return(nil, nil)
+	suite.Run("Should return error when fails to write the accounts", func() {
+		written := suite.expectUpdate(entity.NewAccount("ID1", 100), entity.NewAccount("ID2", 100), errors.New("[AccountRepository] internal error"))
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
 		})
 
-		suite.ErrorIs(err, ErrTransferOriginAccountNotExists)
+		suite.ErrorIs(err, ErrTransferFailToUpdateAccounts)
 		suite.Nil(output)
+		suite.Empty(written.accounts)
 	})
 
-	suite.Run("Should return error when fails to retrieve destination account", func() {
-		origin := entity.NewAccount("ID1", 100)
-		amount := 50
-
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.
-			EXPECT().
-			GetAccountByID("ID2").
-			Return(nil, errors.New("[AccountRepository] internal error"))
+	suite.Run("Should return error when origin account does not exists", func() {
+		written := suite.expectUpdate(nil, entity.NewAccount("ID2", 100), nil)
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
 		})
 
-		suite.ErrorIs(err, ErrWithdrawFailDestinationAccountNotExists)
+		suite.ErrorIs(err, ErrTransferOriginAccountNotExists)
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
 		suite.Nil(output)
+		suite.Empty(written.accounts)
 	})
 
 	suite.Run("Should return error when fails to withdraw from origin account", func() {
-		origin := entity.NewAccount("ID1", 100)
-		destination := entity.NewAccount("ID2", 100)
-		amount := 150
-
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.EXPECT().GetAccountByID("ID2").Return(destination, nil)
+		written := suite.expectUpdate(entity.NewAccount("ID1", 100), entity.NewAccount("ID2", 100), nil)
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      150,
 		})
 
 		suite.ErrorIs(err, ErrTransferFailToWithdrawOriginAccount)
 		suite.Nil(output)
+		suite.Empty(written.accounts)
 	})
 
 	suite.Run("Should create destination account when it does not exists", func() {
-		origin := entity.NewAccount("ID1", 100)
-		amount := 50
-
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.
-			EXPECT().
-			GetAccountByID("ID2").
-			Return(nil, nil)
-		suite.repo.
-			EXPECT().
-			SaveAccount(&entity.Account{ID: "ID2", Balance: amount}).
-			Return(nil)
-		suite.repo.EXPECT().UpdateAccount(origin).Return(nil)
+		written := suite.expectUpdate(entity.NewAccount("ID1", 100), nil, nil)
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
 		})
 
 		suite.NoError(err)
@@ -151,148 +186,165 @@ func (suite *TestTransferUseCaseSuite) TestTransfer() {
 		suite.Equal(output.Destination.Balance, 50)
 		suite.Equal(output.Origin.ID, "ID1")
 		suite.Equal(output.Destination.ID, "ID2")
+		suite.Equal(100, output.OriginPreviousBalance)
+		suite.Equal(0, output.DestinationPreviousBalance)
+		suite.Equal([]int{50, 50}, written.balances())
+		suite.Equal(newCheckingAccount("ID2", 50), written.accounts[1])
+		suite.Equal([]event.Type{event.TypeAccountCreated, event.TypeTransferCompleted}, written.types())
 	})
 
-	suite.Run("Should return error when fails to update origin account", func() {
-		origin := entity.NewAccount("ID1", 100)
-		destination := entity.NewAccount("ID2", 100)
-		amount := 50
+	suite.Run("Should leave both accounts unchanged when fails to create destination account", func() {
+		products := mocks.NewMockProductRepository(suite.ctrl)
+		products.EXPECT().GetProductByID(gomock.Any(), "checking").Return(entity.NewProduct("checking", "Checking"), nil)
+		products.EXPECT().GetDefaultProduct(gomock.Any()).Return(nil, errors.New("[ProductRepository] internal error"))
+		suite.sut = NewTransferUseCase(suite.repo, suite.customerRepo, products)
+		written := suite.expectUpdate(newCheckingAccount("ID1", 100), nil, nil)
 
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.EXPECT().GetAccountByID("ID2").Return(destination, nil)
-		suite.repo.
-			EXPECT().
-			UpdateAccount(origin).
-			Return(errors.New("[AccountRepository] internal error"))
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
+			Origin:      "ID1",
+			Destination: "ID2",
+			Amount:      50,
+		})
+
+		suite.ErrorIs(err, ErrTransferFailToCreateDestinationAccount)
+		suite.Nil(output)
+		suite.Empty(written.accounts)
+	})
+
+	suite.Run("Should leave both accounts unchanged when fails to deposit destination account", func() {
+		destination := entity.NewAccount("ID2", 100)
+		destination.Status = entity.AccountStatusFrozen
+		written := suite.expectUpdate(entity.NewAccount("ID1", 100), destination, nil)
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
 		})
 
-		suite.ErrorIs(err, ErrTransferFailToUpdateOriginAccount)
+		suite.ErrorIs(err, ErrTransferFailToDepositDestinationAccount)
+		suite.ErrorIs(err, domainErrs.ErrAccountFrozen)
 		suite.Nil(output)
+		suite.Empty(written.accounts)
 	})
 
-	suite.Run("Should rollback origin account when fails to create destination account", func() {
-		origin := entity.NewAccount("ID1", 100)
-		amount := 50
+	suite.Run("Should transfer amount when customer owns origin account", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("ID1")
 
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.
-			EXPECT().
-			GetAccountByID("ID2").
-			Return(nil, nil)
-		suite.repo.
-			EXPECT().
-			SaveAccount(&entity.Account{ID: "ID2", Balance: amount}).
-			Return(errors.New("[AccountRepository] internal error"))
-		suite.repo.
-			EXPECT().
-			UpdateAccount(origin).
-			Return(nil)
-		suite.repo.
-			EXPECT().
-			UpdateAccount(&entity.Account{ID: origin.ID, Balance: 100}).
-			Return(nil)
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.expectUpdate(entity.NewAccount("ID1", 100), entity.NewAccount("ID2", 100), nil)
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
+			CustomerID:  "C1",
 		})
 
-		suite.ErrorIs(err, ErrTransferFailToCreateDestinationAccount)
-		suite.Nil(output)
+		suite.NoError(err)
+		suite.Equal(output.Origin.Balance, 50)
 	})
 
-	suite.Run("Should return error when fails to rollback account after fails to create destination account", func() {
-		origin := entity.NewAccount("ID1", 100)
-		amount := 50
+	suite.Run("Should return error when customer does not own origin account", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("ID2")
 
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.
-			EXPECT().
-			GetAccountByID("ID2").
-			Return(nil, nil)
-		suite.repo.
-			EXPECT().
-			SaveAccount(&entity.Account{ID: "ID2", Balance: amount}).
-			Return(errors.New("[AccountRepository] internal error"))
-		suite.repo.
-			EXPECT().
-			UpdateAccount(origin).
-			Return(nil)
-		suite.repo.
-			EXPECT().
-			UpdateAccount(&entity.Account{ID: origin.ID, Balance: 100}).
-			Return(errors.New("[AccountRepository] internal error"))
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
+			CustomerID:  "C1",
 		})
 
-		suite.ErrorIs(err, ErrTransferFailToCreateDestinationAccount)
-		suite.ErrorIs(err, ErrTransferFailToRollbackOriginAccount)
+		suite.ErrorIs(err, ErrTransferOriginAccountNotOwned)
+		suite.ErrorIs(err, domainErrs.ErrAccountNotOwned)
 		suite.Nil(output)
 	})
 
-	suite.Run("Should rollback origin account when fails to deposit destination account", func() {
-		origin := entity.NewAccount("ID1", 100)
-		destination := entity.NewAccount("ID2", 100)
-		amount := 50
-
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.EXPECT().GetAccountByID("ID2").Return(destination, nil)
-		suite.repo.EXPECT().UpdateAccount(origin).Return(nil)
-		suite.repo.
+	suite.Run("Should return error when customer does not exist", func() {
+		suite.customerRepo.
 			EXPECT().
-			UpdateAccount(destination).
-			Return(errors.New("[AccountRepository] internal error"))
-		suite.repo.
+			GetCustomerByID(gomock.Any(), "C1").
+			Return(nil, domainErrs.ErrCustomerNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
+			Origin:      "ID1",
+			Destination: "ID2",
+			Amount:      50,
+			CustomerID:  "C1",
+		})
+
+		suite.ErrorIs(err, domainErrs.ErrAccountNotOwned)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when fails to retrieve customer", func() {
+		suite.customerRepo.
 			EXPECT().
-			UpdateAccount(&entity.Account{ID: origin.ID, Balance: 100}).
-			Return(nil)
+			GetCustomerByID(gomock.Any(), "C1").
+			Return(nil, errors.New("[CustomerRepository] internal error"))
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
+			CustomerID:  "C1",
 		})
 
-		suite.ErrorIs(err, ErrTransferFailToDepositDestinationAccount)
+		suite.ErrorIs(err, ErrTransferFailToRetrieveCustomer)
 		suite.Nil(output)
 	})
 
-	suite.Run("Should return error when fails to rollback account after fails to deposit destination account", func() {
+	suite.Run("Should charge the origin product transfer fee", func() {
 		origin := entity.NewAccount("ID1", 100)
-		destination := entity.NewAccount("ID2", 100)
-		amount := 50
+		origin.ProductID = "savings"
+		savings := entity.NewProduct("savings", "Savings")
+		savings.Fees.Transfer = 2
 
-		suite.repo.EXPECT().GetAccountByID("ID1").Return(origin, nil)
-		suite.repo.EXPECT().GetAccountByID("ID2").Return(destination, nil)
-		suite.repo.EXPECT().UpdateAccount(origin).Return(nil)
-		suite.repo.
-			EXPECT().
-			UpdateAccount(destination).
-			Return(errors.New("[AccountRepository] internal error"))
-		suite.repo.
-			EXPECT().
-			UpdateAccount(&entity.Account{ID: origin.ID, Balance: 100}).
-			Return(errors.New("[AccountRepository] internal error"))
+		suite.productRepo.EXPECT().GetProductByID(gomock.Any(), "savings").Return(savings, nil)
+		suite.expectUpdate(origin, entity.NewAccount("ID2", 100), nil)
 
-		output, err := suite.sut.Execute(TransferInputDTO{
+		output, err := suite.sut.Execute(context.Background(), TransferInputDTO{
 			Origin:      "ID1",
 			Destination: "ID2",
-			Amount:      amount,
+			Amount:      50,
 		})
 
-		suite.ErrorIs(err, ErrTransferFailToDepositDestinationAccount)
-		suite.ErrorIs(err, ErrTransferFailToRollbackOriginAccount)
-		suite.Nil(output)
+		suite.NoError(err)
+		suite.Equal(48, output.Origin.Balance)
+		suite.Equal(150, output.Destination.Balance)
+	})
+}
+
+func (suite *TestTransferUseCaseSuite) TestPublish() {
+	suite.Run("Should publish the event of a committed transfer", func() {
+		publisher := &publisherSpy{}
+		suite.sut.WithPublisher(publisher)
+		suite.expectUpdate(entity.NewAccount("ID1", 100), entity.NewAccount("ID2", 100), nil)
+
+		suite.sut.Execute(context.Background(), TransferInputDTO{Origin: "ID1", Destination: "ID2", Amount: 50})
+
+		suite.Equal([]event.Type{event.TypeTransferCompleted}, publisher.types())
+		suite.Equal(event.TransferCompleted{
+			Origin:             "ID1",
+			Destination:        "ID2",
+			Amount:             50,
+			OriginBalance:      50,
+			DestinationBalance: 150,
+		}, publisher.events[0].Data)
+	})
+
+	suite.Run("Should not publish a transfer that was not written", func() {
+		publisher := &publisherSpy{}
+		suite.sut.WithPublisher(publisher)
+		suite.expectUpdate(entity.NewAccount("ID1", 100), entity.NewAccount("ID2", 100), errors.New("[AccountRepository] internal error"))
+
+		suite.sut.Execute(context.Background(), TransferInputDTO{Origin: "ID1", Destination: "ID2", Amount: 50})
+
+		suite.Empty(publisher.events)
 	})
 }
 
@@ -1,14 +1,22 @@
 package account
 
 import (
+	"context"
 	"errors"
+	"log/slog"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
 	"simple-bank/internal/domain/repository"
 	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/logging"
+	"simple-bank/internal/shared/tracing"
+	"time"
 )
 
 var (
 	ErrWithdrawFailToRetrieveAccount = errors.New("[WithdrawUseCase] Fail to retrieve account")
 	ErrWithdrawAccountNotExists      = errors.New("[WithdrawUseCase] Account not exists")
+	ErrWithdrawFailToRetrieveProduct = errors.New("[WithdrawUseCase] Fail to retrieve product")
 	ErrWithdrawFailToWithdraw        = errors.New("[WithdrawUseCase] Fail to withdraw")
 	ErrWithdrawFailToUpdateAccount   = errors.New("[WithdrawUseCase] Fail to update account")
 )
@@ -21,40 +29,91 @@ type WithdrawInputDTO struct {
 type WithdrawOutputDTO struct {
 	Origin dto.AccountDTO
 	Amount int
+	Fee    int
+	// OriginPreviousBalance is the balance before the withdrawal.
+	OriginPreviousBalance int
 }
 
 type WithdrawUseCase struct {
 	accountRepository repository.AccountRepository
+	productRepository repository.ProductRepository
+	now               func() time.Time
+	recorder          OperationRecorder
+	publisher         event.Publisher
 }
 
-func NewWithdrawUseCase(accountRepository repository.AccountRepository) *WithdrawUseCase {
-	return &WithdrawUseCase{accountRepository: accountRepository}
+func NewWithdrawUseCase(
+	accountRepository repository.AccountRepository,
+	productRepository repository.ProductRepository,
+) *WithdrawUseCase {
+	return &WithdrawUseCase{
+		accountRepository: accountRepository,
+		productRepository: productRepository,
+		now:               time.Now,
+		recorder:          noopRecorder{},
+		publisher:         event.Discard,
+	}
+}
+
+// WithRecorder reports every execution to recorder.
+func (uc *WithdrawUseCase) WithRecorder(recorder OperationRecorder) *WithdrawUseCase {
+	uc.recorder = recorder
+	return uc
+}
+
+// WithPublisher publishes the events of every committed withdrawal on publisher.
+func (uc *WithdrawUseCase) WithPublisher(publisher event.Publisher) *WithdrawUseCase {
+	uc.publisher = publisher
+	return uc
 }
 
-func (uc *WithdrawUseCase) Execute(input WithdrawInputDTO) (*WithdrawOutputDTO, error) {
-	account, err := uc.accountRepository.GetAccountByID(input.Origin)
+func (uc *WithdrawUseCase) Execute(ctx context.Context, input WithdrawInputDTO) (*WithdrawOutputDTO, error) {
+	ctx, span := tracing.Start(ctx, "WithdrawUseCase.Execute")
+	output, err := uc.execute(ctx, input)
+	report(ctx, uc.recorder, OperationWithdraw, input.Amount, err, slog.String(logging.KeyOrigin, input.Origin))
+	tracing.End(span, &err)
+	return output, err
+}
+
+func (uc *WithdrawUseCase) execute(ctx context.Context, input WithdrawInputDTO) (*WithdrawOutputDTO, error) {
+	account, err := uc.accountRepository.GetAccountByID(ctx, input.Origin)
+	if errors.Is(err, domainErrs.ErrAccountNotFound) {
+		return nil, errors.Join(ErrWithdrawAccountNotExists, err)
+	}
+
 	if err != nil {
 		return nil, errors.Join(ErrWithdrawFailToRetrieveAccount, err)
 	}
 
-	if account == nil {
-		return nil, ErrWithdrawAccountNotExists
+	product, err := findProduct(ctx, uc.productRepository, account.ProductID)
+	if err != nil {
+		return nil, errors.Join(ErrWithdrawFailToRetrieveProduct, err)
 	}
 
-	err = account.Withdraw(input.Amount)
+	previousBalance := account.Balance
+	err = account.Debit(product, input.Amount, product.Fees.Withdrawal, uc.now())
 	if err != nil {
 		return nil, errors.Join(ErrWithdrawFailToWithdraw, err)
 	}
 
-	if err = uc.accountRepository.UpdateAccount(account); err != nil {
+	withdrawn := newEvent(uc.now(), event.FundsWithdrawn{
+		Account: account.ID,
+		Amount:  input.Amount,
+		Fee:     product.Fees.Withdrawal,
+		Balance: account.Balance,
+	})
+	if err = uc.accountRepository.UpdateAccount(ctx, account, withdrawn); err != nil {
 		return nil, errors.Join(ErrWithdrawFailToUpdateAccount, err)
 	}
+	uc.publisher.Publish(ctx, withdrawn)
 
 	return &WithdrawOutputDTO{
 		Origin: dto.AccountDTO{
 			ID:      account.ID,
 			Balance: account.Balance,
 		},
-		Amount: input.Amount,
+		Amount:                input.Amount,
+		Fee:                   product.Fees.Withdrawal,
+		OriginPreviousBalance: previousBalance,
 	}, nil
 }
@@ -1,11 +1,14 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"simple-bank/internal/domain/entity"
 	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
 	"simple-bank/internal/domain/repository/mocks"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -13,15 +16,17 @@ import (
 
 type TestWithdrawUseCaseSuite struct {
 	suite.Suite
-	ctrl *gomock.Controller
-	repo *mocks.MockAccountRepository
-	sut  *WithdrawUseCase
+	ctrl        *gomock.Controller
+	repo        *mocks.MockAccountRepository
+	productRepo *mocks.MockProductRepository
+	sut         *WithdrawUseCase
 }
 
 func (suite *TestWithdrawUseCaseSuite) SetupSubTest() {
 	suite.ctrl = gomock.NewController(suite.T())
 	suite.repo = mocks.NewMockAccountRepository(suite.ctrl)
-	suite.sut = NewWithdrawUseCase(suite.repo)
+	suite.productRepo = newProductRepositoryMock(suite.ctrl)
+	suite.sut = NewWithdrawUseCase(suite.repo, suite.productRepo)
 }
 
 func (suite *TestWithdrawUseCaseSuite) TearDownSubTest() {
@@ -31,10 +36,10 @@ func (suite *TestWithdrawUseCaseSuite) TearDownSubTest() {
 func (suite *TestWithdrawUseCaseSuite) TestWithdraw() {
 	suite.Run("Should withdraw amount from account", func() {
 		account := entity.NewAccount("1", 100)
-		suite.repo.EXPECT().GetAccountByID("1").Return(account, nil)
-		suite.repo.EXPECT().UpdateAccount(account).Return(nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(account, nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), account, anEvent(event.TypeFundsWithdrawn)).Return(nil)
 
-		output, err := suite.sut.Execute(WithdrawInputDTO{
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
 			Origin: "1",
 			Amount: 50,
 		})
@@ -45,9 +50,9 @@ func (suite *TestWithdrawUseCaseSuite) TestWithdraw() {
 	})
 
 	suite.Run("Should return error when fail to retrieve account", func() {
-		suite.repo.EXPECT().GetAccountByID("1").Return(nil, errors.New("[AccountRepository] internal error"))
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(nil, errors.New("[AccountRepository] internal error"))
 
-		output, err := suite.sut.Execute(WithdrawInputDTO{
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
 			Origin: "1",
 			Amount: 50,
 		})
@@ -58,9 +63,9 @@ func (suite *TestWithdrawUseCaseSuite) TestWithdraw() {
 
 	suite.Run("Should return error when withdraw account without balance", func() {
 		account := entity.NewAccount("1", 100)
-		suite.repo.EXPECT().GetAccountByID("1").Return(account, nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(account, nil)
 
-		output, err := suite.sut.Execute(WithdrawInputDTO{
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
 			Origin: "1",
 			Amount: 150,
 		})
@@ -72,10 +77,10 @@ func (suite *TestWithdrawUseCaseSuite) TestWithdraw() {
 
 	suite.Run("Should return error when fail to update account", func() {
 		account := entity.NewAccount("1", 100)
-		suite.repo.EXPECT().GetAccountByID("1").Return(account, nil)
-		suite.repo.EXPECT().UpdateAccount(account).Return(errors.New("[AccountRepository] internal error"))
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(account, nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), account, anEvent(event.TypeFundsWithdrawn)).Return(errors.New("[AccountRepository] internal error"))
 
-		output, err := suite.sut.Execute(WithdrawInputDTO{
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
 			Origin: "1",
 			Amount: 50,
 		})
@@ -85,16 +90,120 @@ func (suite *TestWithdrawUseCaseSuite) TestWithdraw() {
 	})
 
 	suite.Run("Should return error when account not exists", func() {
-		suite.repo.EXPECT().GetAccountByID("1").Return(nil, nil)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(nil, domainErrs.ErrAccountNotFound)
 
-		output, err := suite.sut.Execute(WithdrawInputDTO{
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
 			Origin: "1",
 			Amount: 50,
 		})
 
 		suite.ErrorIs(err, ErrWithdrawAccountNotExists)
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
 		suite.Nil(output)
 	})
+
+	suite.Run("Should charge the product withdrawal fee", func() {
+		account := entity.NewAccount("1", 100)
+		account.ProductID = "savings"
+		savings := entity.NewProduct("savings", "Savings")
+		savings.Fees.Withdrawal = 2
+
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(account, nil)
+		suite.productRepo.EXPECT().GetProductByID(gomock.Any(), "savings").Return(savings, nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), account, anEvent(event.TypeFundsWithdrawn)).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
+			Origin: "1",
+			Amount: 50,
+		})
+
+		suite.NoError(err)
+		suite.Equal(48, output.Origin.Balance)
+		suite.Equal(2, output.Fee)
+		suite.Equal(100, output.OriginPreviousBalance)
+	})
+
+	suite.Run("Should return error when the monthly withdrawal limit is reached", func() {
+		now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+		suite.sut.now = func() time.Time { return now }
+		account := entity.NewAccount("1", 100)
+		account.ProductID = "savings"
+		account.WithdrawalPeriod = "2024-03"
+		account.MonthlyWithdrawals = 6
+		savings := entity.NewProduct("savings", "Savings")
+		savings.MonthlyWithdrawalLimit = 6
+
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(account, nil)
+		suite.productRepo.EXPECT().GetProductByID(gomock.Any(), "savings").Return(savings, nil)
+
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
+			Origin: "1",
+			Amount: 50,
+		})
+
+		suite.ErrorIs(err, ErrWithdrawFailToWithdraw)
+		suite.ErrorIs(err, domainErrs.ErrAccountWithdrawalLimitExceeded)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should allow business accounts to use the overdraft", func() {
+		account := entity.NewAccount("1", 100)
+		account.ProductID = "business"
+		business := entity.NewProduct("business", "Business")
+		business.OverdraftLimit = 500
+
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(account, nil)
+		suite.productRepo.EXPECT().GetProductByID(gomock.Any(), "business").Return(business, nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), account, anEvent(event.TypeFundsWithdrawn)).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
+			Origin: "1",
+			Amount: 300,
+		})
+
+		suite.NoError(err)
+		suite.Equal(-200, output.Origin.Balance)
+	})
+
+	suite.Run("Should return error when fails to retrieve product", func() {
+		account := entity.NewAccount("1", 100)
+		account.ProductID = "gold"
+
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(account, nil)
+		suite.productRepo.EXPECT().GetProductByID(gomock.Any(), "gold").Return(nil, domainErrs.ErrProductNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), WithdrawInputDTO{
+			Origin: "1",
+			Amount: 50,
+		})
+
+		suite.ErrorIs(err, ErrWithdrawFailToRetrieveProduct)
+		suite.Nil(output)
+	})
+}
+
+func (suite *TestWithdrawUseCaseSuite) TestPublish() {
+	suite.Run("Should publish the event of a committed withdrawal", func() {
+		publisher := &publisherSpy{}
+		suite.sut.WithPublisher(publisher)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(entity.NewAccount("1", 100), nil)
+		suite.repo.EXPECT().UpdateAccount(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		suite.sut.Execute(context.Background(), WithdrawInputDTO{Origin: "1", Amount: 50})
+
+		suite.Equal([]event.Type{event.TypeFundsWithdrawn}, publisher.types())
+		suite.Equal(event.FundsWithdrawn{Account: "1", Amount: 50, Balance: 50}, publisher.events[0].Data)
+	})
+
+	suite.Run("Should not publish a rejected withdrawal", func() {
+		publisher := &publisherSpy{}
+		suite.sut.WithPublisher(publisher)
+		suite.repo.EXPECT().GetAccountByID(gomock.Any(), "1").Return(entity.NewAccount("1", 10), nil)
+
+		suite.sut.Execute(context.Background(), WithdrawInputDTO{Origin: "1", Amount: 50})
+
+		suite.Empty(publisher.events)
+	})
 }
 
 func TestWithdraw(t *testing.T) {
@@ -0,0 +1,53 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/shared/dto"
+	"slices"
+)
+
+const secretPrefix = "sbk_"
+
+// HashSecret returns the value stored in place of the secret. Secrets carry
+// 256 random bits, so a plain SHA-256 is enough to make them unrecoverable.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func newAPIKeyID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func newSecret() string {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return secretPrefix + hex.EncodeToString(secret)
+}
+
+func toAPIKeyDTO(key *entity.APIKey) dto.APIKeyDTO {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	accountIDs := slices.Clone(key.AccountIDs)
+	if accountIDs == nil {
+		accountIDs = []string{}
+	}
+
+	return dto.APIKeyDTO{
+		ID:         key.ID,
+		Name:       key.Name,
+		Scopes:     scopes,
+		AccountIDs: accountIDs,
+		CustomerID: key.CustomerID,
+		CreatedAt:  key.CreatedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrAuthenticateAPIKeyInvalidKey           = errors.New("[AuthenticateAPIKeyUseCase] Invalid API key")
+	ErrAuthenticateAPIKeyFailToRetrieveAPIKey = errors.New("[AuthenticateAPIKeyUseCase] Fail to retrieve API key")
+)
+
+type AuthenticateAPIKeyInputDTO struct {
+	Secret string
+}
+
+type AuthenticateAPIKeyOutputDTO struct {
+	Key dto.APIKeyDTO
+}
+
+type AuthenticateAPIKeyUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func NewAuthenticateAPIKeyUseCase(apiKeyRepository repository.APIKeyRepository) *AuthenticateAPIKeyUseCase {
+	return &AuthenticateAPIKeyUseCase{apiKeyRepository: apiKeyRepository}
+}
+
+func (uc *AuthenticateAPIKeyUseCase) Execute(
+	ctx context.Context,
+	input AuthenticateAPIKeyInputDTO,
+) (output *AuthenticateAPIKeyOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "AuthenticateAPIKeyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	key, err := uc.apiKeyRepository.GetAPIKeyByHash(ctx, HashSecret(input.Secret))
+	if errors.Is(err, domainErrs.ErrAPIKeyNotFound) {
+		return nil, errors.Join(ErrAuthenticateAPIKeyInvalidKey, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrAuthenticateAPIKeyFailToRetrieveAPIKey, err)
+	}
+
+	if key.IsRevoked() {
+		return nil, errors.Join(ErrAuthenticateAPIKeyInvalidKey, domainErrs.ErrAPIKeyRevoked)
+	}
+
+	return &AuthenticateAPIKeyOutputDTO{Key: toAPIKeyDTO(key)}, nil
+}
@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestAuthenticateAPIKeyUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockAPIKeyRepository
+	sut  *AuthenticateAPIKeyUseCase
+}
+
+func (suite *TestAuthenticateAPIKeyUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockAPIKeyRepository(suite.ctrl)
+	suite.sut = NewAuthenticateAPIKeyUseCase(suite.repo)
+}
+
+func (suite *TestAuthenticateAPIKeyUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestAuthenticateAPIKeyUseCaseSuite) TestAuthenticateAPIKey() {
+	suite.Run("Should return key matching the secret hash", func() {
+		key := entity.NewAPIKey("K1", "backoffice", HashSecret("secret"), []entity.Scope{entity.ScopeBalanceRead}, nil, time.Now())
+		suite.repo.EXPECT().GetAPIKeyByHash(gomock.Any(), HashSecret("secret")).Return(key, nil)
+
+		output, err := suite.sut.Execute(context.Background(), AuthenticateAPIKeyInputDTO{Secret: "secret"})
+
+		suite.NoError(err)
+		suite.Equal("K1", output.Key.ID)
+	})
+
+	suite.Run("Should return error when key does not exist", func() {
+		suite.repo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(nil, domainErrs.ErrAPIKeyNotFound)
+
+		_, err := suite.sut.Execute(context.Background(), AuthenticateAPIKeyInputDTO{Secret: "secret"})
+
+		suite.ErrorIs(err, ErrAuthenticateAPIKeyInvalidKey)
+	})
+
+	suite.Run("Should return error when key is revoked", func() {
+		key := entity.NewAPIKey("K1", "backoffice", HashSecret("secret"), nil, nil, time.Now())
+		key.Revoke(time.Now())
+		suite.repo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(key, nil)
+
+		_, err := suite.sut.Execute(context.Background(), AuthenticateAPIKeyInputDTO{Secret: "secret"})
+
+		suite.ErrorIs(err, ErrAuthenticateAPIKeyInvalidKey)
+		suite.ErrorIs(err, domainErrs.ErrAPIKeyRevoked)
+	})
+
+	suite.Run("Should return error when fails to retrieve key", func() {
+		suite.repo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(nil, errors.New("[APIKeyRepository] internal error"))
+
+		_, err := suite.sut.Execute(context.Background(), AuthenticateAPIKeyInputDTO{Secret: "secret"})
+
+		suite.ErrorIs(err, ErrAuthenticateAPIKeyFailToRetrieveAPIKey)
+	})
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	suite.Run(t, new(TestAuthenticateAPIKeyUseCaseSuite))
+}
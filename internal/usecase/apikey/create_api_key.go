@@ -0,0 +1,73 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCreateAPIKeyInvalidName      = errors.New("[CreateAPIKeyUseCase] Invalid name")
+	ErrCreateAPIKeyInvalidScope     = errors.New("[CreateAPIKeyUseCase] Invalid scope")
+	ErrCreateAPIKeyFailToSaveAPIKey = errors.New("[CreateAPIKeyUseCase] Fail to save API key")
+)
+
+type CreateAPIKeyInputDTO struct {
+	Name       string
+	Scopes     []string
+	AccountIDs []string
+	CustomerID string
+}
+
+type CreateAPIKeyOutputDTO struct {
+	Key    dto.APIKeyDTO
+	Secret string
+}
+
+type CreateAPIKeyUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+	now              func() time.Time
+}
+
+func NewCreateAPIKeyUseCase(apiKeyRepository repository.APIKeyRepository) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{
+		apiKeyRepository: apiKeyRepository,
+		now:              time.Now,
+	}
+}
+
+func (uc *CreateAPIKeyUseCase) Execute(ctx context.Context, input CreateAPIKeyInputDTO) (output *CreateAPIKeyOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "CreateAPIKeyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, ErrCreateAPIKeyInvalidName
+	}
+
+	if len(input.Scopes) == 0 {
+		return nil, ErrCreateAPIKeyInvalidScope
+	}
+
+	scopes := make([]entity.Scope, 0, len(input.Scopes))
+	for _, value := range input.Scopes {
+		scope := entity.Scope(value)
+		if !scope.IsValid() {
+			return nil, ErrCreateAPIKeyInvalidScope
+		}
+		scopes = append(scopes, scope)
+	}
+
+	secret := newSecret()
+	key := entity.NewAPIKey(newAPIKeyID(), input.Name, HashSecret(secret), scopes, input.AccountIDs, uc.now().UTC())
+	key.CustomerID = input.CustomerID
+	if err := uc.apiKeyRepository.SaveAPIKey(ctx, key); err != nil {
+		return nil, errors.Join(ErrCreateAPIKeyFailToSaveAPIKey, err)
+	}
+
+	return &CreateAPIKeyOutputDTO{Key: toAPIKeyDTO(key), Secret: secret}, nil
+}
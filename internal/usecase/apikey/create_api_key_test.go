@@ -0,0 +1,114 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository/mocks"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestCreateAPIKeyUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockAPIKeyRepository
+	sut  *CreateAPIKeyUseCase
+}
+
+func (suite *TestCreateAPIKeyUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockAPIKeyRepository(suite.ctrl)
+	suite.sut = NewCreateAPIKeyUseCase(suite.repo)
+}
+
+func (suite *TestCreateAPIKeyUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestCreateAPIKeyUseCaseSuite) TestCreateAPIKey() {
+	suite.Run("Should store only the hash of the secret", func() {
+		var saved *entity.APIKey
+		suite.repo.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, key *entity.APIKey) error {
+				saved = key
+				return nil
+			},
+		)
+
+		output, err := suite.sut.Execute(context.Background(), CreateAPIKeyInputDTO{
+			Name:       "backoffice",
+			Scopes:     []string{"balance:read"},
+			AccountIDs: []string{"100"},
+		})
+
+		suite.NoError(err)
+		suite.True(strings.HasPrefix(output.Secret, "sbk_"))
+		suite.Equal(HashSecret(output.Secret), saved.Hash)
+		suite.NotContains(saved.Hash, output.Secret)
+		suite.Equal([]string{"balance:read"}, output.Key.Scopes)
+		suite.Equal([]string{"100"}, output.Key.AccountIDs)
+	})
+
+	suite.Run("Should bind the key to a customer", func() {
+		var saved *entity.APIKey
+		suite.repo.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, key *entity.APIKey) error {
+				saved = key
+				return nil
+			},
+		)
+
+		output, err := suite.sut.Execute(context.Background(), CreateAPIKeyInputDTO{
+			Name:       "mobile",
+			Scopes:     []string{"event:write"},
+			CustomerID: "C1",
+		})
+
+		suite.NoError(err)
+		suite.Equal("C1", saved.CustomerID)
+		suite.Equal("C1", output.Key.CustomerID)
+	})
+
+	suite.Run("Should return error when scope is unknown", func() {
+		_, err := suite.sut.Execute(context.Background(), CreateAPIKeyInputDTO{
+			Name:   "backoffice",
+			Scopes: []string{"admin:*"},
+		})
+
+		suite.ErrorIs(err, ErrCreateAPIKeyInvalidScope)
+	})
+
+	suite.Run("Should return error when no scope is given", func() {
+		_, err := suite.sut.Execute(context.Background(), CreateAPIKeyInputDTO{Name: "backoffice"})
+
+		suite.ErrorIs(err, ErrCreateAPIKeyInvalidScope)
+	})
+
+	suite.Run("Should return error when name is blank", func() {
+		_, err := suite.sut.Execute(context.Background(), CreateAPIKeyInputDTO{
+			Name:   " ",
+			Scopes: []string{"balance:read"},
+		})
+
+		suite.ErrorIs(err, ErrCreateAPIKeyInvalidName)
+	})
+
+	suite.Run("Should return error when fails to save key", func() {
+		suite.repo.EXPECT().SaveAPIKey(gomock.Any(), gomock.Any()).Return(errors.New("[APIKeyRepository] internal error"))
+
+		_, err := suite.sut.Execute(context.Background(), CreateAPIKeyInputDTO{
+			Name:   "backoffice",
+			Scopes: []string{"balance:read"},
+		})
+
+		suite.ErrorIs(err, ErrCreateAPIKeyFailToSaveAPIKey)
+	})
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	suite.Run(t, new(TestCreateAPIKeyUseCaseSuite))
+}
@@ -0,0 +1,57 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/tracing"
+	"time"
+)
+
+var (
+	ErrRevokeAPIKeyFailToRetrieveAPIKey = errors.New("[RevokeAPIKeyUseCase] Fail to retrieve API key")
+	ErrRevokeAPIKeyAPIKeyNotExists      = errors.New("[RevokeAPIKeyUseCase] API key not exists")
+	ErrRevokeAPIKeyFailToUpdateAPIKey   = errors.New("[RevokeAPIKeyUseCase] Fail to update API key")
+)
+
+type RevokeAPIKeyInputDTO struct {
+	ID string
+}
+
+type RevokeAPIKeyUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+	now              func() time.Time
+}
+
+func NewRevokeAPIKeyUseCase(apiKeyRepository repository.APIKeyRepository) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		apiKeyRepository: apiKeyRepository,
+		now:              time.Now,
+	}
+}
+
+func (uc *RevokeAPIKeyUseCase) Execute(ctx context.Context, input RevokeAPIKeyInputDTO) (err error) {
+	ctx, span := tracing.Start(ctx, "RevokeAPIKeyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	key, err := uc.apiKeyRepository.GetAPIKeyByID(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrAPIKeyNotFound) {
+		return errors.Join(ErrRevokeAPIKeyAPIKeyNotExists, err)
+	}
+
+	if err != nil {
+		return errors.Join(ErrRevokeAPIKeyFailToRetrieveAPIKey, err)
+	}
+
+	if key.IsRevoked() {
+		return nil
+	}
+
+	key.Revoke(uc.now().UTC())
+	if err = uc.apiKeyRepository.UpdateAPIKey(ctx, key); err != nil {
+		return errors.Join(ErrRevokeAPIKeyFailToUpdateAPIKey, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestRevokeAPIKeyUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockAPIKeyRepository
+	sut  *RevokeAPIKeyUseCase
+}
+
+func (suite *TestRevokeAPIKeyUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockAPIKeyRepository(suite.ctrl)
+	suite.sut = NewRevokeAPIKeyUseCase(suite.repo)
+}
+
+func (suite *TestRevokeAPIKeyUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestRevokeAPIKeyUseCaseSuite) TestRevokeAPIKey() {
+	suite.Run("Should revoke key", func() {
+		key := entity.NewAPIKey("K1", "backoffice", "hash", nil, nil, time.Now())
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(key, nil)
+		suite.repo.EXPECT().UpdateAPIKey(gomock.Any(), key).Return(nil)
+
+		err := suite.sut.Execute(context.Background(), RevokeAPIKeyInputDTO{ID: "K1"})
+
+		suite.NoError(err)
+		suite.True(key.IsRevoked())
+	})
+
+	suite.Run("Should do nothing when key is already revoked", func() {
+		key := entity.NewAPIKey("K1", "backoffice", "hash", nil, nil, time.Now())
+		key.Revoke(time.Now())
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(key, nil)
+
+		err := suite.sut.Execute(context.Background(), RevokeAPIKeyInputDTO{ID: "K1"})
+
+		suite.NoError(err)
+	})
+
+	suite.Run("Should return error when key does not exist", func() {
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(nil, domainErrs.ErrAPIKeyNotFound)
+
+		err := suite.sut.Execute(context.Background(), RevokeAPIKeyInputDTO{ID: "K1"})
+
+		suite.ErrorIs(err, ErrRevokeAPIKeyAPIKeyNotExists)
+	})
+
+	suite.Run("Should return error when fails to update key", func() {
+		key := entity.NewAPIKey("K1", "backoffice", "hash", nil, nil, time.Now())
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(key, nil)
+		suite.repo.EXPECT().UpdateAPIKey(gomock.Any(), key).Return(errors.New("[APIKeyRepository] internal error"))
+
+		err := suite.sut.Execute(context.Background(), RevokeAPIKeyInputDTO{ID: "K1"})
+
+		suite.ErrorIs(err, ErrRevokeAPIKeyFailToUpdateAPIKey)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	suite.Run(t, new(TestRevokeAPIKeyUseCaseSuite))
+}
@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+	"slices"
+)
+
+var (
+	ErrRotateAPIKeyFailToRetrieveAPIKey = errors.New("[RotateAPIKeyUseCase] Fail to retrieve API key")
+	ErrRotateAPIKeyAPIKeyNotExists      = errors.New("[RotateAPIKeyUseCase] API key not exists")
+	ErrRotateAPIKeyAPIKeyRevoked        = errors.New("[RotateAPIKeyUseCase] API key revoked")
+	ErrRotateAPIKeyScopeNotHeld         = errors.New("[RotateAPIKeyUseCase] Scope not held by the caller")
+	ErrRotateAPIKeyFailToUpdateAPIKey   = errors.New("[RotateAPIKeyUseCase] Fail to update API key")
+)
+
+type RotateAPIKeyInputDTO struct {
+	ID string
+	// Scopes are the scopes of the caller, a key with other scopes is not
+	// rotated so its secret cannot be taken over. Nil allows every key.
+	Scopes []entity.Scope
+}
+
+type RotateAPIKeyOutputDTO struct {
+	Key    dto.APIKeyDTO
+	Secret string
+}
+
+type RotateAPIKeyUseCase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func NewRotateAPIKeyUseCase(apiKeyRepository repository.APIKeyRepository) *RotateAPIKeyUseCase {
+	return &RotateAPIKeyUseCase{apiKeyRepository: apiKeyRepository}
+}
+
+func (uc *RotateAPIKeyUseCase) Execute(ctx context.Context, input RotateAPIKeyInputDTO) (output *RotateAPIKeyOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "RotateAPIKeyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	key, err := uc.apiKeyRepository.GetAPIKeyByID(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrAPIKeyNotFound) {
+		return nil, errors.Join(ErrRotateAPIKeyAPIKeyNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrRotateAPIKeyFailToRetrieveAPIKey, err)
+	}
+
+	if key.IsRevoked() {
+		return nil, errors.Join(ErrRotateAPIKeyAPIKeyRevoked, domainErrs.ErrAPIKeyRevoked)
+	}
+
+	if input.Scopes != nil {
+		for _, scope := range key.Scopes {
+			if !slices.Contains(input.Scopes, scope) {
+				return nil, ErrRotateAPIKeyScopeNotHeld
+			}
+		}
+	}
+
+	secret := newSecret()
+	key.Rotate(HashSecret(secret))
+	if err = uc.apiKeyRepository.UpdateAPIKey(ctx, key); err != nil {
+		return nil, errors.Join(ErrRotateAPIKeyFailToUpdateAPIKey, err)
+	}
+
+	return &RotateAPIKeyOutputDTO{Key: toAPIKeyDTO(key), Secret: secret}, nil
+}
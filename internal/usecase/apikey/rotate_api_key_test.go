@@ -0,0 +1,90 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestRotateAPIKeyUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockAPIKeyRepository
+	sut  *RotateAPIKeyUseCase
+}
+
+func (suite *TestRotateAPIKeyUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockAPIKeyRepository(suite.ctrl)
+	suite.sut = NewRotateAPIKeyUseCase(suite.repo)
+}
+
+func (suite *TestRotateAPIKeyUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestRotateAPIKeyUseCaseSuite) TestRotateAPIKey() {
+	suite.Run("Should replace the secret keeping scopes", func() {
+		key := entity.NewAPIKey("K1", "backoffice", HashSecret("old"), []entity.Scope{entity.ScopeEventWrite}, nil, time.Now())
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(key, nil)
+		suite.repo.EXPECT().UpdateAPIKey(gomock.Any(), key).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), RotateAPIKeyInputDTO{ID: "K1"})
+
+		suite.NoError(err)
+		suite.NotEqual("old", output.Secret)
+		suite.Equal(HashSecret(output.Secret), key.Hash)
+		suite.Equal([]string{"event:write"}, output.Key.Scopes)
+	})
+
+	suite.Run("Should return error when key does not exist", func() {
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(nil, domainErrs.ErrAPIKeyNotFound)
+
+		_, err := suite.sut.Execute(context.Background(), RotateAPIKeyInputDTO{ID: "K1"})
+
+		suite.ErrorIs(err, ErrRotateAPIKeyAPIKeyNotExists)
+		suite.ErrorIs(err, domainErrs.ErrAPIKeyNotFound)
+	})
+
+	suite.Run("Should return error when key is revoked", func() {
+		key := entity.NewAPIKey("K1", "backoffice", "hash", nil, nil, time.Now())
+		key.Revoke(time.Now())
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(key, nil)
+
+		_, err := suite.sut.Execute(context.Background(), RotateAPIKeyInputDTO{ID: "K1"})
+
+		suite.ErrorIs(err, ErrRotateAPIKeyAPIKeyRevoked)
+		suite.ErrorIs(err, domainErrs.ErrAPIKeyRevoked)
+	})
+
+	suite.Run("Should refuse keys with scopes the caller does not hold", func() {
+		key := entity.NewAPIKey("K1", "admin", "hash", []entity.Scope{entity.ScopeAdminKeys, entity.ScopeAdminReset}, nil, time.Now())
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(key, nil)
+
+		_, err := suite.sut.Execute(context.Background(), RotateAPIKeyInputDTO{ID: "K1", Scopes: []entity.Scope{entity.ScopeAdminKeys}})
+
+		suite.ErrorIs(err, ErrRotateAPIKeyScopeNotHeld)
+		suite.Equal("hash", key.Hash)
+	})
+
+	suite.Run("Should return error when fails to update key", func() {
+		key := entity.NewAPIKey("K1", "backoffice", "hash", nil, nil, time.Now())
+		suite.repo.EXPECT().GetAPIKeyByID(gomock.Any(), "K1").Return(key, nil)
+		suite.repo.EXPECT().UpdateAPIKey(gomock.Any(), key).Return(errors.New("[APIKeyRepository] internal error"))
+
+		_, err := suite.sut.Execute(context.Background(), RotateAPIKeyInputDTO{ID: "K1"})
+
+		suite.ErrorIs(err, ErrRotateAPIKeyFailToUpdateAPIKey)
+	})
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	suite.Run(t, new(TestRotateAPIKeyUseCaseSuite))
+}
@@ -0,0 +1,53 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+	"strings"
+)
+
+var (
+	ErrCreateCustomerInvalidName        = errors.New("[CreateCustomerUseCase] Invalid name")
+	ErrCreateCustomerFailToSaveCustomer = errors.New("[CreateCustomerUseCase] Fail to save customer")
+)
+
+type CreateCustomerInputDTO struct {
+	Name  string
+	Email string
+	Phone string
+}
+
+type CreateCustomerOutputDTO struct {
+	Customer dto.CustomerDTO
+}
+
+type CreateCustomerUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewCreateCustomerUseCase(customerRepository repository.CustomerRepository) *CreateCustomerUseCase {
+	return &CreateCustomerUseCase{customerRepository: customerRepository}
+}
+
+func (uc *CreateCustomerUseCase) Execute(
+	ctx context.Context,
+	input CreateCustomerInputDTO,
+) (output *CreateCustomerOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "CreateCustomerUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, ErrCreateCustomerInvalidName
+	}
+
+	customer := entity.NewCustomer(newCustomerID(), input.Name, input.Email, input.Phone)
+	if err := uc.customerRepository.SaveCustomer(ctx, customer); err != nil {
+		return nil, errors.Join(ErrCreateCustomerFailToSaveCustomer, err)
+	}
+
+	return &CreateCustomerOutputDTO{Customer: toCustomerDTO(customer)}, nil
+}
@@ -0,0 +1,76 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestCreateCustomerUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockCustomerRepository
+	sut  *CreateCustomerUseCase
+}
+
+func (suite *TestCreateCustomerUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.sut = NewCreateCustomerUseCase(suite.repo)
+}
+
+func (suite *TestCreateCustomerUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestCreateCustomerUseCaseSuite) TestCreateCustomer() {
+	suite.Run("Should create customer pending KYC", func() {
+		suite.repo.EXPECT().SaveCustomer(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, customer *entity.Customer) error {
+				suite.NotEmpty(customer.ID)
+				suite.Equal("John", customer.Name)
+				return nil
+			},
+		)
+
+		output, err := suite.sut.Execute(context.Background(), CreateCustomerInputDTO{
+			Name:  "John",
+			Email: "john@example.com",
+		})
+
+		suite.NoError(err)
+		suite.NotEmpty(output.Customer.ID)
+		suite.Equal("John", output.Customer.Name)
+		suite.Equal("john@example.com", output.Customer.Email)
+		suite.Equal("pending", output.Customer.KYCStatus)
+		suite.Empty(output.Customer.AccountIDs)
+	})
+
+	suite.Run("Should return error when name is blank", func() {
+		output, err := suite.sut.Execute(context.Background(), CreateCustomerInputDTO{Name: " "})
+
+		suite.ErrorIs(err, ErrCreateCustomerInvalidName)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when fails to save customer", func() {
+		suite.repo.
+			EXPECT().
+			SaveCustomer(gomock.Any(), gomock.Any()).
+			Return(errors.New("[CustomerRepository] internal error"))
+
+		output, err := suite.sut.Execute(context.Background(), CreateCustomerInputDTO{Name: "John"})
+
+		suite.ErrorIs(err, ErrCreateCustomerFailToSaveCustomer)
+		suite.Nil(output)
+	})
+}
+
+func TestCreateCustomer(t *testing.T) {
+	suite.Run(t, new(TestCreateCustomerUseCaseSuite))
+}
@@ -0,0 +1,26 @@
+package customer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/shared/dto"
+	"slices"
+)
+
+func newCustomerID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func toCustomerDTO(customer *entity.Customer) dto.CustomerDTO {
+	return dto.CustomerDTO{
+		ID:         customer.ID,
+		Name:       customer.Name,
+		Email:      customer.Email,
+		Phone:      customer.Phone,
+		KYCStatus:  string(customer.KYCStatus),
+		AccountIDs: slices.Clone(customer.AccountIDs),
+	}
+}
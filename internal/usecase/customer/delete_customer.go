@@ -0,0 +1,42 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrDeleteCustomerCustomerNotExists    = errors.New("[DeleteCustomerUseCase] Customer not exists")
+	ErrDeleteCustomerFailToDeleteCustomer = errors.New("[DeleteCustomerUseCase] Fail to delete customer")
+)
+
+type DeleteCustomerInputDTO struct {
+	ID string
+}
+
+type DeleteCustomerUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewDeleteCustomerUseCase(customerRepository repository.CustomerRepository) *DeleteCustomerUseCase {
+	return &DeleteCustomerUseCase{customerRepository: customerRepository}
+}
+
+func (uc *DeleteCustomerUseCase) Execute(ctx context.Context, input DeleteCustomerInputDTO) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteCustomerUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	err = uc.customerRepository.DeleteCustomer(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return errors.Join(ErrDeleteCustomerCustomerNotExists, err)
+	}
+
+	if err != nil {
+		return errors.Join(ErrDeleteCustomerFailToDeleteCustomer, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestDeleteCustomerUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockCustomerRepository
+	sut  *DeleteCustomerUseCase
+}
+
+func (suite *TestDeleteCustomerUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.sut = NewDeleteCustomerUseCase(suite.repo)
+}
+
+func (suite *TestDeleteCustomerUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestDeleteCustomerUseCaseSuite) TestDeleteCustomer() {
+	suite.Run("Should delete customer", func() {
+		suite.repo.EXPECT().DeleteCustomer(gomock.Any(), "C1").Return(nil)
+
+		err := suite.sut.Execute(context.Background(), DeleteCustomerInputDTO{ID: "C1"})
+
+		suite.NoError(err)
+	})
+
+	suite.Run("Should return error when customer does not exist", func() {
+		suite.repo.EXPECT().DeleteCustomer(gomock.Any(), "C1").Return(domainErrs.ErrCustomerNotFound)
+
+		err := suite.sut.Execute(context.Background(), DeleteCustomerInputDTO{ID: "C1"})
+
+		suite.ErrorIs(err, ErrDeleteCustomerCustomerNotExists)
+	})
+
+	suite.Run("Should return error when fails to delete customer", func() {
+		suite.repo.
+			EXPECT().
+			DeleteCustomer(gomock.Any(), "C1").
+			Return(errors.New("[CustomerRepository] internal error"))
+
+		err := suite.sut.Execute(context.Background(), DeleteCustomerInputDTO{ID: "C1"})
+
+		suite.ErrorIs(err, ErrDeleteCustomerFailToDeleteCustomer)
+	})
+}
+
+func TestDeleteCustomer(t *testing.T) {
+	suite.Run(t, new(TestDeleteCustomerUseCaseSuite))
+}
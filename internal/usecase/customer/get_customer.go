@@ -0,0 +1,47 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrGetCustomerFailToRetrieveCustomer = errors.New("[GetCustomerUseCase] Fail to retrieve customer")
+	ErrGetCustomerCustomerNotExists      = errors.New("[GetCustomerUseCase] Customer not exists")
+)
+
+type GetCustomerInputDTO struct {
+	ID string
+}
+
+type GetCustomerOutputDTO struct {
+	Customer dto.CustomerDTO
+}
+
+type GetCustomerUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewGetCustomerUseCase(customerRepository repository.CustomerRepository) *GetCustomerUseCase {
+	return &GetCustomerUseCase{customerRepository: customerRepository}
+}
+
+func (uc *GetCustomerUseCase) Execute(ctx context.Context, input GetCustomerInputDTO) (output *GetCustomerOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "GetCustomerUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	customer, err := uc.customerRepository.GetCustomerByID(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return nil, errors.Join(ErrGetCustomerCustomerNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrGetCustomerFailToRetrieveCustomer, err)
+	}
+
+	return &GetCustomerOutputDTO{Customer: toCustomerDTO(customer)}, nil
+}
@@ -0,0 +1,70 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestGetCustomerUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockCustomerRepository
+	sut  *GetCustomerUseCase
+}
+
+func (suite *TestGetCustomerUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.sut = NewGetCustomerUseCase(suite.repo)
+}
+
+func (suite *TestGetCustomerUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestGetCustomerUseCaseSuite) TestGetCustomer() {
+	suite.Run("Should return customer when it exists", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("100")
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+
+		output, err := suite.sut.Execute(context.Background(), GetCustomerInputDTO{ID: "C1"})
+
+		suite.NoError(err)
+		suite.Equal("C1", output.Customer.ID)
+		suite.Equal([]string{"100"}, output.Customer.AccountIDs)
+	})
+
+	suite.Run("Should return error when customer does not exist", func() {
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(nil, domainErrs.ErrCustomerNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), GetCustomerInputDTO{ID: "C1"})
+
+		suite.ErrorIs(err, ErrGetCustomerCustomerNotExists)
+		suite.ErrorIs(err, domainErrs.ErrCustomerNotFound)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when fails to retrieve customer", func() {
+		suite.repo.
+			EXPECT().
+			GetCustomerByID(gomock.Any(), "C1").
+			Return(nil, errors.New("[CustomerRepository] internal error"))
+
+		output, err := suite.sut.Execute(context.Background(), GetCustomerInputDTO{ID: "C1"})
+
+		suite.ErrorIs(err, ErrGetCustomerFailToRetrieveCustomer)
+		suite.Nil(output)
+	})
+}
+
+func TestGetCustomer(t *testing.T) {
+	suite.Run(t, new(TestGetCustomerUseCaseSuite))
+}
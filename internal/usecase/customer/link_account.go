@@ -0,0 +1,72 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrLinkAccountFailToRetrieveCustomer = errors.New("[LinkAccountUseCase] Fail to retrieve customer")
+	ErrLinkAccountCustomerNotExists      = errors.New("[LinkAccountUseCase] Customer not exists")
+	ErrLinkAccountFailToRetrieveAccount  = errors.New("[LinkAccountUseCase] Fail to retrieve account")
+	ErrLinkAccountAccountNotExists       = errors.New("[LinkAccountUseCase] Account not exists")
+	ErrLinkAccountFailToUpdateCustomer   = errors.New("[LinkAccountUseCase] Fail to update customer")
+)
+
+type LinkAccountInputDTO struct {
+	CustomerID string
+	AccountID  string
+}
+
+type LinkAccountOutputDTO struct {
+	Customer dto.CustomerDTO
+}
+
+type LinkAccountUseCase struct {
+	customerRepository repository.CustomerRepository
+	accountRepository  repository.AccountRepository
+}
+
+func NewLinkAccountUseCase(
+	customerRepository repository.CustomerRepository,
+	accountRepository repository.AccountRepository,
+) *LinkAccountUseCase {
+	return &LinkAccountUseCase{
+		customerRepository: customerRepository,
+		accountRepository:  accountRepository,
+	}
+}
+
+func (uc *LinkAccountUseCase) Execute(ctx context.Context, input LinkAccountInputDTO) (output *LinkAccountOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "LinkAccountUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	customer, err := uc.customerRepository.GetCustomerByID(ctx, input.CustomerID)
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return nil, errors.Join(ErrLinkAccountCustomerNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrLinkAccountFailToRetrieveCustomer, err)
+	}
+
+	_, err = uc.accountRepository.GetAccountByID(ctx, input.AccountID)
+	if errors.Is(err, domainErrs.ErrAccountNotFound) {
+		return nil, errors.Join(ErrLinkAccountAccountNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrLinkAccountFailToRetrieveAccount, err)
+	}
+
+	customer.LinkAccount(input.AccountID)
+	if err = uc.customerRepository.UpdateCustomer(ctx, customer); err != nil {
+		return nil, errors.Join(ErrLinkAccountFailToUpdateCustomer, err)
+	}
+
+	return &LinkAccountOutputDTO{Customer: toCustomerDTO(customer)}, nil
+}
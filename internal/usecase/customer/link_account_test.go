@@ -0,0 +1,85 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestLinkAccountUseCaseSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	customerRepo *mocks.MockCustomerRepository
+	accountRepo  *mocks.MockAccountRepository
+	sut          *LinkAccountUseCase
+}
+
+func (suite *TestLinkAccountUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.customerRepo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.accountRepo = mocks.NewMockAccountRepository(suite.ctrl)
+	suite.sut = NewLinkAccountUseCase(suite.customerRepo, suite.accountRepo)
+}
+
+func (suite *TestLinkAccountUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestLinkAccountUseCaseSuite) TestLinkAccount() {
+	suite.Run("Should link account to customer", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.accountRepo.EXPECT().GetAccountByID(gomock.Any(), "100").Return(entity.NewAccount("100", 10), nil)
+		suite.customerRepo.EXPECT().UpdateCustomer(gomock.Any(), customer).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), LinkAccountInputDTO{CustomerID: "C1", AccountID: "100"})
+
+		suite.NoError(err)
+		suite.Equal([]string{"100"}, output.Customer.AccountIDs)
+	})
+
+	suite.Run("Should return error when customer does not exist", func() {
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(nil, domainErrs.ErrCustomerNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), LinkAccountInputDTO{CustomerID: "C1", AccountID: "100"})
+
+		suite.ErrorIs(err, ErrLinkAccountCustomerNotExists)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when account does not exist", func() {
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(entity.NewCustomer("C1", "John", "", ""), nil)
+		suite.accountRepo.EXPECT().GetAccountByID(gomock.Any(), "100").Return(nil, domainErrs.ErrAccountNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), LinkAccountInputDTO{CustomerID: "C1", AccountID: "100"})
+
+		suite.ErrorIs(err, ErrLinkAccountAccountNotExists)
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when fails to update customer", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.accountRepo.EXPECT().GetAccountByID(gomock.Any(), "100").Return(entity.NewAccount("100", 10), nil)
+		suite.customerRepo.
+			EXPECT().
+			UpdateCustomer(gomock.Any(), customer).
+			Return(errors.New("[CustomerRepository] internal error"))
+
+		output, err := suite.sut.Execute(context.Background(), LinkAccountInputDTO{CustomerID: "C1", AccountID: "100"})
+
+		suite.ErrorIs(err, ErrLinkAccountFailToUpdateCustomer)
+		suite.Nil(output)
+	})
+}
+
+func TestLinkAccount(t *testing.T) {
+	suite.Run(t, new(TestLinkAccountUseCaseSuite))
+}
@@ -0,0 +1,77 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrListCustomerAccountsFailToRetrieveCustomer = errors.New("[ListCustomerAccountsUseCase] Fail to retrieve customer")
+	ErrListCustomerAccountsCustomerNotExists      = errors.New("[ListCustomerAccountsUseCase] Customer not exists")
+	ErrListCustomerAccountsFailToRetrieveAccount  = errors.New("[ListCustomerAccountsUseCase] Fail to retrieve account")
+)
+
+type ListCustomerAccountsInputDTO struct {
+	CustomerID string
+}
+
+type ListCustomerAccountsOutputDTO struct {
+	Accounts []dto.AccountDetailsDTO
+}
+
+type ListCustomerAccountsUseCase struct {
+	customerRepository repository.CustomerRepository
+	accountRepository  repository.AccountRepository
+}
+
+func NewListCustomerAccountsUseCase(
+	customerRepository repository.CustomerRepository,
+	accountRepository repository.AccountRepository,
+) *ListCustomerAccountsUseCase {
+	return &ListCustomerAccountsUseCase{
+		customerRepository: customerRepository,
+		accountRepository:  accountRepository,
+	}
+}
+
+func (uc *ListCustomerAccountsUseCase) Execute(
+	ctx context.Context,
+	input ListCustomerAccountsInputDTO,
+) (output *ListCustomerAccountsOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListCustomerAccountsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	customer, err := uc.customerRepository.GetCustomerByID(ctx, input.CustomerID)
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return nil, errors.Join(ErrListCustomerAccountsCustomerNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrListCustomerAccountsFailToRetrieveCustomer, err)
+	}
+
+	output = &ListCustomerAccountsOutputDTO{Accounts: make([]dto.AccountDetailsDTO, 0, len(customer.AccountIDs))}
+	for _, accountID := range customer.AccountIDs {
+		account, err := uc.accountRepository.GetAccountByID(ctx, accountID)
+		if errors.Is(err, domainErrs.ErrAccountNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, errors.Join(ErrListCustomerAccountsFailToRetrieveAccount, err)
+		}
+
+		output.Accounts = append(output.Accounts, dto.AccountDetailsDTO{
+			ID:      account.ID,
+			Balance: account.Balance,
+			Status:  string(account.Status),
+			Product: account.ProductID,
+		})
+	}
+
+	return output, nil
+}
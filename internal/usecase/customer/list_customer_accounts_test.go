@@ -0,0 +1,78 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestListCustomerAccountsUseCaseSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	customerRepo *mocks.MockCustomerRepository
+	accountRepo  *mocks.MockAccountRepository
+	sut          *ListCustomerAccountsUseCase
+}
+
+func (suite *TestListCustomerAccountsUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.customerRepo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.accountRepo = mocks.NewMockAccountRepository(suite.ctrl)
+	suite.sut = NewListCustomerAccountsUseCase(suite.customerRepo, suite.accountRepo)
+}
+
+func (suite *TestListCustomerAccountsUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestListCustomerAccountsUseCaseSuite) TestListCustomerAccounts() {
+	suite.Run("Should list linked accounts skipping removed ones", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("100")
+		customer.LinkAccount("200")
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.accountRepo.EXPECT().GetAccountByID(gomock.Any(), "100").Return(entity.NewAccount("100", 10), nil)
+		suite.accountRepo.EXPECT().GetAccountByID(gomock.Any(), "200").Return(nil, domainErrs.ErrAccountNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), ListCustomerAccountsInputDTO{CustomerID: "C1"})
+
+		suite.NoError(err)
+		suite.Len(output.Accounts, 1)
+		suite.Equal("100", output.Accounts[0].ID)
+		suite.Equal(10, output.Accounts[0].Balance)
+	})
+
+	suite.Run("Should return error when customer does not exist", func() {
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(nil, domainErrs.ErrCustomerNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), ListCustomerAccountsInputDTO{CustomerID: "C1"})
+
+		suite.ErrorIs(err, ErrListCustomerAccountsCustomerNotExists)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when fails to retrieve account", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("100")
+		suite.customerRepo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.accountRepo.
+			EXPECT().
+			GetAccountByID(gomock.Any(), "100").
+			Return(nil, errors.New("[AccountRepository] internal error"))
+
+		output, err := suite.sut.Execute(context.Background(), ListCustomerAccountsInputDTO{CustomerID: "C1"})
+
+		suite.ErrorIs(err, ErrListCustomerAccountsFailToRetrieveAccount)
+		suite.Nil(output)
+	})
+}
+
+func TestListCustomerAccounts(t *testing.T) {
+	suite.Run(t, new(TestListCustomerAccountsUseCaseSuite))
+}
@@ -0,0 +1,68 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrSetKYCStatusInvalidStatus          = errors.New("[SetKYCStatusUseCase] Invalid KYC status")
+	ErrSetKYCStatusFailToRetrieveCustomer = errors.New("[SetKYCStatusUseCase] Fail to retrieve customer")
+	ErrSetKYCStatusCustomerNotExists      = errors.New("[SetKYCStatusUseCase] Customer not exists")
+	ErrSetKYCStatusFailToUpdateCustomer   = errors.New("[SetKYCStatusUseCase] Fail to update customer")
+)
+
+type SetKYCStatusInputDTO struct {
+	ID        string
+	KYCStatus string
+}
+
+type SetKYCStatusOutputDTO struct {
+	Customer dto.CustomerDTO
+}
+
+// SetKYCStatusUseCase records the outcome of the identity verification of a
+// customer, which customers must not set themselves.
+type SetKYCStatusUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewSetKYCStatusUseCase(customerRepository repository.CustomerRepository) *SetKYCStatusUseCase {
+	return &SetKYCStatusUseCase{customerRepository: customerRepository}
+}
+
+func (uc *SetKYCStatusUseCase) Execute(
+	ctx context.Context,
+	input SetKYCStatusInputDTO,
+) (output *SetKYCStatusOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "SetKYCStatusUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	kycStatus := entity.KYCStatus(input.KYCStatus)
+	switch kycStatus {
+	case entity.KYCStatusPending, entity.KYCStatusVerified, entity.KYCStatusRejected:
+	default:
+		return nil, ErrSetKYCStatusInvalidStatus
+	}
+
+	customer, err := uc.customerRepository.GetCustomerByID(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return nil, errors.Join(ErrSetKYCStatusCustomerNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrSetKYCStatusFailToRetrieveCustomer, err)
+	}
+
+	customer.KYCStatus = kycStatus
+	if err = uc.customerRepository.UpdateCustomer(ctx, customer); err != nil {
+		return nil, errors.Join(ErrSetKYCStatusFailToUpdateCustomer, err)
+	}
+
+	return &SetKYCStatusOutputDTO{Customer: toCustomerDTO(customer)}, nil
+}
@@ -0,0 +1,81 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestSetKYCStatusUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockCustomerRepository
+	sut  *SetKYCStatusUseCase
+}
+
+func (suite *TestSetKYCStatusUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.sut = NewSetKYCStatusUseCase(suite.repo)
+}
+
+func (suite *TestSetKYCStatusUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestSetKYCStatusUseCaseSuite) TestSetKYCStatus() {
+	suite.Run("Should set the KYC status", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.repo.EXPECT().UpdateCustomer(gomock.Any(), customer).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), SetKYCStatusInputDTO{ID: "C1", KYCStatus: "verified"})
+
+		suite.NoError(err)
+		suite.Equal("verified", output.Customer.KYCStatus)
+		suite.Equal("John", output.Customer.Name)
+	})
+
+	suite.Run("Should return error when KYC status is invalid", func() {
+		for _, status := range []string{"", "approved"} {
+			output, err := suite.sut.Execute(context.Background(), SetKYCStatusInputDTO{ID: "C1", KYCStatus: status})
+
+			suite.ErrorIs(err, ErrSetKYCStatusInvalidStatus)
+			suite.Nil(output)
+		}
+	})
+
+	suite.Run("Should return error when customer does not exist", func() {
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(nil, domainErrs.ErrCustomerNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), SetKYCStatusInputDTO{ID: "C1", KYCStatus: "verified"})
+
+		suite.ErrorIs(err, ErrSetKYCStatusCustomerNotExists)
+		suite.ErrorIs(err, domainErrs.ErrCustomerNotFound)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when fails to update customer", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.repo.
+			EXPECT().
+			UpdateCustomer(gomock.Any(), customer).
+			Return(errors.New("[CustomerRepository] internal error"))
+
+		output, err := suite.sut.Execute(context.Background(), SetKYCStatusInputDTO{ID: "C1", KYCStatus: "verified"})
+
+		suite.ErrorIs(err, ErrSetKYCStatusFailToUpdateCustomer)
+		suite.Nil(output)
+	})
+}
+
+func TestSetKYCStatus(t *testing.T) {
+	suite.Run(t, new(TestSetKYCStatusUseCaseSuite))
+}
@@ -0,0 +1,54 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrUnlinkAccountFailToRetrieveCustomer = errors.New("[UnlinkAccountUseCase] Fail to retrieve customer")
+	ErrUnlinkAccountCustomerNotExists      = errors.New("[UnlinkAccountUseCase] Customer not exists")
+	ErrUnlinkAccountAccountNotLinked       = errors.New("[UnlinkAccountUseCase] Account not linked to customer")
+	ErrUnlinkAccountFailToUpdateCustomer   = errors.New("[UnlinkAccountUseCase] Fail to update customer")
+)
+
+type UnlinkAccountInputDTO struct {
+	CustomerID string
+	AccountID  string
+}
+
+type UnlinkAccountUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewUnlinkAccountUseCase(customerRepository repository.CustomerRepository) *UnlinkAccountUseCase {
+	return &UnlinkAccountUseCase{customerRepository: customerRepository}
+}
+
+func (uc *UnlinkAccountUseCase) Execute(ctx context.Context, input UnlinkAccountInputDTO) (err error) {
+	ctx, span := tracing.Start(ctx, "UnlinkAccountUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	customer, err := uc.customerRepository.GetCustomerByID(ctx, input.CustomerID)
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return errors.Join(ErrUnlinkAccountCustomerNotExists, err)
+	}
+
+	if err != nil {
+		return errors.Join(ErrUnlinkAccountFailToRetrieveCustomer, err)
+	}
+
+	if !customer.OwnsAccount(input.AccountID) {
+		return errors.Join(ErrUnlinkAccountAccountNotLinked, domainErrs.ErrAccountNotOwned)
+	}
+
+	customer.UnlinkAccount(input.AccountID)
+	if err = uc.customerRepository.UpdateCustomer(ctx, customer); err != nil {
+		return errors.Join(ErrUnlinkAccountFailToUpdateCustomer, err)
+	}
+
+	return nil
+}
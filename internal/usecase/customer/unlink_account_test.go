@@ -0,0 +1,79 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestUnlinkAccountUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockCustomerRepository
+	sut  *UnlinkAccountUseCase
+}
+
+func (suite *TestUnlinkAccountUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.sut = NewUnlinkAccountUseCase(suite.repo)
+}
+
+func (suite *TestUnlinkAccountUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestUnlinkAccountUseCaseSuite) TestUnlinkAccount() {
+	suite.Run("Should unlink account from customer", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("100")
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.repo.EXPECT().UpdateCustomer(gomock.Any(), customer).Return(nil)
+
+		err := suite.sut.Execute(context.Background(), UnlinkAccountInputDTO{CustomerID: "C1", AccountID: "100"})
+
+		suite.NoError(err)
+		suite.Empty(customer.AccountIDs)
+	})
+
+	suite.Run("Should return error when account is not linked", func() {
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(entity.NewCustomer("C1", "John", "", ""), nil)
+
+		err := suite.sut.Execute(context.Background(), UnlinkAccountInputDTO{CustomerID: "C1", AccountID: "100"})
+
+		suite.ErrorIs(err, ErrUnlinkAccountAccountNotLinked)
+		suite.ErrorIs(err, domainErrs.ErrAccountNotOwned)
+	})
+
+	suite.Run("Should return error when customer does not exist", func() {
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(nil, domainErrs.ErrCustomerNotFound)
+
+		err := suite.sut.Execute(context.Background(), UnlinkAccountInputDTO{CustomerID: "C1", AccountID: "100"})
+
+		suite.ErrorIs(err, ErrUnlinkAccountCustomerNotExists)
+	})
+
+	suite.Run("Should return error when fails to update customer", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("100")
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.repo.
+			EXPECT().
+			UpdateCustomer(gomock.Any(), customer).
+			Return(errors.New("[CustomerRepository] internal error"))
+
+		err := suite.sut.Execute(context.Background(), UnlinkAccountInputDTO{CustomerID: "C1", AccountID: "100"})
+
+		suite.ErrorIs(err, ErrUnlinkAccountFailToUpdateCustomer)
+	})
+}
+
+func TestUnlinkAccount(t *testing.T) {
+	suite.Run(t, new(TestUnlinkAccountUseCaseSuite))
+}
@@ -0,0 +1,68 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+	"strings"
+)
+
+var (
+	ErrUpdateCustomerInvalidName            = errors.New("[UpdateCustomerUseCase] Invalid name")
+	ErrUpdateCustomerFailToRetrieveCustomer = errors.New("[UpdateCustomerUseCase] Fail to retrieve customer")
+	ErrUpdateCustomerCustomerNotExists      = errors.New("[UpdateCustomerUseCase] Customer not exists")
+	ErrUpdateCustomerFailToUpdateCustomer   = errors.New("[UpdateCustomerUseCase] Fail to update customer")
+)
+
+type UpdateCustomerInputDTO struct {
+	ID    string
+	Name  string
+	Email string
+	Phone string
+}
+
+type UpdateCustomerOutputDTO struct {
+	Customer dto.CustomerDTO
+}
+
+type UpdateCustomerUseCase struct {
+	customerRepository repository.CustomerRepository
+}
+
+func NewUpdateCustomerUseCase(customerRepository repository.CustomerRepository) *UpdateCustomerUseCase {
+	return &UpdateCustomerUseCase{customerRepository: customerRepository}
+}
+
+func (uc *UpdateCustomerUseCase) Execute(
+	ctx context.Context,
+	input UpdateCustomerInputDTO,
+) (output *UpdateCustomerOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateCustomerUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, ErrUpdateCustomerInvalidName
+	}
+
+	customer, err := uc.customerRepository.GetCustomerByID(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrCustomerNotFound) {
+		return nil, errors.Join(ErrUpdateCustomerCustomerNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrUpdateCustomerFailToRetrieveCustomer, err)
+	}
+
+	customer.Name = input.Name
+	customer.Email = input.Email
+	customer.Phone = input.Phone
+
+	if err = uc.customerRepository.UpdateCustomer(ctx, customer); err != nil {
+		return nil, errors.Join(ErrUpdateCustomerFailToUpdateCustomer, err)
+	}
+
+	return &UpdateCustomerOutputDTO{Customer: toCustomerDTO(customer)}, nil
+}
@@ -0,0 +1,94 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestUpdateCustomerUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockCustomerRepository
+	sut  *UpdateCustomerUseCase
+}
+
+func (suite *TestUpdateCustomerUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockCustomerRepository(suite.ctrl)
+	suite.sut = NewUpdateCustomerUseCase(suite.repo)
+}
+
+func (suite *TestUpdateCustomerUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestUpdateCustomerUseCaseSuite) TestUpdateCustomer() {
+	suite.Run("Should update customer details", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.repo.EXPECT().UpdateCustomer(gomock.Any(), customer).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), UpdateCustomerInputDTO{
+			ID:    "C1",
+			Name:  "John Doe",
+			Email: "john@example.com",
+		})
+
+		suite.NoError(err)
+		suite.Equal("John Doe", output.Customer.Name)
+		suite.Equal("john@example.com", output.Customer.Email)
+	})
+
+	suite.Run("Should keep KYC status", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.KYCStatus = entity.KYCStatusVerified
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.repo.EXPECT().UpdateCustomer(gomock.Any(), customer).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), UpdateCustomerInputDTO{ID: "C1", Name: "John"})
+
+		suite.NoError(err)
+		suite.Equal("verified", output.Customer.KYCStatus)
+	})
+
+	suite.Run("Should return error when name is blank", func() {
+		output, err := suite.sut.Execute(context.Background(), UpdateCustomerInputDTO{ID: "C1"})
+
+		suite.ErrorIs(err, ErrUpdateCustomerInvalidName)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when customer does not exist", func() {
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(nil, domainErrs.ErrCustomerNotFound)
+
+		output, err := suite.sut.Execute(context.Background(), UpdateCustomerInputDTO{ID: "C1", Name: "John"})
+
+		suite.ErrorIs(err, ErrUpdateCustomerCustomerNotExists)
+		suite.Nil(output)
+	})
+
+	suite.Run("Should return error when fails to update customer", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		suite.repo.EXPECT().GetCustomerByID(gomock.Any(), "C1").Return(customer, nil)
+		suite.repo.
+			EXPECT().
+			UpdateCustomer(gomock.Any(), customer).
+			Return(errors.New("[CustomerRepository] internal error"))
+
+		output, err := suite.sut.Execute(context.Background(), UpdateCustomerInputDTO{ID: "C1", Name: "John"})
+
+		suite.ErrorIs(err, ErrUpdateCustomerFailToUpdateCustomer)
+		suite.Nil(output)
+	})
+}
+
+func TestUpdateCustomer(t *testing.T) {
+	suite.Run(t, new(TestUpdateCustomerUseCaseSuite))
+}
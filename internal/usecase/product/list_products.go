@@ -0,0 +1,50 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var ErrListProductsFailToListProducts = errors.New("[ListProductsUseCase] Fail to list products")
+
+type ListProductsOutputDTO struct {
+	Products []dto.ProductDTO
+}
+
+type ListProductsUseCase struct {
+	productRepository repository.ProductRepository
+}
+
+func NewListProductsUseCase(productRepository repository.ProductRepository) *ListProductsUseCase {
+	return &ListProductsUseCase{productRepository: productRepository}
+}
+
+func (uc *ListProductsUseCase) Execute(ctx context.Context) (output *ListProductsOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListProductsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	products, err := uc.productRepository.ListProducts(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrListProductsFailToListProducts, err)
+	}
+
+	output = &ListProductsOutputDTO{Products: make([]dto.ProductDTO, 0, len(products))}
+	for _, product := range products {
+		output.Products = append(output.Products, dto.ProductDTO{
+			ID:                     product.ID,
+			Name:                   product.Name,
+			OverdraftLimit:         product.OverdraftLimit,
+			MonthlyWithdrawalLimit: product.MonthlyWithdrawalLimit,
+			Fees: dto.FeeScheduleDTO{
+				Deposit:    product.Fees.Deposit,
+				Withdrawal: product.Fees.Withdrawal,
+				Transfer:   product.Fees.Transfer,
+			},
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,59 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestListProductsUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockProductRepository
+	sut  *ListProductsUseCase
+}
+
+func (suite *TestListProductsUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockProductRepository(suite.ctrl)
+	suite.sut = NewListProductsUseCase(suite.repo)
+}
+
+func (suite *TestListProductsUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestListProductsUseCaseSuite) TestListProducts() {
+	suite.Run("Should list products", func() {
+		business := entity.NewProduct("business", "Business")
+		business.OverdraftLimit = 500
+		business.Fees.Transfer = 3
+		suite.repo.EXPECT().ListProducts(gomock.Any()).Return([]*entity.Product{business}, nil)
+
+		output, err := suite.sut.Execute(context.Background())
+
+		suite.NoError(err)
+		suite.Len(output.Products, 1)
+		suite.Equal("business", output.Products[0].ID)
+		suite.Equal(500, output.Products[0].OverdraftLimit)
+		suite.Equal(3, output.Products[0].Fees.Transfer)
+	})
+
+	suite.Run("Should return error when fails to list products", func() {
+		suite.repo.EXPECT().ListProducts(gomock.Any()).Return(nil, errors.New("[ProductRepository] internal error"))
+
+		output, err := suite.sut.Execute(context.Background())
+
+		suite.ErrorIs(err, ErrListProductsFailToListProducts)
+		suite.Nil(output)
+	})
+}
+
+func TestListProducts(t *testing.T) {
+	suite.Run(t, new(TestListProductsUseCaseSuite))
+}
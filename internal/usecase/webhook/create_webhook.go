@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/egress"
+	"simple-bank/internal/shared/tracing"
+	"time"
+)
+
+// minSecretLength keeps secrets long enough to resist guessing the signature
+// key.
+const minSecretLength = 16
+
+var (
+	ErrCreateWebhookInvalidURL       = errors.New("[CreateWebhookUseCase] Invalid URL")
+	ErrCreateWebhookInvalidEventType = errors.New("[CreateWebhookUseCase] Invalid event type")
+	ErrCreateWebhookInvalidSecret    = errors.New("[CreateWebhookUseCase] Invalid secret")
+	ErrCreateWebhookFailToSave       = errors.New("[CreateWebhookUseCase] Fail to save webhook")
+)
+
+type CreateWebhookInputDTO struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+type CreateWebhookOutputDTO struct {
+	Webhook dto.WebhookDTO
+}
+
+type CreateWebhookUseCase struct {
+	webhookRepository repository.WebhookRepository
+	targets           egress.Policy
+	now               func() time.Time
+}
+
+func NewCreateWebhookUseCase(webhookRepository repository.WebhookRepository) *CreateWebhookUseCase {
+	return &CreateWebhookUseCase{
+		webhookRepository: webhookRepository,
+		now:               time.Now,
+	}
+}
+
+// WithTargetPolicy replaces the policy refusing the URLs on the bank network,
+// the dispatcher checks them again when delivering.
+func (uc *CreateWebhookUseCase) WithTargetPolicy(policy egress.Policy) *CreateWebhookUseCase {
+	uc.targets = policy
+	return uc
+}
+
+func (uc *CreateWebhookUseCase) Execute(ctx context.Context, input CreateWebhookInputDTO) (output *CreateWebhookOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "CreateWebhookUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	target, err := url.Parse(input.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, ErrCreateWebhookInvalidURL
+	}
+
+	if err := uc.targets.CheckHost(ctx, target.Hostname()); err != nil {
+		return nil, errors.Join(ErrCreateWebhookInvalidURL, err)
+	}
+
+	if len(input.EventTypes) == 0 {
+		return nil, ErrCreateWebhookInvalidEventType
+	}
+
+	eventTypes := make([]event.Type, 0, len(input.EventTypes))
+	for _, value := range input.EventTypes {
+		eventType := event.Type(value)
+		if !eventType.IsValid() {
+			return nil, ErrCreateWebhookInvalidEventType
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	if len(input.Secret) < minSecretLength {
+		return nil, ErrCreateWebhookInvalidSecret
+	}
+
+	webhook := entity.NewWebhook(newWebhookID(), target.String(), eventTypes, input.Secret, uc.now().UTC())
+	if err := uc.webhookRepository.SaveWebhook(ctx, webhook); err != nil {
+		return nil, errors.Join(ErrCreateWebhookFailToSave, err)
+	}
+
+	return &CreateWebhookOutputDTO{Webhook: toWebhookDTO(webhook)}, nil
+}
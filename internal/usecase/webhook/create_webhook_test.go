@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository/mocks"
+	"simple-bank/internal/shared/egress"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestCreateWebhookUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockWebhookRepository
+	sut  *CreateWebhookUseCase
+}
+
+func (suite *TestCreateWebhookUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockWebhookRepository(suite.ctrl)
+	suite.sut = NewCreateWebhookUseCase(suite.repo).WithTargetPolicy(egress.Policy{Resolver: resolverStub{
+		"example.com":  {netip.MustParseAddr("93.184.215.14")},
+		"internal.com": {netip.MustParseAddr("10.0.0.1")},
+	}})
+}
+
+type resolverStub map[string][]netip.Addr
+
+func (r resolverStub) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func (suite *TestCreateWebhookUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestCreateWebhookUseCaseSuite) TestCreateWebhook() {
+	input := CreateWebhookInputDTO{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"funds.deposited", "transfer.completed"},
+		Secret:     "0123456789abcdef",
+	}
+
+	suite.Run("Should save the webhook", func() {
+		var saved *entity.Webhook
+		suite.repo.EXPECT().SaveWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, webhook *entity.Webhook) error {
+				saved = webhook
+				return nil
+			},
+		)
+
+		output, err := suite.sut.Execute(context.Background(), input)
+
+		suite.NoError(err)
+		suite.Require().NotNil(saved)
+		suite.Equal(saved.ID, output.Webhook.ID)
+		suite.Equal("https://example.com/hooks", output.Webhook.URL)
+		suite.Equal([]string{"funds.deposited", "transfer.completed"}, output.Webhook.EventTypes)
+		suite.Equal([]event.Type{event.TypeFundsDeposited, event.TypeTransferCompleted}, saved.EventTypes)
+		suite.Equal("0123456789abcdef", saved.Secret)
+	})
+
+	suite.Run("Should reject invalid webhooks", func() {
+		cases := []struct {
+			mutate func(*CreateWebhookInputDTO)
+			err    error
+		}{
+			{func(i *CreateWebhookInputDTO) { i.URL = "ftp://example.com" }, ErrCreateWebhookInvalidURL},
+			{func(i *CreateWebhookInputDTO) { i.URL = "/hooks" }, ErrCreateWebhookInvalidURL},
+			{func(i *CreateWebhookInputDTO) { i.URL = "http://127.0.0.1:8080/hooks" }, egress.ErrForbiddenAddress},
+			{func(i *CreateWebhookInputDTO) { i.URL = "http://[::1]/hooks" }, egress.ErrForbiddenAddress},
+			{func(i *CreateWebhookInputDTO) { i.URL = "http://169.254.169.254/latest" }, egress.ErrForbiddenAddress},
+			{func(i *CreateWebhookInputDTO) { i.URL = "https://internal.com/hooks" }, ErrCreateWebhookInvalidURL},
+			{func(i *CreateWebhookInputDTO) { i.EventTypes = nil }, ErrCreateWebhookInvalidEventType},
+			{func(i *CreateWebhookInputDTO) { i.EventTypes = []string{"funds.lost"} }, ErrCreateWebhookInvalidEventType},
+			{func(i *CreateWebhookInputDTO) { i.Secret = "short" }, ErrCreateWebhookInvalidSecret},
+		}
+
+		for _, c := range cases {
+			invalid := input
+			c.mutate(&invalid)
+
+			_, err := suite.sut.Execute(context.Background(), invalid)
+
+			suite.ErrorIs(err, c.err)
+		}
+	})
+
+	suite.Run("Should return error when fails to save webhook", func() {
+		suite.repo.EXPECT().SaveWebhook(gomock.Any(), gomock.Any()).Return(errors.New("[WebhookRepository] internal error"))
+
+		_, err := suite.sut.Execute(context.Background(), input)
+
+		suite.ErrorIs(err, ErrCreateWebhookFailToSave)
+	})
+}
+
+func TestCreateWebhookUseCase(t *testing.T) {
+	suite.Run(t, new(TestCreateWebhookUseCaseSuite))
+}
@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrDeleteWebhookNotExists    = errors.New("[DeleteWebhookUseCase] Webhook not exists")
+	ErrDeleteWebhookFailToDelete = errors.New("[DeleteWebhookUseCase] Fail to delete webhook")
+)
+
+type DeleteWebhookInputDTO struct {
+	ID string
+}
+
+type DeleteWebhookUseCase struct {
+	webhookRepository repository.WebhookRepository
+}
+
+func NewDeleteWebhookUseCase(webhookRepository repository.WebhookRepository) *DeleteWebhookUseCase {
+	return &DeleteWebhookUseCase{webhookRepository: webhookRepository}
+}
+
+func (uc *DeleteWebhookUseCase) Execute(ctx context.Context, input DeleteWebhookInputDTO) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteWebhookUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	err = uc.webhookRepository.DeleteWebhook(ctx, input.ID)
+	if errors.Is(err, domainErrs.ErrWebhookNotFound) {
+		return errors.Join(ErrDeleteWebhookNotExists, err)
+	}
+
+	if err != nil {
+		return errors.Join(ErrDeleteWebhookFailToDelete, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestDeleteWebhookUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockWebhookRepository
+	sut  *DeleteWebhookUseCase
+}
+
+func (suite *TestDeleteWebhookUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockWebhookRepository(suite.ctrl)
+	suite.sut = NewDeleteWebhookUseCase(suite.repo)
+}
+
+func (suite *TestDeleteWebhookUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestDeleteWebhookUseCaseSuite) TestDeleteWebhook() {
+	suite.Run("Should delete webhook", func() {
+		suite.repo.EXPECT().DeleteWebhook(gomock.Any(), "W1").Return(nil)
+
+		err := suite.sut.Execute(context.Background(), DeleteWebhookInputDTO{ID: "W1"})
+
+		suite.NoError(err)
+	})
+
+	suite.Run("Should return error when webhook does not exist", func() {
+		suite.repo.EXPECT().DeleteWebhook(gomock.Any(), "W1").Return(domainErrs.ErrWebhookNotFound)
+
+		err := suite.sut.Execute(context.Background(), DeleteWebhookInputDTO{ID: "W1"})
+
+		suite.ErrorIs(err, ErrDeleteWebhookNotExists)
+		suite.ErrorIs(err, domainErrs.ErrWebhookNotFound)
+	})
+
+	suite.Run("Should return error when fails to delete webhook", func() {
+		suite.repo.EXPECT().DeleteWebhook(gomock.Any(), "W1").Return(errors.New("[WebhookRepository] internal error"))
+
+		err := suite.sut.Execute(context.Background(), DeleteWebhookInputDTO{ID: "W1"})
+
+		suite.ErrorIs(err, ErrDeleteWebhookFailToDelete)
+	})
+}
+
+func TestDeleteWebhookUseCase(t *testing.T) {
+	suite.Run(t, new(TestDeleteWebhookUseCaseSuite))
+}
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var (
+	ErrListDeliveriesInvalidStatus  = errors.New("[ListDeliveriesUseCase] Invalid status")
+	ErrListDeliveriesFailToRetrieve = errors.New("[ListDeliveriesUseCase] Fail to retrieve deliveries")
+)
+
+type ListDeliveriesInputDTO struct {
+	// Status filters the deliveries, "dead" lists the dead letters.
+	Status string
+}
+
+type ListDeliveriesOutputDTO struct {
+	Deliveries []dto.WebhookDeliveryDTO
+}
+
+type ListDeliveriesUseCase struct {
+	webhookRepository repository.WebhookRepository
+}
+
+func NewListDeliveriesUseCase(webhookRepository repository.WebhookRepository) *ListDeliveriesUseCase {
+	return &ListDeliveriesUseCase{webhookRepository: webhookRepository}
+}
+
+func (uc *ListDeliveriesUseCase) Execute(ctx context.Context, input ListDeliveriesInputDTO) (output *ListDeliveriesOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListDeliveriesUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	status := entity.DeliveryStatus(input.Status)
+	if status != "" && !status.IsValid() {
+		return nil, ErrListDeliveriesInvalidStatus
+	}
+
+	deliveries, err := uc.webhookRepository.ListDeliveries(ctx, status)
+	if err != nil {
+		return nil, errors.Join(ErrListDeliveriesFailToRetrieve, err)
+	}
+
+	output = &ListDeliveriesOutputDTO{Deliveries: make([]dto.WebhookDeliveryDTO, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		output.Deliveries = append(output.Deliveries, toWebhookDeliveryDTO(delivery))
+	}
+	return output, nil
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestListDeliveriesUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockWebhookRepository
+	sut  *ListDeliveriesUseCase
+}
+
+func (suite *TestListDeliveriesUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockWebhookRepository(suite.ctrl)
+	suite.sut = NewListDeliveriesUseCase(suite.repo)
+}
+
+func (suite *TestListDeliveriesUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func newDeadDelivery() *entity.WebhookDelivery {
+	now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	webhook := entity.NewWebhook("W1", "https://example.com", []event.Type{event.TypeFundsDeposited}, "secret", now)
+	e := event.New("E1", now, event.FundsDeposited{Account: "100", Amount: 10, Balance: 10})
+	delivery := entity.NewWebhookDelivery("D1", webhook, e, []byte("{}"), now)
+	delivery.Fail("503 Service Unavailable", now, nil)
+	return delivery
+}
+
+func (suite *TestListDeliveriesUseCaseSuite) TestListDeliveries() {
+	suite.Run("Should list the dead letters", func() {
+		suite.repo.EXPECT().
+			ListDeliveries(gomock.Any(), entity.DeliveryStatusDead).
+			Return([]*entity.WebhookDelivery{newDeadDelivery()}, nil)
+
+		output, err := suite.sut.Execute(context.Background(), ListDeliveriesInputDTO{Status: "dead"})
+
+		suite.NoError(err)
+		suite.Require().Len(output.Deliveries, 1)
+		suite.Equal("D1", output.Deliveries[0].ID)
+		suite.Equal("E1", output.Deliveries[0].EventID)
+		suite.Equal("dead", output.Deliveries[0].Status)
+		suite.Equal("503 Service Unavailable", output.Deliveries[0].LastError)
+	})
+
+	suite.Run("Should return error when status is invalid", func() {
+		_, err := suite.sut.Execute(context.Background(), ListDeliveriesInputDTO{Status: "lost"})
+
+		suite.ErrorIs(err, ErrListDeliveriesInvalidStatus)
+	})
+
+	suite.Run("Should return error when fails to retrieve deliveries", func() {
+		suite.repo.EXPECT().ListDeliveries(gomock.Any(), entity.DeliveryStatus("")).Return(nil, errors.New("[WebhookRepository] internal error"))
+
+		_, err := suite.sut.Execute(context.Background(), ListDeliveriesInputDTO{})
+
+		suite.ErrorIs(err, ErrListDeliveriesFailToRetrieve)
+	})
+}
+
+func TestListDeliveriesUseCase(t *testing.T) {
+	suite.Run(t, new(TestListDeliveriesUseCaseSuite))
+}
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+)
+
+var ErrListWebhooksFailToRetrieve = errors.New("[ListWebhooksUseCase] Fail to retrieve webhooks")
+
+type ListWebhooksOutputDTO struct {
+	Webhooks []dto.WebhookDTO
+}
+
+type ListWebhooksUseCase struct {
+	webhookRepository repository.WebhookRepository
+}
+
+func NewListWebhooksUseCase(webhookRepository repository.WebhookRepository) *ListWebhooksUseCase {
+	return &ListWebhooksUseCase{webhookRepository: webhookRepository}
+}
+
+func (uc *ListWebhooksUseCase) Execute(ctx context.Context) (output *ListWebhooksOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListWebhooksUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	webhooks, err := uc.webhookRepository.ListWebhooks(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrListWebhooksFailToRetrieve, err)
+	}
+
+	output = &ListWebhooksOutputDTO{Webhooks: make([]dto.WebhookDTO, 0, len(webhooks))}
+	for _, webhook := range webhooks {
+		output.Webhooks = append(output.Webhooks, toWebhookDTO(webhook))
+	}
+	return output, nil
+}
@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository/mocks"
+	"simple-bank/internal/shared/dto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestListWebhooksUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockWebhookRepository
+	sut  *ListWebhooksUseCase
+}
+
+func (suite *TestListWebhooksUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockWebhookRepository(suite.ctrl)
+	suite.sut = NewListWebhooksUseCase(suite.repo)
+}
+
+func (suite *TestListWebhooksUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestListWebhooksUseCaseSuite) TestListWebhooks() {
+	suite.Run("Should list webhooks without their secret", func() {
+		createdAt := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+		webhook := entity.NewWebhook("W1", "https://example.com", []event.Type{event.TypeFundsWithdrawn}, "secret", createdAt)
+		suite.repo.EXPECT().ListWebhooks(gomock.Any()).Return([]*entity.Webhook{webhook}, nil)
+
+		output, err := suite.sut.Execute(context.Background())
+
+		suite.NoError(err)
+		suite.Equal([]dto.WebhookDTO{{
+			ID:         "W1",
+			URL:        "https://example.com",
+			EventTypes: []string{"funds.withdrawn"},
+			CreatedAt:  createdAt,
+		}}, output.Webhooks)
+	})
+
+	suite.Run("Should return error when fails to retrieve webhooks", func() {
+		suite.repo.EXPECT().ListWebhooks(gomock.Any()).Return(nil, errors.New("[WebhookRepository] internal error"))
+
+		_, err := suite.sut.Execute(context.Background())
+
+		suite.ErrorIs(err, ErrListWebhooksFailToRetrieve)
+	})
+}
+
+func TestListWebhooksUseCase(t *testing.T) {
+	suite.Run(t, new(TestListWebhooksUseCaseSuite))
+}
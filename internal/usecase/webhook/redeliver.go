@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/internal/shared/tracing"
+	"time"
+)
+
+var (
+	ErrRedeliverDeliveryNotExists      = errors.New("[RedeliverUseCase] Delivery not exists")
+	ErrRedeliverFailToRetrieveDelivery = errors.New("[RedeliverUseCase] Fail to retrieve delivery")
+	ErrRedeliverFailToUpdateDelivery   = errors.New("[RedeliverUseCase] Fail to update delivery")
+)
+
+type RedeliverInputDTO struct {
+	DeliveryID string
+}
+
+type RedeliverOutputDTO struct {
+	Delivery dto.WebhookDeliveryDTO
+}
+
+// RedeliverUseCase queues a delivery again, typically a dead letter once the
+// receiver is fixed.
+type RedeliverUseCase struct {
+	webhookRepository repository.WebhookRepository
+	now               func() time.Time
+}
+
+func NewRedeliverUseCase(webhookRepository repository.WebhookRepository) *RedeliverUseCase {
+	return &RedeliverUseCase{
+		webhookRepository: webhookRepository,
+		now:               time.Now,
+	}
+}
+
+func (uc *RedeliverUseCase) Execute(ctx context.Context, input RedeliverInputDTO) (output *RedeliverOutputDTO, err error) {
+	ctx, span := tracing.Start(ctx, "RedeliverUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	delivery, err := uc.webhookRepository.GetDeliveryByID(ctx, input.DeliveryID)
+	if errors.Is(err, domainErrs.ErrWebhookDeliveryNotFound) {
+		return nil, errors.Join(ErrRedeliverDeliveryNotExists, err)
+	}
+
+	if err != nil {
+		return nil, errors.Join(ErrRedeliverFailToRetrieveDelivery, err)
+	}
+
+	delivery.Redeliver(uc.now().UTC())
+	if err = uc.webhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, errors.Join(ErrRedeliverFailToUpdateDelivery, err)
+	}
+
+	return &RedeliverOutputDTO{Delivery: toWebhookDeliveryDTO(delivery)}, nil
+}
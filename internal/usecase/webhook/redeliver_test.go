@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestRedeliverUseCaseSuite struct {
+	suite.Suite
+	ctrl *gomock.Controller
+	repo *mocks.MockWebhookRepository
+	sut  *RedeliverUseCase
+}
+
+func (suite *TestRedeliverUseCaseSuite) SetupSubTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.repo = mocks.NewMockWebhookRepository(suite.ctrl)
+	suite.sut = NewRedeliverUseCase(suite.repo)
+}
+
+func (suite *TestRedeliverUseCaseSuite) TearDownSubTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TestRedeliverUseCaseSuite) TestRedeliver() {
+	suite.Run("Should queue a dead letter again", func() {
+		delivery := newDeadDelivery()
+		suite.repo.EXPECT().GetDeliveryByID(gomock.Any(), "D1").Return(delivery, nil)
+		suite.repo.EXPECT().UpdateDelivery(gomock.Any(), delivery).Return(nil)
+
+		output, err := suite.sut.Execute(context.Background(), RedeliverInputDTO{DeliveryID: "D1"})
+
+		suite.NoError(err)
+		suite.Equal(string(entity.DeliveryStatusPending), output.Delivery.Status)
+		suite.Equal(0, output.Delivery.Attempts)
+	})
+
+	suite.Run("Should return error when delivery does not exist", func() {
+		suite.repo.EXPECT().GetDeliveryByID(gomock.Any(), "D1").Return(nil, domainErrs.ErrWebhookDeliveryNotFound)
+
+		_, err := suite.sut.Execute(context.Background(), RedeliverInputDTO{DeliveryID: "D1"})
+
+		suite.ErrorIs(err, ErrRedeliverDeliveryNotExists)
+	})
+
+	suite.Run("Should return error when fails to update delivery", func() {
+		suite.repo.EXPECT().GetDeliveryByID(gomock.Any(), "D1").Return(newDeadDelivery(), nil)
+		suite.repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Return(errors.New("[WebhookRepository] internal error"))
+
+		_, err := suite.sut.Execute(context.Background(), RedeliverInputDTO{DeliveryID: "D1"})
+
+		suite.ErrorIs(err, ErrRedeliverFailToUpdateDelivery)
+	})
+}
+
+func TestRedeliverUseCase(t *testing.T) {
+	suite.Run(t, new(TestRedeliverUseCaseSuite))
+}
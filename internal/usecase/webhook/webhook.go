@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/shared/dto"
+)
+
+func newWebhookID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func toWebhookDTO(webhook *entity.Webhook) dto.WebhookDTO {
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, eventType := range webhook.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	return dto.WebhookDTO{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryDTO(delivery *entity.WebhookDelivery) dto.WebhookDeliveryDTO {
+	return dto.WebhookDeliveryDTO{
+		ID:            delivery.ID,
+		WebhookID:     delivery.WebhookID,
+		EventID:       delivery.EventID,
+		EventType:     string(delivery.EventType),
+		Status:        string(delivery.Status),
+		Attempts:      delivery.Attempts,
+		LastError:     delivery.LastError,
+		NextAttemptAt: delivery.NextAttemptAt,
+		CreatedAt:     delivery.CreatedAt,
+		DeliveredAt:   delivery.DeliveredAt,
+	}
+}
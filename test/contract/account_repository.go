@@ -0,0 +1,290 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"sync"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AccountRepositorySuite struct {
+	suite.Suite
+	NewRepository func() repository.AccountRepository
+	repo          repository.AccountRepository
+}
+
+func (suite *AccountRepositorySuite) SetupSubTest() {
+	suite.repo = suite.NewRepository()
+}
+
+func (suite *AccountRepositorySuite) TestGetAccountByID() {
+	suite.Run("Should return saved account", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount("ID1", 100)))
+
+		account, err := suite.repo.GetAccountByID(ctx, "ID1")
+
+		suite.NoError(err)
+		suite.Equal("ID1", account.ID)
+		suite.Equal(100, account.Balance)
+	})
+
+	suite.Run("Should return ErrAccountNotFound when account does not exist", func() {
+		account, err := suite.repo.GetAccountByID(context.Background(), "ID1")
+
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
+		suite.Nil(account)
+	})
+
+	suite.Run("Should return error when context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		account, err := suite.repo.GetAccountByID(ctx, "ID1")
+
+		suite.ErrorIs(err, context.Canceled)
+		suite.Nil(account)
+	})
+}
+
+func (suite *AccountRepositorySuite) TestUpdateAccount() {
+	suite.Run("Should persist the new balance", func() {
+		ctx := context.Background()
+		account := entity.NewAccount("ID1", 100)
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, account))
+
+		account.Deposit(50)
+		err := suite.repo.UpdateAccount(ctx, account)
+		suite.NoError(err)
+
+		updated, err := suite.repo.GetAccountByID(ctx, "ID1")
+		suite.NoError(err)
+		suite.Equal(150, updated.Balance)
+	})
+
+	suite.Run("Should not share state with the returned account", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount("ID1", 100)))
+
+		account, err := suite.repo.GetAccountByID(ctx, "ID1")
+		suite.Require().NoError(err)
+		account.Deposit(50)
+
+		stored, err := suite.repo.GetAccountByID(ctx, "ID1")
+		suite.NoError(err)
+		suite.Equal(100, stored.Balance)
+	})
+}
+
+func (suite *AccountRepositorySuite) TestUpdateAccounts() {
+	suite.Run("Should write the updated accounts and create the missing ones", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount("ID1", 100)))
+
+		err := suite.repo.UpdateAccounts(ctx, []string{"ID1", "ID2"}, func(accounts []*entity.Account) ([]event.Event, error) {
+			suite.Equal(100, accounts[0].Balance)
+			suite.Nil(accounts[1])
+
+			accounts[0].Balance -= 30
+			accounts[1] = entity.NewAccount("ID2", 30)
+			return nil, nil
+		})
+		suite.Require().NoError(err)
+
+		suite.Equal(70, suite.balance("ID1"))
+		suite.Equal(30, suite.balance("ID2"))
+	})
+
+	suite.Run("Should write nothing when the update fails", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount("ID1", 100)))
+		failure := errors.New("update failed")
+
+		err := suite.repo.UpdateAccounts(ctx, []string{"ID1", "ID2"}, func(accounts []*entity.Account) ([]event.Event, error) {
+			accounts[0].Balance -= 30
+			accounts[1] = entity.NewAccount("ID2", 30)
+			return nil, failure
+		})
+
+		suite.ErrorIs(err, failure)
+		suite.Equal(100, suite.balance("ID1"))
+		_, err = suite.repo.GetAccountByID(ctx, "ID2")
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
+	})
+
+	suite.Run("Should conserve the total balance across concurrent updates", func() {
+		ctx := context.Background()
+		ids := []string{"ID1", "ID2", "ID3"}
+		for _, id := range ids {
+			suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount(id, 1000)))
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 300; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				from, to := ids[i%3], ids[(i+1)%3]
+				err := suite.repo.UpdateAccounts(ctx, []string{from, to}, func(accounts []*entity.Account) ([]event.Event, error) {
+					accounts[0].Balance -= i
+					// yields between the read and the write, so unlocked
+					// updates would interleave and lose some of the amounts.
+					runtime.Gosched()
+					accounts[1].Balance += i
+					return nil, nil
+				})
+				suite.NoError(err)
+			}()
+		}
+		wg.Wait()
+
+		total := 0
+		for _, id := range ids {
+			total += suite.balance(id)
+		}
+		suite.Equal(3000, total)
+	})
+}
+
+func (suite *AccountRepositorySuite) balance(id string) int {
+	account, err := suite.repo.GetAccountByID(context.Background(), id)
+	suite.Require().NoError(err)
+	return account.Balance
+}
+
+func (suite *AccountRepositorySuite) TestDeleteAllAccounts() {
+	suite.Run("Should remove every account", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount("ID1", 100)))
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount("ID2", 200)))
+
+		err := suite.repo.DeleteAllAccounts(ctx)
+		suite.NoError(err)
+
+		_, err = suite.repo.GetAccountByID(ctx, "ID1")
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
+		_, err = suite.repo.GetAccountByID(ctx, "ID2")
+		suite.ErrorIs(err, domainErrs.ErrAccountNotFound)
+	})
+}
+
+func (suite *AccountRepositorySuite) TestListAccounts() {
+	seed := func() {
+		ctx := context.Background()
+		frozen := entity.NewAccount("B2", 300)
+		frozen.Status = entity.AccountStatusFrozen
+
+		for _, account := range []*entity.Account{
+			entity.NewAccount("A1", 200),
+			entity.NewAccount("A2", 100),
+			entity.NewAccount("B1", 100),
+			frozen,
+			entity.NewAccount("C1", 50),
+		} {
+			suite.Require().NoError(suite.repo.SaveAccount(ctx, account))
+		}
+	}
+
+	ids := func(result *repository.ListAccountsResult) []string {
+		ids := []string{}
+		for _, account := range result.Accounts {
+			ids = append(ids, account.ID)
+		}
+		return ids
+	}
+
+	suite.Run("Should paginate accounts sorted by ID", func() {
+		seed()
+		query := repository.ListAccountsQuery{
+			SortBy: repository.AccountSortByID,
+			Order:  repository.SortOrderAsc,
+			Limit:  2,
+		}
+
+		first, err := suite.repo.ListAccounts(context.Background(), query)
+		suite.Require().NoError(err)
+		suite.Equal([]string{"A1", "A2"}, ids(first))
+		suite.Require().NotNil(first.Next)
+
+		query.After = first.Next
+		second, err := suite.repo.ListAccounts(context.Background(), query)
+		suite.Require().NoError(err)
+		suite.Equal([]string{"B1", "B2"}, ids(second))
+
+		query.After = second.Next
+		third, err := suite.repo.ListAccounts(context.Background(), query)
+		suite.Require().NoError(err)
+		suite.Equal([]string{"C1"}, ids(third))
+		suite.Nil(third.Next)
+	})
+
+	suite.Run("Should paginate accounts sorted by balance descending", func() {
+		seed()
+		query := repository.ListAccountsQuery{
+			SortBy: repository.AccountSortByBalance,
+			Order:  repository.SortOrderDesc,
+			Limit:  3,
+		}
+
+		first, err := suite.repo.ListAccounts(context.Background(), query)
+		suite.Require().NoError(err)
+		suite.Equal([]string{"B2", "A1", "B1"}, ids(first))
+
+		query.After = first.Next
+		second, err := suite.repo.ListAccounts(context.Background(), query)
+		suite.Require().NoError(err)
+		suite.Equal([]string{"A2", "C1"}, ids(second))
+		suite.Nil(second.Next)
+	})
+
+	suite.Run("Should filter accounts by balance range", func() {
+		seed()
+		minBalance, maxBalance := 100, 200
+
+		result, err := suite.repo.ListAccounts(context.Background(), repository.ListAccountsQuery{
+			SortBy: repository.AccountSortByBalance,
+			Order:  repository.SortOrderAsc,
+			Filter: repository.AccountFilter{MinBalance: &minBalance, MaxBalance: &maxBalance},
+		})
+
+		suite.NoError(err)
+		suite.Equal([]string{"A2", "B1", "A1"}, ids(result))
+	})
+
+	suite.Run("Should filter accounts by ID prefix and status", func() {
+		seed()
+
+		result, err := suite.repo.ListAccounts(context.Background(), repository.ListAccountsQuery{
+			SortBy: repository.AccountSortByID,
+			Order:  repository.SortOrderDesc,
+			Filter: repository.AccountFilter{IDPrefix: "B", Status: entity.AccountStatusActive},
+		})
+
+		suite.NoError(err)
+		suite.Equal([]string{"B1"}, ids(result))
+	})
+
+	suite.Run("Should keep the balance order after updates", func() {
+		seed()
+		account, err := suite.repo.GetAccountByID(context.Background(), "C1")
+		suite.Require().NoError(err)
+		account.Deposit(1000)
+		suite.Require().NoError(suite.repo.UpdateAccount(context.Background(), account))
+
+		result, err := suite.repo.ListAccounts(context.Background(), repository.ListAccountsQuery{
+			SortBy: repository.AccountSortByBalance,
+			Order:  repository.SortOrderDesc,
+			Limit:  1,
+		})
+
+		suite.NoError(err)
+		suite.Equal([]string{"C1"}, ids(result))
+	})
+}
@@ -0,0 +1,102 @@
+package contract
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyRepositorySuite struct {
+	suite.Suite
+	NewRepository func() repository.APIKeyRepository
+	repo          repository.APIKeyRepository
+}
+
+func (suite *APIKeyRepositorySuite) SetupSubTest() {
+	suite.repo = suite.NewRepository()
+}
+
+func newAPIKey(id, hash string) *entity.APIKey {
+	createdAt := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	return entity.NewAPIKey(id, "backoffice", hash, []entity.Scope{entity.ScopeBalanceRead}, []string{"100"}, createdAt)
+}
+
+func (suite *APIKeyRepositorySuite) TestGetAPIKey() {
+	suite.Run("Should return saved key by ID and hash", func() {
+		ctx := context.Background()
+		key := newAPIKey("K1", "hash-1")
+		suite.Require().NoError(suite.repo.SaveAPIKey(ctx, key))
+
+		byID, err := suite.repo.GetAPIKeyByID(ctx, "K1")
+		suite.NoError(err)
+		suite.Equal(key, byID)
+
+		byHash, err := suite.repo.GetAPIKeyByHash(ctx, "hash-1")
+		suite.NoError(err)
+		suite.Equal(key, byHash)
+	})
+
+	suite.Run("Should return ErrAPIKeyNotFound when key does not exist", func() {
+		ctx := context.Background()
+
+		_, err := suite.repo.GetAPIKeyByID(ctx, "K1")
+		suite.ErrorIs(err, domainErrs.ErrAPIKeyNotFound)
+
+		_, err = suite.repo.GetAPIKeyByHash(ctx, "hash-1")
+		suite.ErrorIs(err, domainErrs.ErrAPIKeyNotFound)
+	})
+
+	suite.Run("Should not share scopes with the returned key", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveAPIKey(ctx, newAPIKey("K1", "hash-1")))
+
+		key, err := suite.repo.GetAPIKeyByID(ctx, "K1")
+		suite.Require().NoError(err)
+		key.Scopes[0] = entity.ScopeAdminKeys
+
+		stored, err := suite.repo.GetAPIKeyByID(ctx, "K1")
+		suite.NoError(err)
+		suite.Equal([]entity.Scope{entity.ScopeBalanceRead}, stored.Scopes)
+	})
+}
+
+func (suite *APIKeyRepositorySuite) TestUpdateAPIKey() {
+	suite.Run("Should find the key by its new hash after rotation", func() {
+		ctx := context.Background()
+		key := newAPIKey("K1", "hash-1")
+		suite.Require().NoError(suite.repo.SaveAPIKey(ctx, key))
+
+		key.Rotate("hash-2")
+		suite.Require().NoError(suite.repo.UpdateAPIKey(ctx, key))
+
+		_, err := suite.repo.GetAPIKeyByHash(ctx, "hash-1")
+		suite.ErrorIs(err, domainErrs.ErrAPIKeyNotFound)
+
+		rotated, err := suite.repo.GetAPIKeyByHash(ctx, "hash-2")
+		suite.NoError(err)
+		suite.Equal("K1", rotated.ID)
+	})
+
+	suite.Run("Should persist revocation", func() {
+		ctx := context.Background()
+		key := newAPIKey("K1", "hash-1")
+		suite.Require().NoError(suite.repo.SaveAPIKey(ctx, key))
+
+		key.Revoke(time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC))
+		suite.Require().NoError(suite.repo.UpdateAPIKey(ctx, key))
+
+		stored, err := suite.repo.GetAPIKeyByID(ctx, "K1")
+		suite.NoError(err)
+		suite.True(stored.IsRevoked())
+	})
+
+	suite.Run("Should return ErrAPIKeyNotFound when key does not exist", func() {
+		err := suite.repo.UpdateAPIKey(context.Background(), newAPIKey("K1", "hash-1"))
+
+		suite.ErrorIs(err, domainErrs.ErrAPIKeyNotFound)
+	})
+}
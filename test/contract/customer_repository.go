@@ -0,0 +1,114 @@
+package contract
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CustomerRepositorySuite struct {
+	suite.Suite
+	NewRepository func() repository.CustomerRepository
+	repo          repository.CustomerRepository
+}
+
+func (suite *CustomerRepositorySuite) SetupSubTest() {
+	suite.repo = suite.NewRepository()
+}
+
+func (suite *CustomerRepositorySuite) TestGetCustomerByID() {
+	suite.Run("Should return saved customer", func() {
+		ctx := context.Background()
+		customer := entity.NewCustomer("C1", "John", "john@example.com", "")
+		customer.LinkAccount("100")
+		suite.Require().NoError(suite.repo.SaveCustomer(ctx, customer))
+
+		saved, err := suite.repo.GetCustomerByID(ctx, "C1")
+
+		suite.NoError(err)
+		suite.Equal(customer, saved)
+	})
+
+	suite.Run("Should return ErrCustomerNotFound when customer does not exist", func() {
+		customer, err := suite.repo.GetCustomerByID(context.Background(), "C1")
+
+		suite.ErrorIs(err, domainErrs.ErrCustomerNotFound)
+		suite.Nil(customer)
+	})
+
+	suite.Run("Should not share linked accounts with the returned customer", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveCustomer(ctx, entity.NewCustomer("C1", "John", "", "")))
+
+		customer, err := suite.repo.GetCustomerByID(ctx, "C1")
+		suite.Require().NoError(err)
+		customer.LinkAccount("100")
+
+		stored, err := suite.repo.GetCustomerByID(ctx, "C1")
+		suite.NoError(err)
+		suite.Empty(stored.AccountIDs)
+	})
+}
+
+func (suite *CustomerRepositorySuite) TestGetCustomersByAccountID() {
+	suite.Run("Should return every owner of a joint account", func() {
+		ctx := context.Background()
+		john := entity.NewCustomer("C1", "John", "", "")
+		john.LinkAccount("100")
+		jane := entity.NewCustomer("C2", "Jane", "", "")
+		jane.LinkAccount("100")
+		jane.LinkAccount("200")
+		suite.Require().NoError(suite.repo.SaveCustomer(ctx, john))
+		suite.Require().NoError(suite.repo.SaveCustomer(ctx, jane))
+
+		owners, err := suite.repo.GetCustomersByAccountID(ctx, "100")
+		suite.NoError(err)
+		suite.Equal([]*entity.Customer{john, jane}, owners)
+
+		owners, err = suite.repo.GetCustomersByAccountID(ctx, "300")
+		suite.NoError(err)
+		suite.Empty(owners)
+	})
+}
+
+func (suite *CustomerRepositorySuite) TestUpdateCustomer() {
+	suite.Run("Should persist changes", func() {
+		ctx := context.Background()
+		customer := entity.NewCustomer("C1", "John", "", "")
+		suite.Require().NoError(suite.repo.SaveCustomer(ctx, customer))
+
+		customer.KYCStatus = entity.KYCStatusVerified
+		suite.NoError(suite.repo.UpdateCustomer(ctx, customer))
+
+		updated, err := suite.repo.GetCustomerByID(ctx, "C1")
+		suite.NoError(err)
+		suite.Equal(entity.KYCStatusVerified, updated.KYCStatus)
+	})
+
+	suite.Run("Should return ErrCustomerNotFound when customer does not exist", func() {
+		err := suite.repo.UpdateCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+
+		suite.ErrorIs(err, domainErrs.ErrCustomerNotFound)
+	})
+}
+
+func (suite *CustomerRepositorySuite) TestDeleteCustomer() {
+	suite.Run("Should remove customer", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveCustomer(ctx, entity.NewCustomer("C1", "John", "", "")))
+
+		suite.NoError(suite.repo.DeleteCustomer(ctx, "C1"))
+
+		_, err := suite.repo.GetCustomerByID(ctx, "C1")
+		suite.ErrorIs(err, domainErrs.ErrCustomerNotFound)
+	})
+
+	suite.Run("Should return ErrCustomerNotFound when customer does not exist", func() {
+		err := suite.repo.DeleteCustomer(context.Background(), "C1")
+
+		suite.ErrorIs(err, domainErrs.ErrCustomerNotFound)
+	})
+}
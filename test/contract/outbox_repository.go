@@ -0,0 +1,146 @@
+package contract
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// OutboxStore is a repository backend storing accounts along with the
+// outbox of their events.
+type OutboxStore interface {
+	repository.AccountRepository
+	repository.OutboxRepository
+}
+
+type OutboxRepositorySuite struct {
+	suite.Suite
+	NewRepository func() OutboxStore
+	repo          OutboxStore
+}
+
+func (suite *OutboxRepositorySuite) SetupSubTest() {
+	suite.repo = suite.NewRepository()
+}
+
+func outboxEvent(id string, balance int) event.Event {
+	return event.New(id, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		event.FundsDeposited{Account: "100", Amount: 10, Balance: balance})
+}
+
+func (suite *OutboxRepositorySuite) events(entries []repository.OutboxEntry) []event.Event {
+	events := make([]event.Event, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, entry.Event)
+	}
+	return events
+}
+
+func (suite *OutboxRepositorySuite) TestOutboxEntries() {
+	suite.Run("Should return the events written with accounts in order", func() {
+		ctx := context.Background()
+		created := event.New("E1", time.Now().UTC(), event.AccountCreated{Account: "100"})
+		deposited, withdrawn := outboxEvent("E2", 10), outboxEvent("E3", 5)
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount("100", 10), created, deposited))
+		suite.Require().NoError(suite.repo.UpdateAccount(ctx, entity.NewAccount("100", 5), withdrawn))
+
+		entries, err := suite.repo.OutboxEntries(ctx, 0, 10)
+
+		suite.NoError(err)
+		suite.Equal([]event.Event{created, deposited, withdrawn}, suite.events(entries))
+		suite.Less(entries[0].Sequence, entries[1].Sequence)
+		suite.Less(entries[1].Sequence, entries[2].Sequence)
+	})
+
+	suite.Run("Should return the entries after a sequence up to the limit", func() {
+		ctx := context.Background()
+		for i, id := range []string{"E1", "E2", "E3", "E4"} {
+			suite.Require().NoError(suite.repo.UpdateAccount(ctx, entity.NewAccount("100", i), outboxEvent(id, i)))
+		}
+		first, _ := suite.repo.OutboxEntries(ctx, 0, 1)
+
+		entries, err := suite.repo.OutboxEntries(ctx, first[0].Sequence, 2)
+
+		suite.NoError(err)
+		suite.Equal([]event.Event{outboxEvent("E2", 1), outboxEvent("E3", 2)}, suite.events(entries))
+	})
+
+	suite.Run("Should write nothing without events", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveAccount(ctx, entity.NewAccount("100", 10)))
+
+		entries, err := suite.repo.OutboxEntries(ctx, 0, 10)
+
+		suite.NoError(err)
+		suite.Empty(entries)
+	})
+
+	suite.Run("Should return error when context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		entries, err := suite.repo.OutboxEntries(ctx, 0, 10)
+
+		suite.ErrorIs(err, context.Canceled)
+		suite.Nil(entries)
+	})
+}
+
+func (suite *OutboxRepositorySuite) TestOutboxCursor() {
+	suite.Run("Should keep a cursor per sink", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.AdvanceOutboxCursor(ctx, "webhooks", 3))
+
+		webhooks, err := suite.repo.OutboxCursor(ctx, "webhooks")
+		suite.NoError(err)
+		suite.Equal(uint64(3), webhooks)
+
+		other, err := suite.repo.OutboxCursor(ctx, "other")
+		suite.NoError(err)
+		suite.Zero(other)
+	})
+
+	suite.Run("Should never move a cursor back", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.AdvanceOutboxCursor(ctx, "webhooks", 3))
+		suite.Require().NoError(suite.repo.AdvanceOutboxCursor(ctx, "webhooks", 2))
+
+		cursor, err := suite.repo.OutboxCursor(ctx, "webhooks")
+
+		suite.NoError(err)
+		suite.Equal(uint64(3), cursor)
+	})
+}
+
+func (suite *OutboxRepositorySuite) TestPruneOutbox() {
+	suite.Run("Should drop the entries up to the sequence", func() {
+		ctx := context.Background()
+		for i, id := range []string{"E1", "E2", "E3"} {
+			suite.Require().NoError(suite.repo.UpdateAccount(ctx, entity.NewAccount("100", i), outboxEvent(id, i)))
+		}
+		entries, _ := suite.repo.OutboxEntries(ctx, 0, 10)
+
+		suite.NoError(suite.repo.PruneOutbox(ctx, entries[1].Sequence))
+
+		remaining, err := suite.repo.OutboxEntries(ctx, 0, 10)
+		suite.NoError(err)
+		suite.Equal(entries[2:], remaining)
+	})
+
+	suite.Run("Should keep numbering after the pruned entries", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.UpdateAccount(ctx, entity.NewAccount("100", 1), outboxEvent("E1", 1)))
+		entries, _ := suite.repo.OutboxEntries(ctx, 0, 10)
+		suite.Require().NoError(suite.repo.PruneOutbox(ctx, entries[0].Sequence))
+
+		suite.Require().NoError(suite.repo.UpdateAccount(ctx, entity.NewAccount("100", 2), outboxEvent("E2", 2)))
+
+		next, err := suite.repo.OutboxEntries(ctx, entries[0].Sequence, 10)
+		suite.NoError(err)
+		suite.Equal([]event.Event{outboxEvent("E2", 2)}, suite.events(next))
+	})
+}
@@ -0,0 +1,66 @@
+package contract
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/repository"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ProductRepositorySuite expects NewRepository to return a catalog holding
+// exactly the products returned by ProductFixtures, with checking as default.
+type ProductRepositorySuite struct {
+	suite.Suite
+	NewRepository func() repository.ProductRepository
+	repo          repository.ProductRepository
+}
+
+func ProductFixtures() []*entity.Product {
+	checking := entity.NewProduct("checking", "Checking")
+
+	savings := entity.NewProduct("savings", "Savings")
+	savings.MonthlyWithdrawalLimit = 6
+	savings.Fees = entity.FeeSchedule{Withdrawal: 2, Transfer: 2}
+
+	return []*entity.Product{checking, savings}
+}
+
+func (suite *ProductRepositorySuite) SetupSubTest() {
+	suite.repo = suite.NewRepository()
+}
+
+func (suite *ProductRepositorySuite) TestGetProductByID() {
+	suite.Run("Should return configured product", func() {
+		product, err := suite.repo.GetProductByID(context.Background(), "savings")
+
+		suite.NoError(err)
+		suite.Equal(ProductFixtures()[1], product)
+	})
+
+	suite.Run("Should return ErrProductNotFound when product does not exist", func() {
+		product, err := suite.repo.GetProductByID(context.Background(), "gold")
+
+		suite.ErrorIs(err, domainErrs.ErrProductNotFound)
+		suite.Nil(product)
+	})
+}
+
+func (suite *ProductRepositorySuite) TestGetDefaultProduct() {
+	suite.Run("Should return the default product", func() {
+		product, err := suite.repo.GetDefaultProduct(context.Background())
+
+		suite.NoError(err)
+		suite.Equal("checking", product.ID)
+	})
+}
+
+func (suite *ProductRepositorySuite) TestListProducts() {
+	suite.Run("Should list products sorted by ID", func() {
+		products, err := suite.repo.ListProducts(context.Background())
+
+		suite.NoError(err)
+		suite.Equal(ProductFixtures(), products)
+	})
+}
@@ -0,0 +1,156 @@
+package contract
+
+import (
+	"context"
+	"simple-bank/internal/domain/entity"
+	domainErrs "simple-bank/internal/domain/errors"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/domain/repository"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookRepositorySuite struct {
+	suite.Suite
+	NewRepository func() repository.WebhookRepository
+	repo          repository.WebhookRepository
+}
+
+func (suite *WebhookRepositorySuite) SetupSubTest() {
+	suite.repo = suite.NewRepository()
+}
+
+var webhookTime = time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+func newWebhook(id string) *entity.Webhook {
+	return entity.NewWebhook(id, "https://example.com/hooks", []event.Type{event.TypeFundsDeposited}, "secret", webhookTime)
+}
+
+func newDelivery(id string, webhook *entity.Webhook, at time.Time) *entity.WebhookDelivery {
+	e := event.New("E-"+id, webhookTime, event.FundsDeposited{Account: "100", Amount: 10, Balance: 10})
+	return entity.NewWebhookDelivery(id, webhook, e, []byte(`{"id":"E-`+id+`"}`), at)
+}
+
+func (suite *WebhookRepositorySuite) TestWebhooks() {
+	suite.Run("Should return saved webhooks", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveWebhook(ctx, newWebhook("W2")))
+		suite.Require().NoError(suite.repo.SaveWebhook(ctx, newWebhook("W1")))
+
+		webhook, err := suite.repo.GetWebhookByID(ctx, "W1")
+		suite.NoError(err)
+		suite.Equal(newWebhook("W1"), webhook)
+
+		webhooks, err := suite.repo.ListWebhooks(ctx)
+		suite.NoError(err)
+		suite.Equal([]*entity.Webhook{newWebhook("W1"), newWebhook("W2")}, webhooks)
+	})
+
+	suite.Run("Should delete webhooks", func() {
+		ctx := context.Background()
+		suite.Require().NoError(suite.repo.SaveWebhook(ctx, newWebhook("W1")))
+
+		suite.NoError(suite.repo.DeleteWebhook(ctx, "W1"))
+
+		_, err := suite.repo.GetWebhookByID(ctx, "W1")
+		suite.ErrorIs(err, domainErrs.ErrWebhookNotFound)
+		suite.ErrorIs(suite.repo.DeleteWebhook(ctx, "W1"), domainErrs.ErrWebhookNotFound)
+	})
+}
+
+func (suite *WebhookRepositorySuite) TestDeliveries() {
+	suite.Run("Should return due pending deliveries oldest first", func() {
+		ctx := context.Background()
+		webhook := newWebhook("W1")
+		later := newDelivery("D3", webhook, webhookTime.Add(time.Hour))
+		dead := newDelivery("D2", webhook, webhookTime)
+		dead.Fail("timeout", webhookTime, nil)
+		suite.Require().NoError(suite.repo.SaveDeliveries(ctx,
+			newDelivery("D1", webhook, webhookTime), dead, later, newDelivery("D4", webhook, webhookTime),
+		))
+
+		due, err := suite.repo.DueDeliveries(ctx, webhookTime, 10)
+		suite.NoError(err)
+		suite.Equal([]*entity.WebhookDelivery{newDelivery("D1", webhook, webhookTime), newDelivery("D4", webhook, webhookTime)}, due)
+
+		due, err = suite.repo.DueDeliveries(ctx, webhookTime.Add(time.Hour), 2)
+		suite.NoError(err)
+		suite.Len(due, 2)
+	})
+
+	suite.Run("Should list deliveries by status", func() {
+		ctx := context.Background()
+		webhook := newWebhook("W1")
+		dead := newDelivery("D2", webhook, webhookTime)
+		dead.Fail("timeout", webhookTime, nil)
+		suite.Require().NoError(suite.repo.SaveDeliveries(ctx, newDelivery("D1", webhook, webhookTime), dead))
+
+		deliveries, err := suite.repo.ListDeliveries(ctx, entity.DeliveryStatusDead)
+		suite.NoError(err)
+		suite.Equal([]*entity.WebhookDelivery{dead}, deliveries)
+
+		deliveries, err = suite.repo.ListDeliveries(ctx, "")
+		suite.NoError(err)
+		suite.Len(deliveries, 2)
+	})
+
+	suite.Run("Should update deliveries", func() {
+		ctx := context.Background()
+		delivery := newDelivery("D1", newWebhook("W1"), webhookTime)
+		suite.Require().NoError(suite.repo.SaveDeliveries(ctx, delivery))
+
+		delivery.Succeed(webhookTime)
+		suite.NoError(suite.repo.UpdateDelivery(ctx, delivery))
+
+		stored, err := suite.repo.GetDeliveryByID(ctx, "D1")
+		suite.NoError(err)
+		suite.Equal(delivery, stored)
+	})
+
+	suite.Run("Should keep the stored delivery when saved again", func() {
+		ctx := context.Background()
+		delivery := newDelivery("D1", newWebhook("W1"), webhookTime)
+		delivery.Succeed(webhookTime)
+		suite.Require().NoError(suite.repo.SaveDeliveries(ctx, delivery))
+
+		suite.NoError(suite.repo.SaveDeliveries(ctx, newDelivery("D1", newWebhook("W1"), webhookTime)))
+
+		deliveries, err := suite.repo.ListDeliveries(ctx, "")
+		suite.NoError(err)
+		suite.Equal([]*entity.WebhookDelivery{delivery}, deliveries)
+	})
+
+	suite.Run("Should return ErrWebhookDeliveryNotFound when delivery does not exist", func() {
+		ctx := context.Background()
+
+		_, err := suite.repo.GetDeliveryByID(ctx, "D1")
+		suite.ErrorIs(err, domainErrs.ErrWebhookDeliveryNotFound)
+
+		err = suite.repo.UpdateDelivery(ctx, newDelivery("D1", newWebhook("W1"), webhookTime))
+		suite.ErrorIs(err, domainErrs.ErrWebhookDeliveryNotFound)
+	})
+}
+
+func (suite *WebhookRepositorySuite) TestPruneDeliveries() {
+	suite.Run("Should delete the deliveries finished before the time", func() {
+		ctx := context.Background()
+		webhook := newWebhook("W1")
+		delivered, dead := newDelivery("D1", webhook, webhookTime), newDelivery("D2", webhook, webhookTime)
+		delivered.Succeed(webhookTime)
+		dead.Fail("timeout", webhookTime, nil)
+		recent := newDelivery("D4", webhook, webhookTime)
+		recent.Fail("timeout", webhookTime.Add(time.Hour), nil)
+		suite.Require().NoError(suite.repo.SaveDeliveries(ctx, delivered, dead, newDelivery("D3", webhook, webhookTime), recent))
+
+		pruned, err := suite.repo.PruneDeliveries(ctx, webhookTime.Add(time.Hour))
+
+		suite.NoError(err)
+		suite.Equal(2, pruned)
+		deliveries, err := suite.repo.ListDeliveries(ctx, "")
+		suite.NoError(err)
+		suite.Equal([]*entity.WebhookDelivery{newDelivery("D3", webhook, webhookTime), recent}, deliveries, "created before the time but finished since")
+		_, err = suite.repo.GetDeliveryByID(ctx, "D1")
+		suite.ErrorIs(err, domainErrs.ErrWebhookDeliveryNotFound)
+	})
+}
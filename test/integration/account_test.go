@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestAccountHandlerSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestAccountHandlerSuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 300))
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("200", 100))
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("300", 200))
+}
+
+func (suite *TestAccountHandlerSuite) Test_GET_Accounts() {
+	suite.Run("Should list accounts sorted by ID", func() {
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{"accounts": [
+			{"id": "100", "balance": 300, "status": "active", "product": ""},
+			{"id": "200", "balance": 100, "status": "active", "product": ""},
+			{"id": "300", "balance": 200, "status": "active", "product": ""}
+		]}`, rec.Body.String())
+	})
+
+	suite.Run("Should follow the next cursor", func() {
+		req := httptest.NewRequest(http.MethodGet, "/accounts?sort=balance&order=desc&limit=2", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Require().Equal(http.StatusOK, rec.Code)
+		var page struct {
+			NextCursor string `json:"next_cursor"`
+		}
+		suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &page))
+		suite.Require().NotEmpty(page.NextCursor)
+
+		req = httptest.NewRequest(http.MethodGet, "/accounts?sort=balance&order=desc&limit=2&cursor="+page.NextCursor, nil)
+		rec = httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{"accounts": [{"id": "200", "balance": 100, "status": "active", "product": ""}]}`, rec.Body.String())
+	})
+
+	suite.Run("Should filter accounts by balance range", func() {
+		req := httptest.NewRequest(http.MethodGet, "/accounts?min_balance=150&max_balance=250", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{"accounts": [{"id": "300", "balance": 200, "status": "active", "product": ""}]}`, rec.Body.String())
+	})
+
+	suite.Run("Should return 400 when sort is invalid", func() {
+		req := httptest.NewRequest(http.MethodGet, "/accounts?sort=name", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+	})
+
+	suite.Run("Should return 400 when min_balance is not a number", func() {
+		req := httptest.NewRequest(http.MethodGet, "/accounts?min_balance=abc", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestAccountHandler(t *testing.T) {
+	suite.Run(t, new(TestAccountHandlerSuite))
+}
@@ -0,0 +1,124 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestAuditSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestAuditSuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+}
+
+func (suite *TestAuditSuite) post(path string, body any) *httptest.ResponseRecorder {
+	req := suite.app.NewJSONRequest(http.MethodPost, path, body)
+	req.Header.Set("X-Request-ID", "audit-request")
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, req)
+	return rec
+}
+
+func (suite *TestAuditSuite) Test_Events() {
+	suite.Run("Should record the balances of a transfer", func() {
+		rec := suite.post("/event", map[string]any{"type": "transfer", "origin": "100", "destination": "300", "amount": 15})
+		suite.Equal(http.StatusCreated, rec.Code)
+
+		entries := suite.app.AuditEntries()
+		suite.Require().Len(entries, 1)
+		suite.Equal(audit.ActionTransfer, entries[0].Action)
+		suite.Equal(audit.OutcomeSuccess, entries[0].Outcome)
+		suite.Equal("anonymous", entries[0].Actor)
+		suite.Equal("192.0.2.1", entries[0].IP)
+		suite.Equal("audit-request", entries[0].RequestID)
+		suite.Equal([]audit.BalanceChange{
+			{Account: "100", Before: 100, After: 85},
+			{Account: "300", Before: 0, After: 15},
+		}, entries[0].Balances)
+	})
+
+	suite.Run("Should record the peer address instead of forwarded headers", func() {
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", map[string]any{"type": "deposit", "destination": "100", "amount": 10})
+		req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.7")
+		req.Header.Set(echo.HeaderXRealIP, "203.0.113.8")
+		suite.app.PerformRequest(httptest.NewRecorder(), req)
+
+		entries := suite.app.AuditEntries()
+		suite.Require().Len(entries, 1)
+		suite.Equal("192.0.2.1", entries[0].IP)
+	})
+
+	suite.Run("Should record rejected events", func() {
+		suite.post("/event", map[string]any{"type": "withdraw", "origin": "100", "amount": 1000})
+		suite.post("/event", map[string]any{"type": "refund", "origin": "100", "amount": 10})
+
+		entries := suite.app.AuditEntries()
+		suite.Require().Len(entries, 2)
+		suite.Equal(audit.ActionWithdraw, entries[0].Action)
+		suite.Equal(audit.OutcomeRejected, entries[0].Outcome)
+		suite.Equal("insufficient_funds", entries[0].Detail)
+		suite.Empty(entries[0].Balances)
+		suite.Equal(audit.ActionInvalidEvent, entries[1].Action)
+		suite.Equal(audit.OutcomeRejected, entries[1].Outcome)
+	})
+
+	suite.Run("Should record v2 deposits", func() {
+		rec := suite.post("/v2/accounts/100/deposits", map[string]any{"amount": 10})
+		suite.Equal(http.StatusCreated, rec.Code)
+
+		entries := suite.app.AuditEntries()
+		suite.Require().Len(entries, 1)
+		suite.Equal(audit.ActionDeposit, entries[0].Action)
+		suite.Equal([]audit.BalanceChange{{Account: "100", Before: 100, After: 110}}, entries[0].Balances)
+	})
+}
+
+func (suite *TestAuditSuite) Test_AdminActions() {
+	suite.Run("Should record API key management", func() {
+		rec := suite.post("/admin/api-keys", map[string]any{"name": "reporting", "scopes": []string{"balance:read"}})
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.post("/admin/api-keys/unknown/rotate", nil)
+
+		entries := suite.app.AuditEntries()
+		suite.Require().Len(entries, 2)
+		suite.Equal(audit.ActionAPIKeyCreate, entries[0].Action)
+		suite.Equal(audit.OutcomeSuccess, entries[0].Outcome)
+		suite.NotEmpty(entries[0].Target)
+		suite.Equal(audit.ActionAPIKeyRotate, entries[1].Action)
+		suite.Equal("unknown", entries[1].Target)
+		suite.Equal(audit.OutcomeRejected, entries[1].Outcome)
+		suite.Equal("api_key_not_found", entries[1].Detail)
+	})
+}
+
+func (suite *TestAuditSuite) Test_Chain() {
+	suite.Run("Should chain every recorded action", func() {
+		suite.post("/event", map[string]any{"type": "deposit", "destination": "100", "amount": 10})
+		suite.post("/event", map[string]any{"type": "withdraw", "origin": "100", "amount": 5})
+		req := httptest.NewRequest(http.MethodPost, "/reset", nil)
+		req.Header.Set("X-Reset-Confirmation", support.TestResetToken)
+		suite.app.PerformRequest(httptest.NewRecorder(), req)
+
+		entries := suite.app.AuditEntries()
+		suite.Len(entries, 3)
+		head, err := suite.app.Audit.Head(context.Background())
+		suite.NoError(err)
+		suite.NoError(audit.Verify(support.AuditKey, entries, head))
+	})
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, new(TestAuditSuite))
+}
@@ -0,0 +1,277 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestAuthSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestAuthSuite) SetupSubTest() {
+	suite.app = support.NewTestAppWithAuth()
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("200", 100))
+}
+
+func (suite *TestAuthSuite) getBalance(accountID, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/balance?account_id="+accountID, nil)
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, req)
+	return rec
+}
+
+func (suite *TestAuthSuite) Test_Authentication() {
+	suite.Run("Should return 401 without API key", func() {
+		rec := suite.getBalance("100", "")
+
+		suite.Equal(http.StatusUnauthorized, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"unauthenticated"`)
+	})
+
+	suite.Run("Should return 401 with an unknown API key", func() {
+		rec := suite.getBalance("100", "unknown")
+
+		suite.Equal(http.StatusUnauthorized, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"invalid_credentials"`)
+	})
+
+	suite.Run("Should return 403 when key lacks the scope", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeEventWrite})
+
+		rec := suite.getBalance("100", key)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"insufficient_scope"`)
+	})
+
+	suite.Run("Should allow requests with the scope", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead})
+
+		rec := suite.getBalance("100", key)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("100", rec.Body.String())
+	})
+
+	suite.Run("Should keep public routes open", func() {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+	})
+
+	suite.Run("Should require admin:reset to reset", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead, entity.ScopeEventWrite})
+
+		req := httptest.NewRequest(http.MethodPost, "/reset", nil)
+		req.Header.Set("X-API-Key", key)
+		req.Header.Set("X-Reset-Confirmation", support.TestResetToken)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Len(suite.app.AccountRepository.Accounts, 2)
+	})
+
+	suite.Run("Should reset with admin:reset and record the actor", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminReset})
+
+		req := httptest.NewRequest(http.MethodPost, "/reset", nil)
+		req.Header.Set("X-API-Key", key)
+		req.Header.Set("X-Reset-Confirmation", support.TestResetToken)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Empty(suite.app.AccountRepository.Accounts)
+		suite.Require().Len(suite.app.AuditEntries(), 1)
+		suite.Equal("api-key:"+key, suite.app.AuditEntries()[0].Actor)
+	})
+}
+
+func (suite *TestAuthSuite) Test_AccountRestriction() {
+	suite.Run("Should only read balances of allowed accounts", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead}, "100")
+
+		suite.Equal(http.StatusOK, suite.getBalance("100", key).Code)
+
+		rec := suite.getBalance("200", key)
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"account_forbidden"`)
+	})
+
+	suite.Run("Should refuse moving money out of other accounts", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeEventWrite}, "100")
+
+		body := map[string]interface{}{"type": "transfer", "origin": "200", "destination": "100", "amount": 50}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Equal(100, suite.app.AccountRepository.Accounts["200"].Balance)
+	})
+
+	suite.Run("Should allow transfers out of allowed accounts", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeEventWrite}, "100")
+
+		body := map[string]interface{}{"type": "transfer", "origin": "100", "destination": "200", "amount": 50}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+	})
+
+	suite.Run("Should refuse listing every account", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead}, "100")
+
+		req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+	})
+}
+
+func (suite *TestAuthSuite) Test_AdminAPIKeys() {
+	suite.Run("Should create, rotate and revoke keys", func() {
+		admin := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminKeys, entity.ScopeBalanceRead})
+
+		body := map[string]interface{}{"name": "backoffice", "scopes": []string{"balance:read"}, "account_ids": []string{"100"}}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/admin/api-keys", body)
+		req.Header.Set("X-API-Key", admin)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Require().Equal(http.StatusCreated, rec.Code)
+		var created struct {
+			Key struct {
+				ID         string   `json:"id"`
+				Scopes     []string `json:"scopes"`
+				AccountIDs []string `json:"account_ids"`
+			} `json:"key"`
+			Secret string `json:"secret"`
+		}
+		suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &created))
+		suite.Equal([]string{"balance:read"}, created.Key.Scopes)
+		suite.Equal([]string{"100"}, created.Key.AccountIDs)
+		suite.NotContains(suite.app.APIKeyRepository.APIKeys[created.Key.ID].Hash, created.Secret)
+		suite.Equal(http.StatusOK, suite.getBalance("100", created.Secret).Code)
+
+		req = httptest.NewRequest(http.MethodPost, "/admin/api-keys/"+created.Key.ID+"/rotate", nil)
+		req.Header.Set("X-API-Key", admin)
+		rec = httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Require().Equal(http.StatusOK, rec.Code)
+		var rotated struct {
+			Secret string `json:"secret"`
+		}
+		suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &rotated))
+		suite.Equal(http.StatusUnauthorized, suite.getBalance("100", created.Secret).Code)
+		suite.Equal(http.StatusOK, suite.getBalance("100", rotated.Secret).Code)
+
+		req = httptest.NewRequest(http.MethodDelete, "/admin/api-keys/"+created.Key.ID, nil)
+		req.Header.Set("X-API-Key", admin)
+		rec = httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNoContent, rec.Code)
+		suite.Equal(http.StatusUnauthorized, suite.getBalance("100", rotated.Secret).Code)
+	})
+
+	suite.Run("Should refuse unknown scopes", func() {
+		admin := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminKeys})
+
+		body := map[string]interface{}{"name": "backoffice", "scopes": []string{"admin:*"}}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/admin/api-keys", body)
+		req.Header.Set("X-API-Key", admin)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"invalid_api_key"`)
+	})
+
+	suite.Run("Should refuse granting scopes the caller does not hold", func() {
+		admin := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminKeys, entity.ScopeBalanceRead})
+
+		body := map[string]interface{}{"name": "backoffice", "scopes": []string{"balance:read", "admin:reset"}}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/admin/api-keys", body)
+		req.Header.Set("X-API-Key", admin)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"insufficient_scope"`)
+		suite.Len(suite.app.APIKeyRepository.APIKeys, 1)
+	})
+
+	suite.Run("Should refuse managing keys with a key restricted to some accounts", func() {
+		admin := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminKeys, entity.ScopeBalanceRead}, "100")
+		other := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead}, "100")
+
+		for _, req := range []*http.Request{
+			suite.app.NewJSONRequest(http.MethodPost, "/admin/api-keys", map[string]interface{}{
+				"name": "backoffice", "scopes": []string{"balance:read"}, "account_ids": []string{"100"},
+			}),
+			httptest.NewRequest(http.MethodPost, "/admin/api-keys/"+other+"/rotate", nil),
+			httptest.NewRequest(http.MethodDelete, "/admin/api-keys/"+other, nil),
+		} {
+			req.Header.Set("X-API-Key", admin)
+			rec := httptest.NewRecorder()
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusForbidden, rec.Code, req.URL.Path)
+		}
+		suite.Len(suite.app.APIKeyRepository.APIKeys, 2)
+		suite.Equal(http.StatusOK, suite.getBalance("100", other).Code)
+	})
+
+	suite.Run("Should refuse rotating keys with scopes the caller does not hold", func() {
+		admin := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminKeys})
+		reset := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminKeys, entity.ScopeAdminReset})
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/api-keys/"+reset+"/rotate", nil)
+		req.Header.Set("X-API-Key", admin)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"insufficient_scope"`)
+	})
+
+	suite.Run("Should require admin:keys", func() {
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminReset})
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/api-keys/K1", nil)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestAuth(t *testing.T) {
+	suite.Run(t, new(TestAuthSuite))
+}
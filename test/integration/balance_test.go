@@ -1,12 +1,15 @@
 package integration
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/http/problem"
 	"simple-bank/test/support"
 	"testing"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -22,7 +25,7 @@ func (suite *TestBalanceHandlerSuite) SetupSubTest() {
 func (suite *TestBalanceHandlerSuite) Test_GET_Balance() {
 	suite.Run("Should return balance when account exists", func() {
 		account := entity.NewAccount("ID1", 100)
-		suite.app.AccountRepository.SaveAccount(account)
+		suite.app.AccountRepository.SaveAccount(context.Background(), account)
 
 		req := httptest.NewRequest(http.MethodGet, "/balance?account_id=ID1", nil)
 		rec := httptest.NewRecorder()
@@ -40,7 +43,14 @@ func (suite *TestBalanceHandlerSuite) Test_GET_Balance() {
 		suite.app.PerformRequest(rec, req)
 
 		suite.Equal(http.StatusNotFound, rec.Code)
-		suite.Equal("0", rec.Body.String())
+		suite.Equal(problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		suite.JSONEq(`{
+			"type": "/problems/account-not-found",
+			"title": "Account not found",
+			"status": 404,
+			"code": "account_not_found",
+			"instance": "/balance"
+		}`, rec.Body.String())
 	})
 }
 
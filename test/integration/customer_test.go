@@ -0,0 +1,299 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestCustomerHandlerSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestCustomerHandlerSuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+}
+
+func (suite *TestCustomerHandlerSuite) Test_POST_Customers() {
+	suite.Run("Should create customer", func() {
+		body := map[string]interface{}{
+			"name":  "John",
+			"email": "john@example.com",
+			"phone": "+5511999999999",
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/customers", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		var customer map[string]interface{}
+		suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &customer))
+		suite.NotEmpty(customer["id"])
+		suite.Equal("John", customer["name"])
+		suite.Equal("pending", customer["kyc_status"])
+		suite.Len(suite.app.CustomerRepository.Customers, 1)
+	})
+
+	suite.Run("Should return 400 when name is missing", func() {
+		req := suite.app.NewJSONRequest(http.MethodPost, "/customers", map[string]interface{}{})
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+	})
+}
+
+func (suite *TestCustomerHandlerSuite) Test_GET_Customer() {
+	suite.Run("Should return customer", func() {
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+
+		req := httptest.NewRequest(http.MethodGet, "/customers/C1", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{
+			"id": "C1",
+			"name": "John",
+			"email": "",
+			"phone": "",
+			"kyc_status": "pending",
+			"account_ids": []
+		}`, rec.Body.String())
+	})
+
+	suite.Run("Should return 404 when customer does not exist", func() {
+		req := httptest.NewRequest(http.MethodGet, "/customers/C1", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNotFound, rec.Code)
+	})
+}
+
+func (suite *TestCustomerHandlerSuite) Test_PUT_Customer() {
+	suite.Run("Should update customer", func() {
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+
+		body := map[string]interface{}{"name": "John Doe", "kyc_status": "verified"}
+		req := suite.app.NewJSONRequest(http.MethodPut, "/customers/C1", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal(entity.KYCStatusPending, suite.app.CustomerRepository.Customers["C1"].KYCStatus, "kyc_status is ignored")
+		suite.Equal("John Doe", suite.app.CustomerRepository.Customers["C1"].Name)
+	})
+}
+
+func (suite *TestCustomerHandlerSuite) Test_PUT_Customer_KYC() {
+	suite.Run("Should set the KYC status with admin:kyc", func() {
+		suite.app = support.NewTestAppWithAuth()
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminKYC})
+
+		req := suite.app.NewJSONRequest(http.MethodPut, "/customers/C1/kyc", map[string]interface{}{"kyc_status": "verified"})
+		req.Header.Set(auth.HeaderAPIKey, key)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal(entity.KYCStatusVerified, suite.app.CustomerRepository.Customers["C1"].KYCStatus)
+	})
+
+	suite.Run("Should refuse setting the KYC status without admin:kyc", func() {
+		suite.app = support.NewTestAppWithAuth()
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 0))
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("100")
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), customer)
+
+		for _, key := range []string{
+			suite.app.CreateAPIKey([]entity.Scope{entity.ScopeCustomerWrite}, "100"),
+			suite.app.CreateAPIKey([]entity.Scope{entity.ScopeAdminKYC}, "100"),
+		} {
+			req := suite.app.NewJSONRequest(http.MethodPut, "/customers/C1/kyc", map[string]interface{}{"kyc_status": "verified"})
+			req.Header.Set(auth.HeaderAPIKey, key)
+			rec := httptest.NewRecorder()
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusForbidden, rec.Code)
+		}
+		suite.Equal(entity.KYCStatusPending, suite.app.CustomerRepository.Customers["C1"].KYCStatus)
+	})
+
+	suite.Run("Should return 400 when the KYC status is invalid", func() {
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+
+		req := suite.app.NewJSONRequest(http.MethodPut, "/customers/C1/kyc", map[string]interface{}{"kyc_status": "approved"})
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"invalid_customer"`)
+	})
+}
+
+func (suite *TestCustomerHandlerSuite) Test_DELETE_Customer() {
+	suite.Run("Should delete customer", func() {
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+
+		req := httptest.NewRequest(http.MethodDelete, "/customers/C1", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNoContent, rec.Code)
+		suite.Empty(suite.app.CustomerRepository.Customers)
+	})
+}
+
+func (suite *TestCustomerHandlerSuite) Test_Customer_Accounts() {
+	suite.Run("Should link a joint account to two customers", func() {
+		ctx := context.Background()
+		suite.app.AccountRepository.SaveAccount(ctx, entity.NewAccount("100", 50))
+		suite.app.CustomerRepository.SaveCustomer(ctx, entity.NewCustomer("C1", "John", "", ""))
+		suite.app.CustomerRepository.SaveCustomer(ctx, entity.NewCustomer("C2", "Jane", "", ""))
+
+		for _, customerID := range []string{"C1", "C2"} {
+			body := map[string]interface{}{"account_id": "100"}
+			req := suite.app.NewJSONRequest(http.MethodPost, "/customers/"+customerID+"/accounts", body)
+			rec := httptest.NewRecorder()
+
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusCreated, rec.Code)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/customers/C2/accounts", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{"accounts": [{"id": "100", "balance": 50, "status": "active", "product": ""}]}`, rec.Body.String())
+	})
+
+	suite.Run("Should return 404 when linking an unknown account", func() {
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+
+		body := map[string]interface{}{"account_id": "100"}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/customers/C1/accounts", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNotFound, rec.Code)
+	})
+
+	suite.Run("Should unlink account", func() {
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("100")
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), customer)
+
+		req := httptest.NewRequest(http.MethodDelete, "/customers/C1/accounts/100", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNoContent, rec.Code)
+		suite.Empty(suite.app.CustomerRepository.Customers["C1"].AccountIDs)
+	})
+
+	suite.Run("Should refuse accounts outside of the credential restriction", func() {
+		suite.app = support.NewTestAppWithAuth()
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 0))
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("200", 0))
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("200")
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), customer)
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeCustomerRead, entity.ScopeCustomerWrite}, "100")
+
+		requests := []*http.Request{
+			suite.app.NewJSONRequest(http.MethodGet, "/customers/C1/accounts", nil),
+			suite.app.NewJSONRequest(http.MethodPost, "/customers/C1/accounts", map[string]interface{}{"account_id": "300"}),
+			suite.app.NewJSONRequest(http.MethodDelete, "/customers/C1/accounts/200", nil),
+		}
+		for _, req := range requests {
+			req.Header.Set(auth.HeaderAPIKey, key)
+			rec := httptest.NewRecorder()
+
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusForbidden, rec.Code, req.Method+" "+req.URL.Path)
+			suite.Contains(rec.Body.String(), `"code":"account_forbidden"`)
+		}
+		suite.Equal([]string{"200"}, suite.app.CustomerRepository.Customers["C1"].AccountIDs)
+	})
+}
+
+func (suite *TestCustomerHandlerSuite) Test_Customer_Ownership() {
+	setup := func() string {
+		suite.app = support.NewTestAppWithAuth()
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 0))
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("200", 0))
+		own := entity.NewCustomer("C1", "John", "", "")
+		own.LinkAccount("100")
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), own)
+		other := entity.NewCustomer("C2", "Jane", "", "")
+		other.LinkAccount("200")
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), other)
+		return suite.app.CreateAPIKey([]entity.Scope{entity.ScopeCustomerRead, entity.ScopeCustomerWrite}, "100")
+	}
+
+	suite.Run("Should refuse customers without an account of the credential", func() {
+		key := setup()
+
+		requests := []*http.Request{
+			suite.app.NewJSONRequest(http.MethodGet, "/customers/C2", nil),
+			suite.app.NewJSONRequest(http.MethodPut, "/customers/C2", map[string]interface{}{"name": "Mallory"}),
+			suite.app.NewJSONRequest(http.MethodDelete, "/customers/C2", nil),
+			suite.app.NewJSONRequest(http.MethodPost, "/customers/C2/accounts", map[string]interface{}{"account_id": "100"}),
+			suite.app.NewJSONRequest(http.MethodGet, "/customers/C3", nil),
+		}
+		for _, req := range requests {
+			req.Header.Set(auth.HeaderAPIKey, key)
+			rec := httptest.NewRecorder()
+
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusForbidden, rec.Code, req.Method+" "+req.URL.Path)
+		}
+		suite.Equal("Jane", suite.app.CustomerRepository.Customers["C2"].Name)
+		suite.Equal([]string{"200"}, suite.app.CustomerRepository.Customers["C2"].AccountIDs)
+	})
+
+	suite.Run("Should allow customers with an account of the credential", func() {
+		key := setup()
+
+		req := suite.app.NewJSONRequest(http.MethodGet, "/customers/C1", nil)
+		req.Header.Set(auth.HeaderAPIKey, key)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+		suite.Equal(http.StatusOK, rec.Code)
+
+		req = suite.app.NewJSONRequest(http.MethodPut, "/customers/C1", map[string]interface{}{"name": "John Doe"})
+		req.Header.Set(auth.HeaderAPIKey, key)
+		rec = httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("John Doe", suite.app.CustomerRepository.Customers["C1"].Name)
+	})
+}
+
+func TestCustomerHandler(t *testing.T) {
+	suite.Run(t, new(TestCustomerHandlerSuite))
+}
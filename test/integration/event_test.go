@@ -1,10 +1,16 @@
 package integration
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/problem"
 	"simple-bank/test/support"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -36,7 +42,7 @@ func (suite *TestEventHandlerSuite) Test_POST_Event_Deposit() {
 	})
 
 	suite.Run("Should deposit amount when account exists", func() {
-		suite.app.AccountRepository.SaveAccount(entity.NewAccount("100", 100))
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
 
 		body := map[string]interface{}{
 			"type":        "deposit",
@@ -53,6 +59,36 @@ func (suite *TestEventHandlerSuite) Test_POST_Event_Deposit() {
 	})
 }
 
+func (suite *TestEventHandlerSuite) Test_POST_Event_Subscribers() {
+	suite.Run("Should move money even when subscribers fail", func() {
+		handled := make(chan event.Type, 10)
+		suite.app.Bus.Subscribe("failing", event.Sync, func(ctx context.Context, e event.Event) error {
+			return errors.New("boom")
+		})
+		suite.app.Bus.Subscribe("panicking", event.Async, func(ctx context.Context, e event.Event) error {
+			panic("boom")
+		})
+		suite.app.Bus.Subscribe("handled", event.Async, func(ctx context.Context, e event.Event) error {
+			handled <- e.Type
+			return nil
+		})
+
+		body := map[string]interface{}{
+			"type":        "deposit",
+			"destination": "100",
+			"amount":      100,
+		}
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, suite.app.NewJSONRequest(http.MethodPost, "/event", body))
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.Equal(100, suite.app.AccountRepository.Accounts["100"].Balance)
+		suite.app.Bus.Close()
+		suite.Equal(event.TypeAccountCreated, <-handled)
+		suite.Equal(event.TypeFundsDeposited, <-handled)
+	})
+}
+
 func (suite *TestEventHandlerSuite) Test_POST_Event_Withdraw() {
 	suite.Run("Should return 404 when account does not exist", func() {
 		body := map[string]interface{}{
@@ -66,11 +102,17 @@ func (suite *TestEventHandlerSuite) Test_POST_Event_Withdraw() {
 		suite.app.PerformRequest(rec, req)
 
 		suite.Equal(http.StatusNotFound, rec.Code)
-		suite.Equal("0", rec.Body.String())
+		suite.JSONEq(`{
+			"type": "/problems/account-not-found",
+			"title": "Account not found",
+			"status": 404,
+			"code": "account_not_found",
+			"instance": "/event"
+		}`, rec.Body.String())
 	})
 
 	suite.Run("Should withdraw amount when account exists", func() {
-		suite.app.AccountRepository.SaveAccount(entity.NewAccount("100", 100))
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
 
 		body := map[string]interface{}{
 			"type":   "withdraw",
@@ -87,6 +129,87 @@ func (suite *TestEventHandlerSuite) Test_POST_Event_Withdraw() {
 	})
 }
 
+func (suite *TestEventHandlerSuite) Test_POST_Event_BusinessFailures() {
+	suite.Run("Should return 422 with the balance when funds are insufficient", func() {
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 50))
+
+		body := map[string]interface{}{
+			"type":   "withdraw",
+			"origin": "100",
+			"amount": 100,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusUnprocessableEntity, rec.Code)
+		suite.JSONEq(`{
+			"type": "/problems/insufficient-funds",
+			"title": "Insufficient funds",
+			"status": 422,
+			"code": "insufficient_funds",
+			"instance": "/event",
+			"balance": 50,
+			"available_balance": 50
+		}`, rec.Body.String())
+		suite.Equal(1, suite.app.Problems.Count(problem.ClassBusiness))
+		suite.Equal(0, suite.app.Problems.Count(problem.ClassServer))
+	})
+
+	suite.Run("Should return 422 when account is frozen", func() {
+		account := entity.NewAccount("100", 50)
+		account.Status = entity.AccountStatusFrozen
+		suite.app.AccountRepository.SaveAccount(context.Background(), account)
+
+		body := map[string]interface{}{
+			"type":        "transfer",
+			"origin":      "100",
+			"destination": "200",
+			"amount":      10,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusUnprocessableEntity, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"account_frozen"`)
+	})
+
+	suite.Run("Should return 400 when amount is invalid", func() {
+		body := map[string]interface{}{"amount": -10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/v2/accounts/100/deposits", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"validation_failed"`)
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+
+	suite.Run("Should return 422 when the monthly withdrawal limit is reached", func() {
+		account := entity.NewAccount("100", 100)
+		account.ProductID = "savings"
+		suite.app.AccountRepository.SaveAccount(context.Background(), account)
+
+		var rec *httptest.ResponseRecorder
+		for i := 0; i < 3; i++ {
+			body := map[string]interface{}{
+				"type":   "withdraw",
+				"origin": "100",
+				"amount": 1,
+			}
+			rec = httptest.NewRecorder()
+			suite.app.PerformRequest(rec, suite.app.NewJSONRequest(http.MethodPost, "/event", body))
+		}
+
+		suite.Equal(http.StatusUnprocessableEntity, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"withdrawal_limit_exceeded"`)
+	})
+}
+
 func (suite *TestEventHandlerSuite) Test_POST_Event_Transfer() {
 	suite.Run("Should return 404 when origin account does not exist", func() {
 		body := map[string]interface{}{
@@ -101,11 +224,17 @@ func (suite *TestEventHandlerSuite) Test_POST_Event_Transfer() {
 		suite.app.PerformRequest(rec, req)
 
 		suite.Equal(http.StatusNotFound, rec.Code)
-		suite.Equal("0", rec.Body.String())
+		suite.JSONEq(`{
+			"type": "/problems/account-not-found",
+			"title": "Account not found",
+			"status": 404,
+			"code": "account_not_found",
+			"instance": "/event"
+		}`, rec.Body.String())
 	})
 
 	suite.Run("Should create destination account when does not exist", func() {
-		suite.app.AccountRepository.SaveAccount(entity.NewAccount("100", 100))
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
 
 		body := map[string]interface{}{
 			"type":        "transfer",
@@ -123,8 +252,8 @@ func (suite *TestEventHandlerSuite) Test_POST_Event_Transfer() {
 	})
 
 	suite.Run("Should transfer amount when accounts exist", func() {
-		suite.app.AccountRepository.SaveAccount(entity.NewAccount("100", 100))
-		suite.app.AccountRepository.SaveAccount(entity.NewAccount("200", 100))
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("200", 100))
 
 		body := map[string]interface{}{
 			"type":        "transfer",
@@ -140,6 +269,107 @@ func (suite *TestEventHandlerSuite) Test_POST_Event_Transfer() {
 		suite.Equal(http.StatusCreated, rec.Code)
 		suite.JSONEq(`{"origin": {"id": "100", "balance": 0}, "destination": {"id": "200", "balance": 200}}`, rec.Body.String())
 	})
+
+	suite.Run("Should return 403 when customer does not own origin account", func() {
+		suite.app = support.NewTestAppWithAuth()
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+		key := suite.app.CreateCustomerAPIKey("C1", []entity.Scope{entity.ScopeEventWrite})
+
+		body := map[string]interface{}{
+			"type":        "transfer",
+			"origin":      "100",
+			"destination": "200",
+			"amount":      100,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		req.Header.Set(auth.HeaderAPIKey, key)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"account_not_owned"`)
+		suite.Equal(100, suite.app.AccountRepository.Accounts["100"].Balance)
+	})
+
+	suite.Run("Should ignore a customer sent by the client", func() {
+		suite.app = support.NewTestAppWithAuth()
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+		owner := entity.NewCustomer("C2", "Jane", "", "")
+		owner.LinkAccount("100")
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), owner)
+		key := suite.app.CreateCustomerAPIKey("C1", []entity.Scope{entity.ScopeEventWrite})
+
+		body := map[string]interface{}{
+			"type":        "transfer",
+			"origin":      "100",
+			"destination": "200",
+			"amount":      100,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		req.Header.Set(auth.HeaderAPIKey, key)
+		req.Header.Set("X-Customer-ID", "C2")
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Equal(100, suite.app.AccountRepository.Accounts["100"].Balance)
+	})
+
+	suite.Run("Should transfer amount when customer owns origin account", func() {
+		suite.app = support.NewTestAppWithAuth()
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+		customer := entity.NewCustomer("C1", "John", "", "")
+		customer.LinkAccount("100")
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), customer)
+		key := suite.app.CreateCustomerAPIKey("C1", []entity.Scope{entity.ScopeEventWrite})
+
+		body := map[string]interface{}{
+			"type":        "transfer",
+			"origin":      "100",
+			"destination": "200",
+			"amount":      100,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		req.Header.Set(auth.HeaderAPIKey, key)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+	})
+
+	suite.Run("Should conserve the total balance across concurrent transfers", func() {
+		ids := []string{"100", "200", "300"}
+		for _, id := range ids {
+			suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount(id, 1000))
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 150; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				body := map[string]interface{}{
+					"type":        "transfer",
+					"origin":      ids[i%3],
+					"destination": ids[(i+1)%3],
+					"amount":      i%7 + 1,
+				}
+				rec := httptest.NewRecorder()
+				suite.app.PerformRequest(rec, suite.app.NewJSONRequest(http.MethodPost, "/event", body))
+				suite.Equal(http.StatusCreated, rec.Code)
+			}()
+		}
+		wg.Wait()
+
+		_, total, err := suite.app.AccountRepository.Totals(context.Background())
+		suite.Require().NoError(err)
+		suite.Equal(3000, total)
+	})
 }
 
 func TestEventHandler(t *testing.T) {
@@ -0,0 +1,409 @@
+package integration
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	appGrpc "simple-bank/internal/infrastructure/grpc"
+	"simple-bank/internal/infrastructure/grpc/bankv1"
+	appHttp "simple-bank/internal/infrastructure/http"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/internal/infrastructure/http/ratelimit"
+	"simple-bank/internal/usecase/account"
+	"simple-bank/test/support"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+)
+
+type TestGRPCSuite struct {
+	suite.Suite
+	app    *support.TestApp
+	conn   *grpc.ClientConn
+	close  func()
+	client bankv1.AccountServiceClient
+}
+
+func (suite *TestGRPCSuite) SetupSubTest() {
+	suite.start(support.NewTestApp())
+}
+
+func (suite *TestGRPCSuite) TearDownSubTest() {
+	suite.close()
+}
+
+func (suite *TestGRPCSuite) start(app *support.TestApp) {
+	if suite.close != nil {
+		suite.close()
+	}
+	suite.app = app
+	suite.conn, suite.close = app.ServeGRPC()
+	suite.client = bankv1.NewAccountServiceClient(suite.conn)
+}
+
+func (suite *TestGRPCSuite) context(pairs ...string) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	suite.T().Cleanup(cancel)
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+func (suite *TestGRPCSuite) deposit(destination string, amount int64) {
+	_, err := suite.client.Deposit(suite.context(), &bankv1.DepositRequest{Destination: destination, Amount: amount})
+	suite.Require().NoError(err)
+}
+
+// requireStatus checks the code of err and the problem code it carries.
+func (suite *TestGRPCSuite) requireStatus(err error, code codes.Code, reason string) *status.Status {
+	s, ok := status.FromError(err)
+	suite.Require().True(ok)
+	suite.Require().Equal(code, s.Code(), s.Message())
+
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			suite.Equal(reason, info.Reason)
+			suite.Equal(appGrpc.ErrorDomain, info.Domain)
+			return s
+		}
+	}
+	suite.Fail("missing error info")
+	return s
+}
+
+func (suite *TestGRPCSuite) Test_MoneyMovements() {
+	suite.Run("Should deposit, withdraw and transfer", func() {
+		deposit, err := suite.client.Deposit(suite.context(), &bankv1.DepositRequest{Destination: "100", Amount: 50})
+		suite.Require().NoError(err)
+		suite.Equal("100", deposit.Destination.Id)
+		suite.EqualValues(50, deposit.Destination.Balance)
+
+		withdraw, err := suite.client.Withdraw(suite.context(), &bankv1.WithdrawRequest{Origin: "100", Amount: 5})
+		suite.Require().NoError(err)
+		suite.EqualValues(45, withdraw.Origin.Balance)
+
+		transfer, err := suite.client.Transfer(suite.context(), &bankv1.TransferRequest{
+			Origin:      "100",
+			Destination: "300",
+			Amount:      15,
+		})
+		suite.Require().NoError(err)
+		suite.EqualValues(30, transfer.Origin.Balance)
+		suite.Equal("300", transfer.Destination.Id)
+		suite.EqualValues(15, transfer.Destination.Balance)
+
+		balance, err := suite.client.GetBalance(suite.context(), &bankv1.GetBalanceRequest{AccountId: "300"})
+		suite.Require().NoError(err)
+		suite.EqualValues(15, balance.Balance)
+	})
+
+	suite.Run("Should audit the money movements", func() {
+		suite.deposit("100", 50)
+		_, err := suite.client.Withdraw(suite.context(), &bankv1.WithdrawRequest{Origin: "100", Amount: 80})
+		suite.Require().Error(err)
+
+		entries := suite.app.AuditEntries()
+		suite.Require().Len(entries, 2)
+		suite.Equal(audit.ActionDeposit, entries[0].Action)
+		suite.Equal(audit.OutcomeSuccess, entries[0].Outcome)
+		suite.Equal([]audit.BalanceChange{{Account: "100", Before: 0, After: 50}}, entries[0].Balances)
+		suite.NotEmpty(entries[0].RequestID)
+		suite.Equal(audit.ActionWithdraw, entries[1].Action)
+		suite.Equal(audit.OutcomeRejected, entries[1].Outcome)
+		suite.Equal("insufficient_funds", entries[1].Detail)
+	})
+
+	suite.Run("Should echo the request ID", func() {
+		var header metadata.MD
+		_, err := suite.client.Deposit(
+			suite.context(appGrpc.MetadataRequestID, "req-1"),
+			&bankv1.DepositRequest{Destination: "100", Amount: 10},
+			grpc.Header(&header),
+		)
+		suite.Require().NoError(err)
+		suite.Equal([]string{"req-1"}, header.Get(appGrpc.MetadataRequestID))
+		suite.Equal("req-1", suite.app.AuditEntries()[0].RequestID)
+	})
+}
+
+func (suite *TestGRPCSuite) Test_Errors() {
+	suite.Run("Should answer unknown accounts with NotFound", func() {
+		_, err := suite.client.GetBalance(suite.context(), &bankv1.GetBalanceRequest{AccountId: "404"})
+		suite.requireStatus(err, codes.NotFound, "account_not_found")
+	})
+
+	suite.Run("Should answer insufficient funds with FailedPrecondition and the balances", func() {
+		suite.deposit("100", 10)
+
+		_, err := suite.client.Withdraw(suite.context(), &bankv1.WithdrawRequest{Origin: "100", Amount: 20})
+		s := suite.requireStatus(err, codes.FailedPrecondition, "insufficient_funds")
+		info := s.Details()[0].(*errdetails.ErrorInfo)
+		suite.Equal("10", info.Metadata["balance"])
+	})
+
+	suite.Run("Should answer invalid requests with InvalidArgument and the invalid fields", func() {
+		_, err := suite.client.Deposit(suite.context(), &bankv1.DepositRequest{Destination: "bad id", Amount: 0})
+		s := suite.requireStatus(err, codes.InvalidArgument, "validation_failed")
+
+		var fields []string
+		for _, detail := range s.Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				for _, violation := range badRequest.FieldViolations {
+					fields = append(fields, violation.Field)
+				}
+			}
+		}
+		suite.ElementsMatch([]string{"destination", "amount"}, fields)
+	})
+
+	suite.Run("Should answer transfers from accounts not owned by the customer with PermissionDenied", func() {
+		suite.start(support.NewTestAppWithAuth())
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 10))
+		key := suite.app.CreateCustomerAPIKey("unknown", []entity.Scope{entity.ScopeEventWrite})
+
+		_, err := suite.client.Transfer(suite.context("x-api-key", key), &bankv1.TransferRequest{
+			Origin:      "100",
+			Destination: "300",
+			Amount:      5,
+		})
+		suite.requireStatus(err, codes.PermissionDenied, "account_not_owned")
+	})
+}
+
+func (suite *TestGRPCSuite) Test_Auth() {
+	suite.Run("Should refuse calls without credentials", func() {
+		suite.start(support.NewTestAppWithAuth())
+
+		_, err := suite.client.GetBalance(suite.context(), &bankv1.GetBalanceRequest{AccountId: "100"})
+		suite.requireStatus(err, codes.Unauthenticated, "unauthenticated")
+	})
+
+	suite.Run("Should refuse invalid credentials", func() {
+		suite.start(support.NewTestAppWithAuth())
+
+		_, err := suite.client.GetBalance(
+			suite.context("x-api-key", "wrong"),
+			&bankv1.GetBalanceRequest{AccountId: "100"},
+		)
+		suite.requireStatus(err, codes.Unauthenticated, "invalid_credentials")
+	})
+
+	suite.Run("Should check the scope and the accounts of API keys", func() {
+		suite.start(support.NewTestAppWithAuth())
+		reader := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead})
+		writer := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeEventWrite}, "100")
+
+		_, err := suite.client.Deposit(suite.context("x-api-key", reader), &bankv1.DepositRequest{Destination: "100", Amount: 1})
+		suite.requireStatus(err, codes.PermissionDenied, "insufficient_scope")
+
+		_, err = suite.client.Deposit(suite.context("x-api-key", writer), &bankv1.DepositRequest{Destination: "200", Amount: 1})
+		suite.requireStatus(err, codes.PermissionDenied, "account_forbidden")
+
+		_, err = suite.client.Deposit(suite.context("x-api-key", writer), &bankv1.DepositRequest{Destination: "100", Amount: 1})
+		suite.Require().NoError(err)
+		suite.Equal("api-key:"+writer, suite.app.AuditEntries()[2].Actor)
+	})
+
+	suite.Run("Should accept bearer tokens", func() {
+		suite.start(support.NewTestAppWithAuth())
+		token := support.MintToken("client", []entity.Scope{entity.ScopeEventWrite}, "100")
+
+		_, err := suite.client.Deposit(
+			suite.context("authorization", "Bearer "+token),
+			&bankv1.DepositRequest{Destination: "100", Amount: 1},
+		)
+		suite.Require().NoError(err)
+	})
+
+	suite.Run("Should serve health without credentials", func() {
+		suite.start(support.NewTestAppWithAuth())
+
+		res, err := healthpb.NewHealthClient(suite.conn).Check(suite.context(), &healthpb.HealthCheckRequest{
+			Service: bankv1.AccountService_ServiceDesc.ServiceName,
+		})
+		suite.Require().NoError(err)
+		suite.Equal(healthpb.HealthCheckResponse_SERVING, res.Status)
+	})
+}
+
+func (suite *TestGRPCSuite) Test_Reset() {
+	suite.Run("Should reset with the confirmation token", func() {
+		suite.deposit("100", 10)
+
+		_, err := suite.client.Reset(
+			suite.context(appGrpc.MetadataResetConfirmation, support.TestResetToken),
+			&bankv1.ResetRequest{},
+		)
+		suite.Require().NoError(err)
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+
+	suite.Run("Should refuse resets without the confirmation token", func() {
+		suite.deposit("100", 10)
+
+		_, err := suite.client.Reset(suite.context(), &bankv1.ResetRequest{})
+		suite.requireStatus(err, codes.FailedPrecondition, "reset_not_confirmed")
+		suite.Len(suite.app.AccountRepository.Accounts, 1)
+		suite.Equal(audit.OutcomeDenied, suite.app.AuditEntries()[1].Outcome)
+	})
+}
+
+func (suite *TestGRPCSuite) Test_StreamAccountUpdates() {
+	suite.Run("Should stream the updates of an account", func() {
+		suite.deposit("100", 10)
+
+		updates, err := suite.client.StreamAccountUpdates(suite.context(), &bankv1.StreamAccountUpdatesRequest{AccountId: "100"})
+		suite.Require().NoError(err)
+		_, err = updates.Header()
+		suite.Require().NoError(err)
+
+		suite.deposit("200", 5)
+		suite.deposit("100", 5)
+
+		update, err := updates.Recv()
+		suite.Require().NoError(err)
+		suite.Equal(suite.app.Broker.Epoch()+"-3", update.Id)
+		suite.Equal("funds.deposited", update.EventType)
+		suite.Equal("100", update.Account)
+		suite.EqualValues(15, update.Balance)
+		suite.False(update.OccurredAt.AsTime().IsZero())
+	})
+
+	suite.Run("Should resume after last_id", func() {
+		suite.deposit("100", 10)
+		suite.deposit("100", 10)
+
+		updates, err := suite.client.StreamAccountUpdates(suite.context(), &bankv1.StreamAccountUpdatesRequest{
+			AccountId: "100",
+			LastId:    suite.app.Broker.Epoch() + "-1",
+		})
+		suite.Require().NoError(err)
+
+		update, err := updates.Recv()
+		suite.Require().NoError(err)
+		suite.Equal(suite.app.Broker.Epoch()+"-2", update.Id)
+		suite.EqualValues(20, update.Balance)
+	})
+
+	suite.Run("Should require a reset when last_id cannot be resumed", func() {
+		suite.deposit("100", 10)
+
+		updates, err := suite.client.StreamAccountUpdates(suite.context(), &bankv1.StreamAccountUpdatesRequest{
+			AccountId: "100",
+			LastId:    "1-1",
+		})
+		suite.Require().NoError(err)
+
+		update, err := updates.Recv()
+		suite.Require().NoError(err)
+		suite.True(update.ResetRequired)
+		suite.Equal(suite.app.Broker.Epoch()+"-1", update.Id)
+	})
+
+	suite.Run("Should require admin:stream to stream every account", func() {
+		suite.start(support.NewTestAppWithAuth())
+		reader := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead})
+
+		updates, err := suite.client.StreamAccountUpdates(
+			suite.context("x-api-key", reader),
+			&bankv1.StreamAccountUpdatesRequest{},
+		)
+		suite.Require().NoError(err)
+		_, err = updates.Recv()
+		suite.requireStatus(err, codes.PermissionDenied, "insufficient_scope")
+	})
+
+	suite.Run("Should end the streams when the server stops", func() {
+		updates, err := suite.client.StreamAccountUpdates(suite.context(), &bankv1.StreamAccountUpdatesRequest{})
+		suite.Require().NoError(err)
+		_, err = updates.Header()
+		suite.Require().NoError(err)
+		suite.deposit("100", 10)
+		_, err = updates.Recv()
+		suite.Require().NoError(err)
+
+		suite.Require().NoError(suite.app.GRPCServer.Stop(suite.context()))
+
+		_, err = updates.Recv()
+		suite.NotEqual(io.EOF, err)
+		suite.Equal(codes.Unavailable, status.Code(err))
+	})
+}
+
+func (suite *TestGRPCSuite) Test_Recovery() {
+	suite.Run("Should answer panics with Internal and keep serving", func() {
+		app := support.NewTestApp()
+		broken := appGrpc.NewAccountService(account.NewGetBalanceUseCase(nil), nil, nil, nil, app.Broker)
+		app.GRPCServer = appGrpc.NewGRPCServer(appGrpc.GRPCServerConfig{}, broken)
+		suite.start(app)
+
+		for i := 0; i < 2; i++ {
+			_, err := suite.client.GetBalance(suite.context(), &bankv1.GetBalanceRequest{AccountId: "100"})
+			s := suite.requireStatus(err, codes.Internal, "internal_error")
+			suite.NotContains(s.Message(), "nil pointer")
+		}
+	})
+}
+
+func (suite *TestGRPCSuite) Test_RateLimit() {
+	suite.Run("Should take from the buckets of the HTTP API", func() {
+		suite.start(support.NewTestAppWithAuthConfig(appHttp.HTTPServerConfig{
+			Port: "3000",
+			RateLimit: &ratelimit.Config{
+				Store: ratelimit.NewMemoryStore(),
+				Key:   ratelimit.KeyByCredential,
+				Read:  ratelimit.Limit{Rate: 0.01, Burst: 1},
+				Write: ratelimit.Limit{Rate: 0.01, Burst: 1},
+			},
+		}))
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead, entity.ScopeEventWrite})
+
+		req := httptest.NewRequest(http.MethodGet, "/balance?account_id=100", nil)
+		req.Header.Set(auth.HeaderAPIKey, key)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+		suite.Require().Equal(http.StatusOK, rec.Code)
+
+		_, err := suite.client.GetBalance(suite.context("x-api-key", key), &bankv1.GetBalanceRequest{AccountId: "100"})
+		suite.requireStatus(err, codes.ResourceExhausted, "rate_limited")
+
+		_, err = suite.client.Withdraw(suite.context("x-api-key", key), &bankv1.WithdrawRequest{Origin: "100", Amount: 10})
+		suite.Require().NoError(err)
+		_, err = suite.client.Withdraw(suite.context("x-api-key", key), &bankv1.WithdrawRequest{Origin: "100", Amount: 10})
+		suite.requireStatus(err, codes.ResourceExhausted, "rate_limited")
+		suite.Equal(90, suite.app.AccountRepository.Accounts["100"].Balance)
+	})
+}
+
+func (suite *TestGRPCSuite) Test_Reflection() {
+	suite.Run("Should list the services", func() {
+		stream, err := reflectionpb.NewServerReflectionClient(suite.conn).ServerReflectionInfo(suite.context())
+		suite.Require().NoError(err)
+		suite.Require().NoError(stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}))
+
+		res, err := stream.Recv()
+		suite.Require().NoError(err)
+		var services []string
+		for _, service := range res.GetListServicesResponse().Service {
+			services = append(services, service.Name)
+		}
+		suite.Contains(services, bankv1.AccountService_ServiceDesc.ServiceName)
+		suite.Contains(services, healthpb.Health_ServiceDesc.ServiceName)
+	})
+}
+
+func TestGRPC(t *testing.T) {
+	suite.Run(t, new(TestGRPCSuite))
+}
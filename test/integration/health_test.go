@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/infrastructure/health"
+	appHttp "simple-bank/internal/infrastructure/http"
+	"simple-bank/test/support"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestHealthSuite struct {
+	suite.Suite
+}
+
+func (suite *TestHealthSuite) get(app *support.TestApp, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	app.PerformRequest(rec, req)
+	return rec
+}
+
+func (suite *TestHealthSuite) Test_Probes() {
+	suite.Run("Should report liveness", func() {
+		rec := suite.get(support.NewTestApp(), "/healthz")
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{"status":"ok"}`, rec.Body.String())
+	})
+
+	suite.Run("Should report ready when the repositories are reachable", func() {
+		rec := suite.get(support.NewTestApp(), "/readyz")
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{"status":"ready","checks":{"account_repository":"ok"}}`, rec.Body.String())
+	})
+
+	suite.Run("Should report not ready when a check fails", func() {
+		app := support.NewTestAppWithConfig(appHttp.HTTPServerConfig{
+			Readiness: health.NewReadiness(0, health.Check{
+				Name:  "account_repository",
+				Check: func(ctx context.Context) error { return errors.New("unreachable") },
+			}),
+		})
+
+		rec := suite.get(app, "/readyz")
+
+		suite.Equal(http.StatusServiceUnavailable, rec.Code)
+		suite.JSONEq(`{"status":"not_ready","checks":{"account_repository":"unreachable"}}`, rec.Body.String())
+	})
+
+	suite.Run("Should report not ready as soon as the server stops", func() {
+		app := support.NewTestAppWithConfig(appHttp.HTTPServerConfig{ShutdownDelay: time.Second})
+
+		stopped := make(chan error)
+		go func() { stopped <- app.HTTPServer.Stop(context.Background()) }()
+
+		suite.Eventually(func() bool {
+			return suite.get(app, "/readyz").Code == http.StatusServiceUnavailable
+		}, 500*time.Millisecond, 10*time.Millisecond)
+		suite.Contains(suite.get(app, "/readyz").Body.String(), `"shutting_down"`)
+		suite.Equal(http.StatusOK, suite.get(app, "/healthz").Code)
+		suite.NoError(<-stopped)
+	})
+}
+
+func TestHealth(t *testing.T) {
+	suite.Run(t, new(TestHealthSuite))
+}
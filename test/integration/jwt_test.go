@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestJWTSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestJWTSuite) SetupSubTest() {
+	suite.app = support.NewTestAppWithAuth()
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("200", 100))
+}
+
+func (suite *TestJWTSuite) getBalance(accountID, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/balance?account_id="+accountID, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, req)
+	return rec
+}
+
+func (suite *TestJWTSuite) postEvent(body map[string]interface{}, token string) *httptest.ResponseRecorder {
+	req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, req)
+	return rec
+}
+
+func (suite *TestJWTSuite) Test_Authentication() {
+	suite.Run("Should accept HS256 tokens", func() {
+		token := support.MintToken("alice", []entity.Scope{entity.ScopeBalanceRead}, "100")
+
+		rec := suite.getBalance("100", token)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Equal("100", rec.Body.String())
+	})
+
+	suite.Run("Should accept RS256 tokens signed with a JWKS key", func() {
+		token := support.MintRS256Token("alice", []entity.Scope{entity.ScopeBalanceRead}, "100")
+
+		suite.Equal(http.StatusOK, suite.getBalance("100", token).Code)
+	})
+
+	suite.Run("Should return 401 with a tampered token", func() {
+		token := support.MintToken("alice", []entity.Scope{entity.ScopeBalanceRead}, "100")
+
+		rec := suite.getBalance("100", token+"x")
+
+		suite.Equal(http.StatusUnauthorized, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"invalid_credentials"`)
+	})
+
+	suite.Run("Should return 403 when token lacks the scope", func() {
+		token := support.MintToken("alice", []entity.Scope{entity.ScopeEventWrite}, "100")
+
+		suite.Equal(http.StatusForbidden, suite.getBalance("100", token).Code)
+	})
+}
+
+func (suite *TestJWTSuite) Test_AccountClaims() {
+	scopes := []entity.Scope{entity.ScopeBalanceRead, entity.ScopeEventWrite}
+
+	suite.Run("Should refuse reading accounts outside the token", func() {
+		token := support.MintToken("alice", scopes, "100")
+
+		rec := suite.getBalance("200", token)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"account_forbidden"`)
+	})
+
+	suite.Run("Should refuse withdrawals from accounts outside the token", func() {
+		token := support.MintToken("alice", scopes, "100")
+
+		rec := suite.postEvent(map[string]interface{}{"type": "withdraw", "origin": "200", "amount": 50}, token)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Equal(100, suite.app.AccountRepository.Accounts["200"].Balance)
+	})
+
+	suite.Run("Should refuse transfers from accounts outside the token", func() {
+		token := support.MintToken("alice", scopes, "100")
+
+		rec := suite.postEvent(map[string]interface{}{"type": "transfer", "origin": "200", "destination": "100", "amount": 50}, token)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Equal(100, suite.app.AccountRepository.Accounts["200"].Balance)
+	})
+
+	suite.Run("Should refuse every account when the token has none", func() {
+		token := support.MintToken("alice", scopes)
+
+		suite.Equal(http.StatusForbidden, suite.getBalance("100", token).Code)
+	})
+
+	suite.Run("Should allow transfers from accounts in the token", func() {
+		token := support.MintRS256Token("alice", scopes, "100")
+
+		rec := suite.postEvent(map[string]interface{}{"type": "transfer", "origin": "100", "destination": "200", "amount": 50}, token)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.Equal(150, suite.app.AccountRepository.Accounts["200"].Balance)
+	})
+}
+
+func TestJWT(t *testing.T) {
+	suite.Run(t, new(TestJWTSuite))
+}
@@ -0,0 +1,98 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/shared/logging"
+	"simple-bank/test/support"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestLoggingSuite struct {
+	suite.Suite
+	app      *support.TestApp
+	logs     *bytes.Buffer
+	previous *slog.Logger
+}
+
+func (suite *TestLoggingSuite) SetupSubTest() {
+	suite.logs = &bytes.Buffer{}
+	suite.previous = slog.Default()
+	slog.SetDefault(slog.New(logging.NewHandler(suite.logs, logging.Options{Level: slog.LevelDebug})))
+
+	suite.app = support.NewTestApp()
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+}
+
+func (suite *TestLoggingSuite) TearDownSubTest() {
+	slog.SetDefault(suite.previous)
+}
+
+func (suite *TestLoggingSuite) lines(msg string) []map[string]any {
+	var lines []map[string]any
+	for _, raw := range strings.Split(strings.TrimSpace(suite.logs.String()), "\n") {
+		var line map[string]any
+		suite.Require().NoError(json.Unmarshal([]byte(raw), &line))
+		if line["msg"] == msg {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func (suite *TestLoggingSuite) Test_RequestID() {
+	suite.Run("Should keep the request ID sent by the client", func() {
+		req := httptest.NewRequest(http.MethodGet, "/balance?account_id=100", nil)
+		req.Header.Set("X-Request-ID", "client-id-1")
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal("client-id-1", rec.Header().Get("X-Request-ID"))
+		suite.Equal("client-id-1", suite.lines("request")[0]["request_id"])
+	})
+
+	suite.Run("Should generate a request ID when missing or malformed", func() {
+		req := httptest.NewRequest(http.MethodGet, "/balance?account_id=100", nil)
+		req.Header.Set("X-Request-ID", "not valid\n")
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Len(rec.Header().Get("X-Request-ID"), 32)
+	})
+
+	suite.Run("Should log operations and repository calls with the request ID", func() {
+		body := map[string]interface{}{"type": "transfer", "origin": "100", "destination": "200", "amount": 500}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		req.Header.Set("X-Request-ID", "transfer-1")
+
+		suite.app.PerformRequest(httptest.NewRecorder(), req)
+
+		operations := suite.lines("operation")
+		suite.Require().Len(operations, 1)
+		suite.Equal("transfer-1", operations[0]["request_id"])
+		suite.Equal("transfer", operations[0]["event_type"])
+		suite.Equal("100", operations[0]["origin"])
+		suite.Equal("200", operations[0]["destination"])
+		suite.Equal(float64(500), operations[0]["amount"])
+		suite.Equal("rejected", operations[0]["outcome"])
+		suite.Equal("WARN", operations[0]["level"])
+
+		calls := suite.lines("repository call")
+		suite.Require().NotEmpty(calls)
+		suite.Equal("transfer-1", calls[0]["request_id"])
+	})
+}
+
+func TestLogging(t *testing.T) {
+	suite.Run(t, new(TestLoggingSuite))
+}
@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestMetricsSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestMetricsSuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+}
+
+func (suite *TestMetricsSuite) scrape() string {
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, req)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func (suite *TestMetricsSuite) postEvent(body map[string]interface{}) {
+	req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+	suite.app.PerformRequest(httptest.NewRecorder(), req)
+}
+
+func (suite *TestMetricsSuite) Test_Metrics() {
+	suite.Run("Should count requests by route and status", func() {
+		req := httptest.NewRequest(http.MethodGet, "/balance?account_id=100", nil)
+		suite.app.PerformRequest(httptest.NewRecorder(), req)
+		req = httptest.NewRequest(http.MethodGet, "/balance?account_id=unknown", nil)
+		suite.app.PerformRequest(httptest.NewRecorder(), req)
+
+		body := suite.scrape()
+
+		suite.Contains(body, `http_requests_total{method="GET",route="/balance",status="200"} 1`)
+		suite.Contains(body, `http_requests_total{method="GET",route="/balance",status="404"} 1`)
+		suite.Contains(body, `http_request_duration_seconds_count{method="GET",route="/balance",status="200"} 1`)
+		suite.Contains(body, `http_problems_total{class="client",code="account_not_found"} 1`)
+	})
+
+	suite.Run("Should count operations by outcome", func() {
+		suite.postEvent(map[string]interface{}{"type": "deposit", "destination": "100", "amount": 50})
+		suite.postEvent(map[string]interface{}{"type": "withdraw", "origin": "100", "amount": 1000})
+
+		body := suite.scrape()
+
+		suite.Contains(body, `bank_operations_total{operation="deposit",outcome="success"} 1`)
+		suite.Contains(body, `bank_operations_total{operation="withdraw",outcome="rejected"} 1`)
+		suite.Contains(body, `bank_operation_amount_sum{operation="deposit"} 50`)
+	})
+
+	suite.Run("Should count the committed events by type", func() {
+		suite.postEvent(map[string]interface{}{"type": "deposit", "destination": "100", "amount": 50})
+		suite.postEvent(map[string]interface{}{"type": "transfer", "origin": "100", "destination": "300", "amount": 10})
+		suite.postEvent(map[string]interface{}{"type": "withdraw", "origin": "100", "amount": 1000})
+
+		// events are counted asynchronously, closing the bus waits for them.
+		suite.app.Bus.Close()
+		body := suite.scrape()
+
+		suite.Contains(body, `bank_events_total{type="funds.deposited"} 1`)
+		suite.Contains(body, `bank_events_total{type="account.created"} 1`)
+		suite.Contains(body, `bank_events_total{type="transfer.completed"} 1`)
+		suite.NotContains(body, `bank_events_total{type="funds.withdrawn"}`)
+	})
+
+	suite.Run("Should report the accounts and the money they hold", func() {
+		suite.postEvent(map[string]interface{}{"type": "deposit", "destination": "200", "amount": 50})
+
+		body := suite.scrape()
+
+		suite.Contains(body, "bank_accounts 2")
+		suite.Contains(body, "bank_money_under_management 150")
+	})
+
+	suite.Run("Should time repository calls", func() {
+		suite.postEvent(map[string]interface{}{"type": "deposit", "destination": "100", "amount": 50})
+
+		body := suite.scrape()
+
+		suite.Contains(body, `repository_operation_duration_seconds_count{operation="get_account_by_id",repository="account",result="ok"} 1`)
+		suite.Contains(body, `repository_operation_duration_seconds_count{operation="update_account",repository="account",result="ok"} 1`)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	suite.Run(t, new(TestMetricsSuite))
+}
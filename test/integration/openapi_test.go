@@ -0,0 +1,209 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"simple-bank/internal/infrastructure/health"
+	handlers "simple-bank/internal/infrastructure/http/handler"
+	handlersV2 "simple-bank/internal/infrastructure/http/handler/v2"
+	"simple-bank/internal/infrastructure/http/openapi"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/test/support"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type openAPISchema struct {
+	Type       string                   `json:"type"`
+	Ref        string                   `json:"$ref"`
+	Properties map[string]openAPISchema `json:"properties"`
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// documentedTypes lists every type exchanged through the API with the name
+// of the schema describing it.
+var documentedTypes = map[string]any{
+	"Problem":                  problem.Problem{},
+	"APIKey":                   dto.APIKeyDTO{},
+	"CreateAPIKeyRequest":      handlers.CreateAPIKeyRequest{},
+	"APIKeySecretResponse":     handlers.APIKeySecretResponse{},
+	"FieldError":               problem.FieldError{},
+	"Account":                  dto.AccountDTO{},
+	"AccountDetails":           dto.AccountDetailsDTO{},
+	"Customer":                 dto.CustomerDTO{},
+	"FeeSchedule":              dto.FeeScheduleDTO{},
+	"Product":                  dto.ProductDTO{},
+	"HandleEventRequest":       handlers.HandleEventRequest{},
+	"HandleEventResponse":      handlers.HandleEventResponse{},
+	"ListAccountsResponse":     handlers.ListAccountsResponse{},
+	"CustomerRequest":          handlers.CustomerRequest{},
+	"LinkAccountRequest":       handlers.LinkAccountRequest{},
+	"KYCStatusRequest":         handlers.KYCStatusRequest{},
+	"CustomerAccountsResponse": handlers.CustomerAccountsResponse{},
+	"ListProductsResponse":     handlers.ListProductsResponse{},
+	"DepositRequest":           handlersV2.DepositRequest{},
+	"DepositResponse":          handlersV2.DepositResponse{},
+	"WithdrawalRequest":        handlersV2.WithdrawalRequest{},
+	"WithdrawalResponse":       handlersV2.WithdrawalResponse{},
+	"TransferRequest":          handlersV2.TransferRequest{},
+	"TransferResponse":         handlersV2.TransferResponse{},
+	"HealthReport":             health.Report{},
+	"Webhook":                  dto.WebhookDTO{},
+	"WebhookDelivery":          dto.WebhookDeliveryDTO{},
+	"CreateWebhookRequest":     handlers.CreateWebhookRequest{},
+	"ListWebhooksResponse":     handlers.ListWebhooksResponse{},
+	"ListDeliveriesResponse":   handlers.ListDeliveriesResponse{},
+	"BalanceChange":            dto.BalanceChangeDTO{},
+	"WebSocketRequest":         handlers.WebSocketRequest{},
+	"WebSocketResponse":        handlers.WebSocketResponse{},
+}
+
+type TestOpenAPISuite struct {
+	suite.Suite
+	app *support.TestApp
+	doc openAPIDocument
+}
+
+func (suite *TestOpenAPISuite) SetupTest() {
+	suite.app = support.NewTestApp()
+	suite.Require().NoError(json.Unmarshal(openapi.Spec, &suite.doc))
+}
+
+func (suite *TestOpenAPISuite) Test_Spec() {
+	suite.Run("Should serve the document", func() {
+		req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(string(openapi.Spec), rec.Body.String())
+	})
+
+	suite.Run("Should serve the docs page", func() {
+		req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Contains(rec.Body.String(), "/openapi.json")
+		suite.NotContains(rec.Body.String(), "https://")
+	})
+
+	suite.Run("Should serve the docs assets from the binary", func() {
+		for path, contentType := range map[string]string{
+			"/docs/swagger-ui.css":       "text/css",
+			"/docs/swagger-ui-bundle.js": "text/javascript",
+		} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			rec := httptest.NewRecorder()
+
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusOK, rec.Code, path)
+			suite.Contains(rec.Header().Get(echo.HeaderContentType), contentType, path)
+			suite.NotEmpty(rec.Body.Bytes(), path)
+		}
+	})
+
+	suite.Run("Should document every registered route", func() {
+		registered := map[string]bool{}
+		for _, route := range suite.app.HTTPServer.Engine.Routes() {
+			// groups with middleware register catch all routes of their own
+			if strings.HasPrefix(route.Method, "echo_") {
+				continue
+			}
+			key := strings.ToLower(route.Method) + " " + openAPIPath(route.Path)
+			registered[key] = true
+			suite.Contains(suite.doc.Paths[openAPIPath(route.Path)], strings.ToLower(route.Method), "route %s is not documented", key)
+		}
+
+		for path, operations := range suite.doc.Paths {
+			for method := range operations {
+				if method == "parameters" {
+					continue
+				}
+				suite.True(registered[method+" "+path], "documented route %s %s is not registered", method, path)
+			}
+		}
+	})
+
+	suite.Run("Should document every field of the exchanged types", func() {
+		for name, value := range documentedTypes {
+			schema, ok := suite.doc.Components.Schemas[name]
+			if !suite.True(ok, "schema %s is not documented", name) {
+				continue
+			}
+
+			fields := jsonFields(reflect.TypeOf(value))
+			suite.Len(schema.Properties, len(fields), "schema %s does not match %T", name, value)
+			for field, kind := range fields {
+				property, ok := schema.Properties[field]
+				if suite.True(ok, "field %s of %T is not documented", field, value) && kind != "" {
+					suite.Equal(kind, property.Type, "field %s of %T has another type", field, value)
+				}
+			}
+		}
+	})
+}
+
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// jsonFields returns the JSON names of the fields of t with their OpenAPI type,
+// left empty for nested objects which are documented through references.
+func jsonFields(t reflect.Type) map[string]string {
+	fields := map[string]string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		switch fieldType.Kind() {
+		case reflect.String:
+			fields[name] = "string"
+		case reflect.Int, reflect.Int64:
+			fields[name] = "integer"
+		case reflect.Slice:
+			fields[name] = "array"
+		default:
+			fields[name] = ""
+		}
+	}
+	return fields
+}
+
+func TestOpenAPI(t *testing.T) {
+	suite.Run(t, new(TestOpenAPISuite))
+}
@@ -0,0 +1,130 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	appHttp "simple-bank/internal/infrastructure/http"
+	"simple-bank/test/support"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestProblemResponseSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestProblemResponseSuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+}
+
+func (suite *TestProblemResponseSuite) Test_ProblemResponse() {
+	suite.Run("Should return problem when event type is invalid", func() {
+		body := map[string]interface{}{"type": "unknown", "origin": "100", "destination": "200", "amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.JSONEq(`{
+			"type": "/problems/validation-failed",
+			"title": "Validation failed",
+			"status": 400,
+			"code": "validation_failed",
+			"instance": "/event",
+			"errors": [{"field": "type", "message": "must be one of: deposit, withdraw, transfer"}]
+		}`, rec.Body.String())
+	})
+
+	suite.Run("Should return problem when body is malformed", func() {
+		req := httptest.NewRequest(http.MethodPost, "/event", strings.NewReader(`{"type":`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"invalid_request_body"`)
+	})
+
+	suite.Run("Should return field errors when query parameters are invalid", func() {
+		req := httptest.NewRequest(http.MethodGet, "/accounts?limit=abc&min_balance=x", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.JSONEq(`{
+			"type": "/problems/validation-failed",
+			"title": "Validation failed",
+			"status": 400,
+			"code": "validation_failed",
+			"instance": "/accounts",
+			"errors": [
+				{"field": "limit", "message": "must be an integer"},
+				{"field": "min_balance", "message": "must be an integer"}
+			]
+		}`, rec.Body.String())
+	})
+
+	suite.Run("Should return problem when route does not exist", func() {
+		req := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNotFound, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"not_found"`)
+	})
+}
+
+func (suite *TestProblemResponseSuite) Test_LegacyErrors() {
+	suite.Run("Should return 0 when account does not exist on balance", func() {
+		suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", LegacyErrors: true})
+
+		req := httptest.NewRequest(http.MethodGet, "/balance?account_id=ID1", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNotFound, rec.Code)
+		suite.Equal("0", rec.Body.String())
+	})
+
+	suite.Run("Should return 0 when account does not exist on withdraw", func() {
+		suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", LegacyErrors: true})
+
+		body := map[string]interface{}{
+			"type":   "withdraw",
+			"origin": "100",
+			"amount": 100,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNotFound, rec.Code)
+		suite.Equal("0", rec.Body.String())
+	})
+
+	suite.Run("Should keep problem responses for other errors", func() {
+		suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", LegacyErrors: true})
+
+		body := map[string]interface{}{"type": "unknown"}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"validation_failed"`)
+	})
+}
+
+func TestProblemResponse(t *testing.T) {
+	suite.Run(t, new(TestProblemResponseSuite))
+}
@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestProductHandlerSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestProductHandlerSuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+}
+
+func (suite *TestProductHandlerSuite) Test_GET_Products() {
+	suite.Run("Should list the product catalog", func() {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{"products": [
+			{
+				"id": "business",
+				"name": "Business",
+				"overdraft_limit": 500,
+				"monthly_withdrawal_limit": 0,
+				"fees": {"deposit": 1, "withdrawal": 1, "transfer": 3}
+			},
+			{
+				"id": "checking",
+				"name": "Checking",
+				"overdraft_limit": 0,
+				"monthly_withdrawal_limit": 0,
+				"fees": {"deposit": 0, "withdrawal": 0, "transfer": 0}
+			},
+			{
+				"id": "savings",
+				"name": "Savings",
+				"overdraft_limit": 0,
+				"monthly_withdrawal_limit": 2,
+				"fees": {"deposit": 0, "withdrawal": 2, "transfer": 2}
+			}
+		]}`, rec.Body.String())
+	})
+}
+
+func (suite *TestProductHandlerSuite) Test_Product_Rules() {
+	suite.Run("Should open account with the requested product", func() {
+		body := map[string]interface{}{
+			"type":        "deposit",
+			"destination": "100",
+			"amount":      100,
+			"product":     "savings",
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.Equal("savings", suite.app.AccountRepository.Accounts["100"].ProductID)
+	})
+
+	suite.Run("Should return 400 when product does not exist", func() {
+		body := map[string]interface{}{
+			"type":        "deposit",
+			"destination": "100",
+			"amount":      100,
+			"product":     "gold",
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+
+	suite.Run("Should limit savings withdrawals per month", func() {
+		account := entity.NewAccount("100", 100)
+		account.ProductID = "savings"
+		suite.app.AccountRepository.SaveAccount(context.Background(), account)
+
+		codes := []int{}
+		for i := 0; i < 3; i++ {
+			body := map[string]interface{}{
+				"type":   "withdraw",
+				"origin": "100",
+				"amount": 10,
+			}
+			req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+			rec := httptest.NewRecorder()
+
+			suite.app.PerformRequest(rec, req)
+			codes = append(codes, rec.Code)
+		}
+
+		suite.Equal(http.StatusCreated, codes[0])
+		suite.Equal(http.StatusCreated, codes[1])
+		suite.NotEqual(http.StatusCreated, codes[2])
+		suite.Equal(76, suite.app.AccountRepository.Accounts["100"].Balance)
+	})
+
+	suite.Run("Should let business accounts go into overdraft", func() {
+		account := entity.NewAccount("100", 100)
+		account.ProductID = "business"
+		suite.app.AccountRepository.SaveAccount(context.Background(), account)
+
+		body := map[string]interface{}{
+			"type":   "withdraw",
+			"origin": "100",
+			"amount": 300,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.JSONEq(`{"origin": {"id": "100", "balance": -201}}`, rec.Body.String())
+	})
+}
+
+func TestProductHandler(t *testing.T) {
+	suite.Run(t, new(TestProductHandlerSuite))
+}
@@ -0,0 +1,216 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	appHttp "simple-bank/internal/infrastructure/http"
+	"simple-bank/internal/infrastructure/http/auth"
+	handlers "simple-bank/internal/infrastructure/http/handler"
+	"simple-bank/internal/infrastructure/http/ratelimit"
+	"simple-bank/test/support"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestRateLimitSuite struct {
+	suite.Suite
+	app *support.TestApp
+	// key is the API key sent by getBalance and withdraw, none when empty.
+	key string
+}
+
+func (suite *TestRateLimitSuite) newApp(key ratelimit.KeyFunc) {
+	suite.app = support.NewTestAppWithConfig(suite.config(key))
+	suite.key = ""
+	suite.saveAccounts()
+}
+
+// newAuthApp builds an app requiring credentials, requests are sent with an
+// unrestricted key.
+func (suite *TestRateLimitSuite) newAuthApp(key ratelimit.KeyFunc) {
+	suite.app = support.NewTestAppWithAuthConfig(suite.config(key))
+	suite.key = suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead, entity.ScopeEventWrite})
+	suite.saveAccounts()
+}
+
+func (suite *TestRateLimitSuite) config(key ratelimit.KeyFunc) appHttp.HTTPServerConfig {
+	return appHttp.HTTPServerConfig{
+		Port: "3000",
+		RateLimit: &ratelimit.Config{
+			Store: ratelimit.NewMemoryStore(),
+			Key:   key,
+			Read:  ratelimit.Limit{Rate: 0.01, Burst: 2},
+			Write: ratelimit.Limit{Rate: 0.01, Burst: 1},
+		},
+	}
+}
+
+func (suite *TestRateLimitSuite) saveAccounts() {
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("200", 100))
+}
+
+func (suite *TestRateLimitSuite) getBalance(accountID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/balance?account_id="+accountID, nil)
+	return suite.perform(req, suite.key)
+}
+
+func (suite *TestRateLimitSuite) withdraw(origin string) *httptest.ResponseRecorder {
+	return suite.withdrawWithKey(origin, suite.key)
+}
+
+func (suite *TestRateLimitSuite) withdrawWithKey(origin, key string) *httptest.ResponseRecorder {
+	body := map[string]interface{}{"type": "withdraw", "origin": origin, "amount": 10}
+	return suite.perform(suite.app.NewJSONRequest(http.MethodPost, "/event", body), key)
+}
+
+func (suite *TestRateLimitSuite) perform(req *http.Request, key string) *httptest.ResponseRecorder {
+	if key != "" {
+		req.Header.Set(auth.HeaderAPIKey, key)
+	}
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, req)
+	return rec
+}
+
+func (suite *TestRateLimitSuite) Test_RateLimit() {
+	suite.Run("Should answer 429 with Retry-After once the limit is reached", func() {
+		suite.newApp(ratelimit.KeyByIP)
+
+		suite.Equal(http.StatusOK, suite.getBalance("100").Code)
+		suite.Equal(http.StatusOK, suite.getBalance("100").Code)
+
+		rec := suite.getBalance("100")
+		suite.Equal(http.StatusTooManyRequests, rec.Code)
+		suite.Equal("100", rec.Header().Get("Retry-After"))
+		suite.Contains(rec.Body.String(), `"code":"rate_limited"`)
+	})
+
+	suite.Run("Should ignore the X-Forwarded-For header of clients", func() {
+		suite.newApp(ratelimit.KeyByIP)
+
+		for i, code := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+			req := httptest.NewRequest(http.MethodGet, "/balance?account_id=100", nil)
+			req.Header.Set(echo.HeaderXForwardedFor, fmt.Sprintf("203.0.113.%d", i))
+			req.Header.Set(echo.HeaderXRealIP, fmt.Sprintf("198.51.100.%d", i))
+			rec := httptest.NewRecorder()
+			suite.app.PerformRequest(rec, req)
+			suite.Equal(code, rec.Code)
+		}
+	})
+
+	suite.Run("Should read the X-Forwarded-For header of trusted proxies", func() {
+		_, proxies, _ := net.ParseCIDR("192.0.2.0/24")
+		suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{
+			Port: "3000",
+			RateLimit: &ratelimit.Config{
+				Store: ratelimit.NewMemoryStore(),
+				Key:   ratelimit.KeyByIP,
+				Read:  ratelimit.Limit{Rate: 0.01, Burst: 1},
+			},
+			TrustedProxies: []*net.IPNet{proxies},
+		})
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+
+		for _, client := range []string{"203.0.113.1", "203.0.113.2"} {
+			req := httptest.NewRequest(http.MethodGet, "/balance?account_id=100", nil)
+			req.Header.Set(echo.HeaderXForwardedFor, client)
+			rec := httptest.NewRecorder()
+			suite.app.PerformRequest(rec, req)
+			suite.Equal(http.StatusOK, rec.Code, client)
+		}
+	})
+
+	suite.Run("Should limit reads and writes separately", func() {
+		suite.newApp(ratelimit.KeyByIP)
+
+		suite.Equal(http.StatusCreated, suite.withdraw("100").Code)
+		suite.Equal(http.StatusTooManyRequests, suite.withdraw("100").Code)
+		suite.Equal(http.StatusOK, suite.getBalance("100").Code)
+		suite.Equal(90, suite.app.AccountRepository.Accounts["100"].Balance)
+	})
+
+	suite.Run("Should limit each account of the event body", func() {
+		suite.newAuthApp(ratelimit.KeyByAccount)
+
+		suite.Equal(http.StatusCreated, suite.withdraw("100").Code)
+		suite.Equal(http.StatusTooManyRequests, suite.withdraw("100").Code)
+		suite.Equal(http.StatusCreated, suite.withdraw("200").Code)
+	})
+
+	suite.Run("Should limit anonymous requests by client address rather than account", func() {
+		suite.newApp(ratelimit.KeyByAccount)
+
+		suite.Equal(http.StatusCreated, suite.withdraw("100").Code)
+		suite.Equal(http.StatusTooManyRequests, suite.withdraw("200").Code)
+	})
+
+	suite.Run("Should not charge an account to callers not allowed on it", func() {
+		suite.newAuthApp(ratelimit.KeyByAccount)
+		other := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeEventWrite}, "200")
+
+		suite.Equal(http.StatusForbidden, suite.withdrawWithKey("100", other).Code)
+		suite.Equal(http.StatusTooManyRequests, suite.withdrawWithKey("100", other).Code)
+		suite.Equal(http.StatusCreated, suite.withdraw("100").Code)
+	})
+
+	suite.Run("Should not charge an account to unauthenticated requests", func() {
+		suite.newAuthApp(ratelimit.KeyByAccount)
+
+		suite.Equal(http.StatusUnauthorized, suite.withdrawWithKey("100", "").Code)
+		suite.Equal(http.StatusTooManyRequests, suite.withdrawWithKey("100", "").Code)
+		suite.Equal(http.StatusCreated, suite.withdraw("100").Code)
+	})
+
+	suite.Run("Should refuse event bodies too large to peek", func() {
+		suite.newAuthApp(ratelimit.KeyByAccount)
+		body := map[string]interface{}{"type": "withdraw", "origin": "100", "amount": 10, "pad": strings.Repeat("x", 70<<10)}
+		rec := suite.perform(suite.app.NewJSONRequest(http.MethodPost, "/event", body), suite.key)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Equal(100, suite.app.AccountRepository.Accounts["100"].Balance)
+	})
+
+	suite.Run("Should charge websocket events to the bucket of /event", func() {
+		suite.newAuthApp(ratelimit.KeyByAccount)
+		server := httptest.NewServer(suite.app.HTTPServer.Engine)
+		defer server.Close()
+		header := http.Header{auth.HeaderAPIKey: {suite.key}}
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", header)
+		suite.Require().NoError(err)
+		defer conn.Close()
+
+		suite.Equal(http.StatusCreated, suite.withdraw("100").Code)
+		for _, origin := range []string{"100", "200"} {
+			suite.Require().NoError(conn.WriteJSON(handlers.WebSocketRequest{ID: origin, Type: "event", Event: &handlers.HandleEventRequest{
+				Type:   "withdraw",
+				Origin: origin,
+				Amount: 10,
+			}}))
+		}
+
+		responses := map[string]handlers.WebSocketResponse{}
+		for i := 0; i < 2; i++ {
+			var response handlers.WebSocketResponse
+			conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+			suite.Require().NoError(conn.ReadJSON(&response))
+			responses[response.ID] = response
+		}
+		suite.Equal("rate_limited", responses["100"].Error.Code)
+		suite.Equal("result", responses["200"].Type)
+		suite.Equal(http.StatusTooManyRequests, suite.withdraw("200").Code)
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	suite.Run(t, new(TestRateLimitSuite))
+}
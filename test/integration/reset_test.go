@@ -1,9 +1,11 @@
 package integration
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
 	"simple-bank/test/support"
 	"testing"
 
@@ -17,22 +19,56 @@ type TestResetHandlerSuite struct {
 
 func (suite *TestResetHandlerSuite) SetupSubTest() {
 	suite.app = support.NewTestApp()
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("ID1", 100))
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("ID2", 200))
+}
+
+func (suite *TestResetHandlerSuite) reset(token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/reset", nil)
+	if token != "" {
+		req.Header.Set("X-Reset-Confirmation", token)
+	}
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, req)
+	return rec
 }
 
 func (suite *TestResetHandlerSuite) Test_POST_Reset() {
 	suite.Run("Should reset the app removing all accounts", func() {
-		suite.app.AccountRepository.SaveAccount(entity.NewAccount("ID1", 100))
-		suite.app.AccountRepository.SaveAccount(entity.NewAccount("ID2", 200))
-
-		req := httptest.NewRequest(http.MethodPost, "/reset", nil)
-		rec := httptest.NewRecorder()
-
-		suite.app.PerformRequest(rec, req)
+		rec := suite.reset(support.TestResetToken)
 
 		suite.Equal(200, rec.Code)
 		suite.Equal("OK", rec.Body.String())
 		suite.Equal(0, len(suite.app.AccountRepository.Accounts))
 	})
+
+	suite.Run("Should refuse resets without the confirmation token", func() {
+		rec := suite.reset("")
+
+		suite.Equal(http.StatusPreconditionRequired, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"reset_not_confirmed"`)
+		suite.Equal(2, len(suite.app.AccountRepository.Accounts))
+	})
+
+	suite.Run("Should refuse resets with a wrong confirmation token", func() {
+		rec := suite.reset("wrong")
+
+		suite.Equal(http.StatusPreconditionRequired, rec.Code)
+		suite.Equal(2, len(suite.app.AccountRepository.Accounts))
+	})
+
+	suite.Run("Should audit every reset attempt", func() {
+		suite.reset("wrong")
+		suite.reset(support.TestResetToken)
+
+		entries := suite.app.AuditEntries()
+		suite.Require().Len(entries, 2)
+		suite.Equal(audit.ActionReset, entries[0].Action)
+		suite.Equal(audit.OutcomeDenied, entries[0].Outcome)
+		suite.Equal(audit.OutcomeSuccess, entries[1].Outcome)
+		suite.Equal("anonymous", entries[1].Actor)
+		suite.Equal("192.0.2.1", entries[1].IP)
+	})
 }
 
 func TestResetHandler(t *testing.T) {
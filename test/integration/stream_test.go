@@ -0,0 +1,250 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	appHttp "simple-bank/internal/infrastructure/http"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/test/support"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type sseMessage struct {
+	id    string
+	event string
+	data  string
+}
+
+// sseClient reads the messages of an open stream.
+type sseClient struct {
+	messages chan sseMessage
+	cancel   context.CancelFunc
+}
+
+func (c *sseClient) next() (sseMessage, bool) {
+	select {
+	case message, ok := <-c.messages:
+		return message, ok
+	case <-time.After(2 * time.Second):
+		return sseMessage{}, false
+	}
+}
+
+type TestStreamSuite struct {
+	suite.Suite
+	app    *support.TestApp
+	server *httptest.Server
+}
+
+func (suite *TestStreamSuite) SetupSubTest() {
+	suite.start(support.NewTestApp())
+}
+
+func (suite *TestStreamSuite) TearDownSubTest() {
+	suite.server.Close()
+}
+
+func (suite *TestStreamSuite) start(app *support.TestApp) {
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	suite.app = app
+	suite.server = httptest.NewServer(app.HTTPServer.Engine)
+}
+
+func (suite *TestStreamSuite) open(path string, header http.Header) *sseClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, suite.server.URL+path, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+	suite.Require().Equal(http.StatusOK, res.StatusCode)
+	suite.Equal("text/event-stream", res.Header.Get("Content-Type"))
+
+	client := &sseClient{messages: make(chan sseMessage, 10), cancel: cancel}
+	go func() {
+		defer res.Body.Close()
+		defer close(client.messages)
+
+		var message sseMessage
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if message.data != "" {
+					client.messages <- message
+				}
+				message = sseMessage{}
+			case strings.HasPrefix(line, "id: "):
+				message.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				message.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				message.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return client
+}
+
+func (suite *TestStreamSuite) post(body any) {
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, suite.app.NewJSONRequest(http.MethodPost, "/event", body))
+	suite.Require().Equal(http.StatusCreated, rec.Code, rec.Body.String())
+}
+
+func (suite *TestStreamSuite) change(message sseMessage) dto.BalanceChangeDTO {
+	var change dto.BalanceChangeDTO
+	suite.Require().NoError(json.Unmarshal([]byte(message.data), &change))
+	return change
+}
+
+func (suite *TestStreamSuite) Test_AccountStream() {
+	suite.Run("Should push the balance changes of the account", func() {
+		client := suite.open("/accounts/100/stream", nil)
+		defer client.cancel()
+
+		suite.post(map[string]any{"type": "deposit", "destination": "200", "amount": 10})
+		suite.post(map[string]any{"type": "deposit", "destination": "100", "amount": 10})
+		suite.post(map[string]any{"type": "withdraw", "origin": "100", "amount": 4})
+
+		message, ok := client.next()
+		suite.Require().True(ok)
+		suite.Equal(suite.app.Broker.Epoch()+"-2", message.id)
+		suite.Equal("balance", message.event)
+		change := suite.change(message)
+		suite.Equal("100", change.Account)
+		suite.Equal(10, change.Balance)
+		suite.Equal("funds.deposited", change.EventType)
+		suite.NotEmpty(change.EventID)
+
+		message, ok = client.next()
+		suite.Require().True(ok)
+		suite.Equal(suite.app.Broker.Epoch()+"-3", message.id)
+		suite.Equal(6, suite.change(message).Balance)
+		suite.Equal("funds.withdrawn", suite.change(message).EventType)
+	})
+
+	suite.Run("Should resume after the Last-Event-ID", func() {
+		suite.post(map[string]any{"type": "deposit", "destination": "100", "amount": 10})
+		suite.post(map[string]any{"type": "deposit", "destination": "100", "amount": 5})
+		suite.post(map[string]any{"type": "deposit", "destination": "100", "amount": 1})
+
+		epoch := suite.app.Broker.Epoch()
+		client := suite.open("/accounts/100/stream", http.Header{"Last-Event-Id": {epoch + "-1"}})
+		defer client.cancel()
+
+		message, _ := client.next()
+		suite.Equal(epoch+"-2", message.id)
+		suite.Equal(15, suite.change(message).Balance)
+		message, _ = client.next()
+		suite.Equal(epoch+"-3", message.id)
+		suite.Equal(16, suite.change(message).Balance)
+	})
+
+	suite.Run("Should send a reset when the Last-Event-ID cannot be resumed", func() {
+		suite.post(map[string]any{"type": "deposit", "destination": "100", "amount": 10})
+
+		client := suite.open("/accounts/100/stream", http.Header{"Last-Event-Id": {"1-1"}})
+		defer client.cancel()
+
+		message, _ := client.next()
+		suite.Equal("reset", message.event)
+		suite.Equal(suite.app.Broker.Epoch()+"-1", message.id)
+
+		suite.post(map[string]any{"type": "deposit", "destination": "100", "amount": 5})
+		message, _ = client.next()
+		suite.Equal(suite.app.Broker.Epoch()+"-2", message.id)
+		suite.Equal(15, suite.change(message).Balance)
+	})
+
+	suite.Run("Should outlive the request timeout", func() {
+		suite.start(support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", RequestTimeout: 20 * time.Millisecond}))
+		client := suite.open("/accounts/100/stream", nil)
+		defer client.cancel()
+
+		time.Sleep(50 * time.Millisecond)
+		suite.post(map[string]any{"type": "deposit", "destination": "100", "amount": 10})
+
+		_, ok := client.next()
+		suite.True(ok)
+	})
+
+	suite.Run("Should end the streams when the broker is closed", func() {
+		client := suite.open("/accounts/100/stream", nil)
+		defer client.cancel()
+
+		suite.app.Broker.Close()
+
+		_, ok := client.next()
+		suite.False(ok)
+	})
+}
+
+func (suite *TestStreamSuite) Test_AdminStream() {
+	suite.Run("Should push both sides of a transfer", func() {
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+		client := suite.open("/admin/stream", nil)
+		defer client.cancel()
+
+		suite.post(map[string]any{"type": "transfer", "origin": "100", "destination": "300", "amount": 15})
+
+		first, _ := client.next()
+		second, _ := client.next()
+		suite.Equal(dto.BalanceChangeDTO{
+			EventID:    suite.change(first).EventID,
+			EventType:  "transfer.completed",
+			Account:    "100",
+			Balance:    85,
+			OccurredAt: suite.change(first).OccurredAt,
+		}, suite.change(first))
+		suite.Equal("300", suite.change(second).Account)
+		suite.Equal(15, suite.change(second).Balance)
+		suite.Equal(suite.change(first).EventID, suite.change(second).EventID)
+	})
+}
+
+func (suite *TestStreamSuite) Test_Authorization() {
+	suite.Run("Should only stream the accounts a key is restricted to", func() {
+		suite.start(support.NewTestAppWithAuth())
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead, entity.ScopeAdminStream}, "100")
+
+		for _, path := range []string{"/accounts/200/stream", "/admin/stream"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("X-API-Key", key)
+			rec := httptest.NewRecorder()
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusForbidden, rec.Code, path)
+		}
+	})
+
+	suite.Run("Should require the admin:stream scope for the global stream", func() {
+		suite.start(support.NewTestAppWithAuth())
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead})
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/stream", nil)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Contains(rec.Body.String(), "insufficient_scope")
+	})
+}
+
+func TestStream(t *testing.T) {
+	suite.Run(t, new(TestStreamSuite))
+}
@@ -0,0 +1,45 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	appHttp "simple-bank/internal/infrastructure/http"
+	"simple-bank/test/support"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestRequestTimeoutSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestRequestTimeoutSuite) SetupSubTest() {
+	suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{
+		Port:           "3000",
+		RequestTimeout: time.Nanosecond,
+	})
+}
+
+func (suite *TestRequestTimeoutSuite) Test_RequestTimeout() {
+	suite.Run("Should return 503 when request exceeds the timeout", func() {
+		body := map[string]interface{}{
+			"type":        "deposit",
+			"destination": "100",
+			"amount":      100,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusServiceUnavailable, rec.Code)
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+}
+
+func TestRequestTimeout(t *testing.T) {
+	suite.Run(t, new(TestRequestTimeoutSuite))
+}
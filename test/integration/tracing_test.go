@@ -0,0 +1,98 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TestTracingSuite struct {
+	suite.Suite
+	app      *support.TestApp
+	spans    *tracetest.SpanRecorder
+	previous trace.TracerProvider
+}
+
+func (suite *TestTracingSuite) SetupSubTest() {
+	suite.spans = tracetest.NewSpanRecorder()
+	suite.previous = otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.spans)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	suite.app = support.NewTestApp()
+	suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+}
+
+func (suite *TestTracingSuite) TearDownSubTest() {
+	otel.SetTracerProvider(suite.previous)
+}
+
+func (suite *TestTracingSuite) span(name string) sdktrace.ReadOnlySpan {
+	for _, span := range suite.spans.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	suite.FailNow("span not found", name)
+	return nil
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	values := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		values[kv.Key] = kv.Value.Emit()
+	}
+	return values
+}
+
+func (suite *TestTracingSuite) Test_Spans() {
+	suite.Run("Should trace an event from the request to the repository", func() {
+		body := map[string]interface{}{"type": "withdraw", "origin": "100", "amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		suite.app.PerformRequest(httptest.NewRecorder(), req)
+
+		server := suite.span("POST /event")
+		handler := suite.span("EventHandler.HandleEvent")
+		useCase := suite.span("WithdrawUseCase.Execute")
+		repository := suite.span("AccountRepository.UpdateAccount")
+
+		suite.Equal("4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+		suite.Equal("00f067aa0ba902b7", server.Parent().SpanID().String())
+		suite.Equal(server.SpanContext().SpanID(), handler.Parent().SpanID())
+		suite.Equal(handler.SpanContext().SpanID(), useCase.Parent().SpanID())
+		suite.Equal(useCase.SpanContext().SpanID(), repository.Parent().SpanID())
+
+		suite.Equal("withdraw", attributes(handler)["bank.event_type"])
+		suite.Equal("success", attributes(handler)["bank.outcome"])
+		suite.Equal("success", attributes(useCase)["bank.outcome"])
+		suite.Equal("100", attributes(repository)["bank.account_id"])
+	})
+
+	suite.Run("Should record business failures on the spans", func() {
+		body := map[string]interface{}{"type": "withdraw", "origin": "100", "amount": 1000}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+
+		suite.app.PerformRequest(httptest.NewRecorder(), req)
+
+		suite.Equal("insufficient_funds", attributes(suite.span("EventHandler.HandleEvent"))["bank.outcome"])
+		suite.Equal("rejected", attributes(suite.span("WithdrawUseCase.Execute"))["bank.outcome"])
+		suite.Equal("422", attributes(suite.span("POST /event"))["http.response.status_code"])
+	})
+}
+
+func TestTracing(t *testing.T) {
+	suite.Run(t, new(TestTracingSuite))
+}
@@ -0,0 +1,201 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/http/auth"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestV2APISuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestV2APISuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+}
+
+func (suite *TestV2APISuite) Test_GET_Account() {
+	suite.Run("Should return account details", func() {
+		account := entity.NewAccount("100", 50)
+		account.ProductID = "checking"
+		suite.app.AccountRepository.SaveAccount(context.Background(), account)
+
+		req := httptest.NewRequest(http.MethodGet, "/v2/accounts/100", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.JSONEq(`{"id": "100", "balance": 50, "status": "active", "product": "checking"}`, rec.Body.String())
+	})
+
+	suite.Run("Should return 404 when account does not exist", func() {
+		req := httptest.NewRequest(http.MethodGet, "/v2/accounts/100", nil)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusNotFound, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"account_not_found"`)
+	})
+}
+
+func (suite *TestV2APISuite) Test_POST_Deposits() {
+	suite.Run("Should create account on first deposit", func() {
+		body := map[string]interface{}{"amount": 100}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/v2/accounts/100/deposits", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.JSONEq(`{"account": {"id": "100", "balance": 100}}`, rec.Body.String())
+		suite.Equal("checking", suite.app.AccountRepository.Accounts["100"].ProductID)
+	})
+
+	suite.Run("Should charge the product deposit fee", func() {
+		body := map[string]interface{}{"amount": 100, "product": "business"}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/v2/accounts/100/deposits", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.JSONEq(`{"account": {"id": "100", "balance": 99}}`, rec.Body.String())
+	})
+}
+
+func (suite *TestV2APISuite) Test_POST_Withdrawals() {
+	suite.Run("Should withdraw from account", func() {
+		account := entity.NewAccount("100", 100)
+		account.ProductID = "savings"
+		suite.app.AccountRepository.SaveAccount(context.Background(), account)
+
+		body := map[string]interface{}{"amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/v2/accounts/100/withdrawals", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.JSONEq(`{"account": {"id": "100", "balance": 88}, "amount": 10, "fee": 2}`, rec.Body.String())
+	})
+
+	suite.Run("Should return 422 when funds are insufficient", func() {
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 5))
+
+		body := map[string]interface{}{"amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/v2/accounts/100/withdrawals", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusUnprocessableEntity, rec.Code)
+		suite.Contains(rec.Body.String(), `"code":"insufficient_funds"`)
+	})
+}
+
+func (suite *TestV2APISuite) Test_POST_Deposits_Withdrawals_Validation() {
+	suite.Run("Should return 400 when the amount is not positive", func() {
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+
+		for _, path := range []string{"/v2/accounts/100/deposits", "/v2/accounts/100/withdrawals"} {
+			req := suite.app.NewJSONRequest(http.MethodPost, path, map[string]interface{}{"amount": 0})
+			rec := httptest.NewRecorder()
+
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusBadRequest, rec.Code)
+			suite.Contains(rec.Body.String(), `"field":"amount"`)
+		}
+		suite.Equal(100, suite.app.AccountRepository.Accounts["100"].Balance)
+	})
+
+	suite.Run("Should return 400 when the account ID is invalid", func() {
+		req := suite.app.NewJSONRequest(http.MethodPost, "/v2/accounts/a%20b/deposits", map[string]interface{}{"amount": 10})
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `"field":"id"`)
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+}
+
+func (suite *TestV2APISuite) Test_POST_Transfers() {
+	suite.Run("Should transfer between accounts", func() {
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+
+		body := map[string]interface{}{
+			"origin":      "100",
+			"destination": "200",
+			"amount":      40,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/v2/transfers", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+		suite.JSONEq(`{"origin": {"id": "100", "balance": 60}, "destination": {"id": "200", "balance": 40}}`, rec.Body.String())
+	})
+
+	suite.Run("Should return 400 when the request is invalid", func() {
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+
+		cases := []struct {
+			body  map[string]interface{}
+			field string
+		}{
+			{map[string]interface{}{"origin": "100", "destination": "100", "amount": 10}, "destination"},
+			{map[string]interface{}{"origin": "100", "destination": "", "amount": 10}, "destination"},
+			{map[string]interface{}{"destination": "200", "amount": 10}, "origin"},
+			{map[string]interface{}{"origin": "100", "destination": "200", "amount": 0}, "amount"},
+		}
+
+		for _, c := range cases {
+			req := suite.app.NewJSONRequest(http.MethodPost, "/v2/transfers", c.body)
+			rec := httptest.NewRecorder()
+
+			suite.app.PerformRequest(rec, req)
+
+			suite.Equal(http.StatusBadRequest, rec.Code)
+			suite.Contains(rec.Body.String(), `"field":"`+c.field+`"`)
+		}
+		suite.Equal(100, suite.app.AccountRepository.Accounts["100"].Balance)
+		suite.Len(suite.app.AccountRepository.Accounts, 1)
+	})
+
+	suite.Run("Should return 403 when customer does not own origin account", func() {
+		suite.app = support.NewTestAppWithAuth()
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 100))
+		suite.app.CustomerRepository.SaveCustomer(context.Background(), entity.NewCustomer("C1", "John", "", ""))
+		key := suite.app.CreateCustomerAPIKey("C1", []entity.Scope{entity.ScopeEventWrite})
+
+		body := map[string]interface{}{
+			"origin":      "100",
+			"destination": "200",
+			"amount":      40,
+		}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/v2/transfers", body)
+		req.Header.Set(auth.HeaderAPIKey, key)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusForbidden, rec.Code)
+		suite.Equal(100, suite.app.AccountRepository.Accounts["100"].Balance)
+	})
+}
+
+func TestV2API(t *testing.T) {
+	suite.Run(t, new(TestV2APISuite))
+}
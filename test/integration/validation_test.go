@@ -0,0 +1,161 @@
+package integration
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	appHttp "simple-bank/internal/infrastructure/http"
+	"simple-bank/test/support"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestEventValidationSuite struct {
+	suite.Suite
+	app *support.TestApp
+}
+
+func (suite *TestEventValidationSuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+}
+
+func (suite *TestEventValidationSuite) Test_Validation() {
+	suite.Run("Should require destination on deposit", func() {
+		body := map[string]interface{}{"type": "deposit", "amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.JSONEq(`{
+			"type": "/problems/validation-failed",
+			"title": "Validation failed",
+			"status": 400,
+			"code": "validation_failed",
+			"instance": "/event",
+			"errors": [{"field": "destination", "message": "is required"}]
+		}`, rec.Body.String())
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+
+	suite.Run("Should require origin on withdraw", func() {
+		body := map[string]interface{}{"type": "withdraw", "amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `{"field":"origin","message":"is required"}`)
+	})
+
+	suite.Run("Should require a positive amount", func() {
+		body := map[string]interface{}{"type": "deposit", "destination": "100", "amount": 0}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `{"field":"amount","message":"must be greater than 0"}`)
+	})
+
+	suite.Run("Should reject malformed account IDs", func() {
+		body := map[string]interface{}{"type": "deposit", "destination": "../100", "amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `"field":"destination"`)
+	})
+
+	suite.Run("Should reject transfers to the origin account", func() {
+		body := map[string]interface{}{"type": "transfer", "origin": "100", "destination": "100", "amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `{"field":"destination","message":"must differ from origin"}`)
+	})
+
+	suite.Run("Should ignore unknown fields when strict mode is off", func() {
+		body := map[string]interface{}{"type": "deposit", "destination": "100", "amount": 10, "note": "gift"}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+	})
+}
+
+func (suite *TestEventValidationSuite) Test_StrictValidation() {
+	suite.Run("Should reject unknown fields", func() {
+		suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", StrictValidation: true})
+
+		body := map[string]interface{}{"type": "deposit", "destination": "100", "amount": 10, "note": "gift"}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `{"field":"note","message":"is not allowed"}`)
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+
+	suite.Run("Should report fields with the wrong type", func() {
+		suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", StrictValidation: true})
+
+		req := httptest.NewRequest(http.MethodPost, "/event", bytes.NewBufferString(`{"type": "deposit", "destination": "100", "amount": "10"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), `{"field":"amount","message":"must be int"}`)
+	})
+
+	suite.Run("Should reject unknown fields on the v2 routes", func() {
+		suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", StrictValidation: true})
+
+		requests := map[string]map[string]interface{}{
+			"/v2/accounts/100/deposits":    {"amount": 10, "note": "gift"},
+			"/v2/accounts/100/withdrawals": {"amount": 10, "note": "gift"},
+			"/v2/transfers":                {"origin": "100", "destination": "200", "amount": 10, "note": "gift"},
+		}
+		for path, body := range requests {
+			rec := httptest.NewRecorder()
+
+			suite.app.PerformRequest(rec, suite.app.NewJSONRequest(http.MethodPost, path, body))
+
+			suite.Equal(http.StatusBadRequest, rec.Code, path)
+			suite.Contains(rec.Body.String(), `{"field":"note","message":"is not allowed"}`, path)
+		}
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+
+	suite.Run("Should accept known fields", func() {
+		suite.app = support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", StrictValidation: true})
+
+		body := map[string]interface{}{"type": "deposit", "destination": "100", "amount": 10}
+		req := suite.app.NewJSONRequest(http.MethodPost, "/event", body)
+		rec := httptest.NewRecorder()
+
+		suite.app.PerformRequest(rec, req)
+
+		suite.Equal(http.StatusCreated, rec.Code)
+	})
+}
+
+func TestEventValidation(t *testing.T) {
+	suite.Run(t, new(TestEventValidationSuite))
+}
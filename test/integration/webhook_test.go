@@ -0,0 +1,187 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/webhook"
+	"simple-bank/internal/shared/dto"
+	"simple-bank/test/support"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const webhookSecret = "integration-secret"
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+type TestWebhookSuite struct {
+	suite.Suite
+	app      *support.TestApp
+	status   atomic.Int32
+	received chan receivedWebhook
+	receiver *httptest.Server
+}
+
+func (suite *TestWebhookSuite) SetupSubTest() {
+	suite.app = support.NewTestApp()
+	suite.status.Store(http.StatusOK)
+	suite.received = make(chan receivedWebhook, 10)
+	suite.receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		suite.received <- receivedWebhook{header: r.Header, body: body}
+		w.WriteHeader(int(suite.status.Load()))
+	}))
+}
+
+func (suite *TestWebhookSuite) TearDownSubTest() {
+	suite.receiver.Close()
+}
+
+func (suite *TestWebhookSuite) request(method, path string, body any) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	suite.app.PerformRequest(rec, suite.app.NewJSONRequest(method, path, body))
+	return rec
+}
+
+func (suite *TestWebhookSuite) createWebhook(eventTypes ...string) dto.WebhookDTO {
+	rec := suite.request(http.MethodPost, "/admin/webhooks", map[string]any{
+		"url":         suite.receiver.URL,
+		"event_types": eventTypes,
+		"secret":      webhookSecret,
+	})
+	suite.Require().Equal(http.StatusCreated, rec.Code, rec.Body.String())
+
+	var created dto.WebhookDTO
+	suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &created))
+	return created
+}
+
+// deliver relays the outbox and sends the due deliveries, as the background
+// workers would.
+func (suite *TestWebhookSuite) deliver() {
+	_, err := suite.app.Relay.Flush(context.Background())
+	suite.Require().NoError(err)
+	_, err = suite.app.Dispatcher.DeliverDue(context.Background())
+	suite.Require().NoError(err)
+}
+
+func (suite *TestWebhookSuite) deliveries(status string) []dto.WebhookDeliveryDTO {
+	rec := suite.request(http.MethodGet, "/admin/webhooks/deliveries?status="+status, nil)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+
+	var response struct {
+		Deliveries []dto.WebhookDeliveryDTO `json:"deliveries"`
+	}
+	suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	return response.Deliveries
+}
+
+func (suite *TestWebhookSuite) Test_Delivery() {
+	suite.Run("Should deliver signed events of the subscribed types", func() {
+		suite.createWebhook("funds.deposited", "transfer.completed")
+		suite.request(http.MethodPost, "/event", map[string]any{"type": "deposit", "destination": "100", "amount": 10})
+		suite.request(http.MethodPost, "/event", map[string]any{"type": "withdraw", "origin": "100", "amount": 5})
+
+		suite.deliver()
+
+		suite.Require().Len(suite.received, 1)
+		received := <-suite.received
+		timestamp, _ := strconv.ParseInt(received.header.Get(webhook.HeaderTimestamp), 10, 64)
+		suite.True(webhook.Verify(webhookSecret, timestamp, received.body, received.header.Get(webhook.HeaderSignature)))
+		suite.Equal("funds.deposited", received.header.Get(webhook.HeaderEvent))
+
+		var payload map[string]any
+		suite.Require().NoError(json.Unmarshal(received.body, &payload))
+		suite.Equal("funds.deposited", payload["type"])
+		suite.Equal(map[string]any{"account": "100", "amount": 10.0, "fee": 0.0, "balance": 10.0}, payload["data"])
+		suite.Len(suite.deliveries("delivered"), 1)
+	})
+
+	suite.Run("Should not deliver events of rejected operations", func() {
+		suite.createWebhook("funds.withdrawn", "transfer.completed")
+		suite.app.AccountRepository.SaveAccount(context.Background(), entity.NewAccount("100", 10))
+		suite.request(http.MethodPost, "/event", map[string]any{"type": "withdraw", "origin": "100", "amount": 50})
+		suite.request(http.MethodPost, "/event", map[string]any{"type": "transfer", "origin": "100", "destination": "300", "amount": 50})
+
+		suite.deliver()
+
+		suite.Empty(suite.received)
+		suite.Empty(suite.deliveries(""))
+	})
+}
+
+func (suite *TestWebhookSuite) Test_DeadLetters() {
+	suite.Run("Should dead letter failing deliveries and redeliver them on demand", func() {
+		suite.status.Store(http.StatusInternalServerError)
+		suite.createWebhook("funds.deposited")
+		suite.request(http.MethodPost, "/event", map[string]any{"type": "deposit", "destination": "100", "amount": 10})
+
+		suite.deliver()
+		for i := 0; i < 2; i++ {
+			time.Sleep(2 * time.Millisecond)
+			suite.app.Dispatcher.DeliverDue(context.Background())
+		}
+
+		dead := suite.deliveries("dead")
+		suite.Require().Len(dead, 1)
+		suite.Equal(3, dead[0].Attempts)
+		suite.Len(suite.received, 3)
+
+		suite.status.Store(http.StatusOK)
+		rec := suite.request(http.MethodPost, "/admin/webhooks/deliveries/"+dead[0].ID+"/redeliver", nil)
+		suite.Equal(http.StatusAccepted, rec.Code)
+		suite.app.Dispatcher.DeliverDue(context.Background())
+
+		suite.Empty(suite.deliveries("dead"))
+		suite.Len(suite.deliveries("delivered"), 1)
+	})
+
+	suite.Run("Should answer not found for unknown deliveries", func() {
+		rec := suite.request(http.MethodPost, "/admin/webhooks/deliveries/unknown/redeliver", nil)
+
+		suite.Equal(http.StatusNotFound, rec.Code)
+		suite.Contains(rec.Body.String(), "webhook_delivery_not_found")
+	})
+}
+
+func (suite *TestWebhookSuite) Test_Management() {
+	suite.Run("Should list and delete webhooks", func() {
+		created := suite.createWebhook("account.created")
+
+		rec := suite.request(http.MethodGet, "/admin/webhooks", nil)
+		suite.Equal(http.StatusOK, rec.Code)
+		suite.Contains(rec.Body.String(), created.ID)
+		suite.NotContains(rec.Body.String(), webhookSecret)
+
+		rec = suite.request(http.MethodDelete, "/admin/webhooks/"+created.ID, nil)
+		suite.Equal(http.StatusNoContent, rec.Code)
+		rec = suite.request(http.MethodDelete, "/admin/webhooks/"+created.ID, nil)
+		suite.Equal(http.StatusNotFound, rec.Code)
+	})
+
+	suite.Run("Should reject invalid webhooks", func() {
+		rec := suite.request(http.MethodPost, "/admin/webhooks", map[string]any{
+			"url":         "ftp://example.com",
+			"event_types": []string{"funds.deposited"},
+			"secret":      webhookSecret,
+		})
+
+		suite.Equal(http.StatusBadRequest, rec.Code)
+		suite.Contains(rec.Body.String(), "invalid_webhook")
+	})
+}
+
+func TestWebhook(t *testing.T) {
+	suite.Run(t, new(TestWebhookSuite))
+}
@@ -0,0 +1,289 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/audit"
+	appHttp "simple-bank/internal/infrastructure/http"
+	handlers "simple-bank/internal/infrastructure/http/handler"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/test/support"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestWebSocketSuite struct {
+	suite.Suite
+	app    *support.TestApp
+	server *httptest.Server
+}
+
+func (suite *TestWebSocketSuite) SetupSubTest() {
+	suite.start(support.NewTestApp())
+}
+
+func (suite *TestWebSocketSuite) TearDownSubTest() {
+	suite.server.Close()
+}
+
+func (suite *TestWebSocketSuite) start(app *support.TestApp) {
+	if suite.server != nil {
+		suite.server.Close()
+	}
+	suite.app = app
+	suite.server = httptest.NewServer(app.HTTPServer.Engine)
+}
+
+func (suite *TestWebSocketSuite) dial(header http.Header) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(suite.server.URL, "http") + "/ws"
+	conn, res, err := websocket.DefaultDialer.Dial(url, header)
+	suite.Require().NoError(err)
+	suite.Equal(http.StatusSwitchingProtocols, res.StatusCode)
+	return conn
+}
+
+func (suite *TestWebSocketSuite) send(conn *websocket.Conn, request handlers.WebSocketRequest) {
+	suite.Require().NoError(conn.WriteJSON(request))
+}
+
+func (suite *TestWebSocketSuite) receive(conn *websocket.Conn) handlers.WebSocketResponse {
+	var response handlers.WebSocketResponse
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	suite.Require().NoError(conn.ReadJSON(&response))
+	return response
+}
+
+func (suite *TestWebSocketSuite) Test_Events() {
+	suite.Run("Should answer events with the correlation ID", func() {
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "c1", Type: "event", Event: &handlers.HandleEventRequest{
+			Type:        "deposit",
+			Destination: "100",
+			Amount:      10,
+		}})
+
+		response := suite.receive(conn)
+		suite.Equal("c1", response.ID)
+		suite.Equal("result", response.Type)
+		suite.Equal("100", response.Result.Destination.ID)
+		suite.Equal(10, response.Result.Destination.Balance)
+		suite.Equal([]audit.BalanceChange{{Account: "100", Before: 0, After: 10}}, suite.app.AuditEntries()[0].Balances)
+	})
+
+	suite.Run("Should answer rejected events with a problem", func() {
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "c1", Type: "event", Event: &handlers.HandleEventRequest{
+			Type:   "withdraw",
+			Origin: "100",
+			Amount: 10,
+		}})
+		suite.send(conn, handlers.WebSocketRequest{ID: "c2", Type: "event", Event: &handlers.HandleEventRequest{
+			Type:        "deposit",
+			Destination: "100",
+		}})
+
+		responses := map[string]handlers.WebSocketResponse{}
+		for i := 0; i < 2; i++ {
+			response := suite.receive(conn)
+			responses[response.ID] = response
+		}
+		suite.Equal("error", responses["c1"].Type)
+		suite.Equal("account_not_found", responses["c1"].Error.Code)
+		suite.Equal(http.StatusNotFound, responses["c1"].Error.Status)
+		suite.Equal("validation_failed", responses["c2"].Error.Code)
+	})
+
+	suite.Run("Should run events concurrently and answer each of them", func() {
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		for i := 0; i < 20; i++ {
+			suite.send(conn, handlers.WebSocketRequest{ID: strconv.Itoa(i), Type: "event", Event: &handlers.HandleEventRequest{
+				Type:        "deposit",
+				Destination: "100",
+				Amount:      1,
+			}})
+		}
+
+		ids := map[string]bool{}
+		for i := 0; i < 20; i++ {
+			response := suite.receive(conn)
+			suite.Equal("result", response.Type)
+			ids[response.ID] = true
+		}
+		suite.Len(ids, 20)
+		account, _ := suite.app.AccountRepository.GetAccountByID(context.Background(), "100")
+		suite.Equal(20, account.Balance)
+	})
+
+	suite.Run("Should reject malformed messages without closing the connection", func() {
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.Require().NoError(conn.WriteMessage(websocket.TextMessage, []byte("{not json")))
+		suite.Equal("invalid_request_body", suite.receive(conn).Error.Code)
+
+		suite.send(conn, handlers.WebSocketRequest{Type: "event"})
+		suite.Equal("missing_message_id", suite.receive(conn).Error.Code)
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "c1", Type: "refund"})
+		response := suite.receive(conn)
+		suite.Equal("c1", response.ID)
+		suite.Equal("unknown_message_type", response.Error.Code)
+	})
+
+	suite.Run("Should reject unknown fields in strict mode", func() {
+		suite.start(support.NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000", StrictValidation: true}))
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		message := `{"id":"c1","type":"event","event":{"type":"deposit","destination":"100","amount":10,"note":"gift"}}`
+		suite.Require().NoError(conn.WriteMessage(websocket.TextMessage, []byte(message)))
+
+		response := suite.receive(conn)
+		suite.Equal("validation_failed", response.Error.Code)
+		suite.Equal([]problem.FieldError{{Field: "note", Message: "is not allowed"}}, response.Error.Errors)
+		suite.Empty(suite.app.AccountRepository.Accounts)
+	})
+}
+
+func (suite *TestWebSocketSuite) Test_Subscriptions() {
+	suite.Run("Should push the balance changes of subscribed accounts", func() {
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "s1", Type: "subscribe", Account: "100"})
+		suite.Equal(handlers.WebSocketResponse{ID: "s1", Type: "subscribed", Account: "100"}, suite.receive(conn))
+
+		suite.app.PerformRequest(httptest.NewRecorder(), suite.app.NewJSONRequest(http.MethodPost, "/event",
+			map[string]any{"type": "deposit", "destination": "200", "amount": 10}))
+		suite.app.PerformRequest(httptest.NewRecorder(), suite.app.NewJSONRequest(http.MethodPost, "/event",
+			map[string]any{"type": "transfer", "origin": "200", "destination": "100", "amount": 4}))
+
+		response := suite.receive(conn)
+		suite.Equal("balance", response.Type)
+		suite.Equal("100", response.Account)
+		suite.Equal(suite.app.Broker.Epoch()+"-3", response.Seq)
+		suite.Equal(4, response.Balance.Balance)
+		suite.Equal("transfer.completed", response.Balance.EventType)
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "s2", Type: "unsubscribe", Account: "100"})
+		suite.Equal(handlers.WebSocketResponse{ID: "s2", Type: "unsubscribed", Account: "100"}, suite.receive(conn))
+	})
+
+	suite.Run("Should resume a subscription after last_id", func() {
+		for _, amount := range []int{10, 5} {
+			suite.app.PerformRequest(httptest.NewRecorder(), suite.app.NewJSONRequest(http.MethodPost, "/event",
+				map[string]any{"type": "deposit", "destination": "100", "amount": amount}))
+		}
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "s1", Type: "subscribe", Account: "100", LastID: suite.app.Broker.Epoch() + "-1"})
+
+		suite.Equal("subscribed", suite.receive(conn).Type)
+		response := suite.receive(conn)
+		suite.Equal(suite.app.Broker.Epoch()+"-2", response.Seq)
+		suite.Equal(15, response.Balance.Balance)
+	})
+
+	suite.Run("Should reset a subscription whose last_id cannot be resumed", func() {
+		suite.app.PerformRequest(httptest.NewRecorder(), suite.app.NewJSONRequest(http.MethodPost, "/event",
+			map[string]any{"type": "deposit", "destination": "100", "amount": 10}))
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "s1", Type: "subscribe", Account: "100", LastID: "1-1"})
+
+		suite.Equal("subscribed", suite.receive(conn).Type)
+		suite.Equal(handlers.WebSocketResponse{Type: "reset", Account: "100", Seq: suite.app.Broker.Epoch() + "-1"}, suite.receive(conn))
+	})
+
+	suite.Run("Should limit the subscriptions of a connection", func() {
+		suite.start(support.NewTestAppWithWebSocket(handlers.WebSocketConfig{MaxSubscriptions: 1}))
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "s1", Type: "subscribe", Account: "100"})
+		suite.send(conn, handlers.WebSocketRequest{ID: "s2", Type: "subscribe", Account: "100"})
+		suite.send(conn, handlers.WebSocketRequest{ID: "s3", Type: "subscribe", Account: "200"})
+
+		suite.Equal("subscribed", suite.receive(conn).Type)
+		suite.Equal("subscribed", suite.receive(conn).Type)
+		response := suite.receive(conn)
+		suite.Equal("s3", response.ID)
+		suite.Equal("too_many_subscriptions", response.Error.Code)
+	})
+}
+
+func (suite *TestWebSocketSuite) Test_Limits() {
+	suite.Run("Should close connections sending oversized messages", func() {
+		suite.start(support.NewTestAppWithWebSocket(handlers.WebSocketConfig{MaxMessageSize: 64}))
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.send(conn, handlers.WebSocketRequest{ID: strings.Repeat("x", 100), Type: "subscribe"})
+
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		_, _, err := conn.ReadMessage()
+		suite.True(websocket.IsCloseError(err, websocket.CloseMessageTooBig, websocket.CloseGoingAway), err)
+	})
+
+	suite.Run("Should close the connections on shutdown", func() {
+		conn := suite.dial(nil)
+		defer conn.Close()
+
+		suite.app.WebSocket.Close()
+
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		_, _, err := conn.ReadMessage()
+		suite.True(websocket.IsCloseError(err, websocket.CloseGoingAway), err)
+	})
+}
+
+func (suite *TestWebSocketSuite) Test_Authorization() {
+	suite.Run("Should refuse connections without credentials", func() {
+		suite.start(support.NewTestAppWithAuth())
+		url := "ws" + strings.TrimPrefix(suite.server.URL, "http") + "/ws"
+
+		_, res, err := websocket.DefaultDialer.Dial(url, nil)
+
+		suite.Error(err)
+		suite.Equal(http.StatusUnauthorized, res.StatusCode)
+	})
+
+	suite.Run("Should check the scopes and accounts of the key on every message", func() {
+		suite.start(support.NewTestAppWithAuth())
+		key := suite.app.CreateAPIKey([]entity.Scope{entity.ScopeBalanceRead}, "100")
+		conn := suite.dial(http.Header{"X-Api-Key": {key}})
+		defer conn.Close()
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "c1", Type: "event", Event: &handlers.HandleEventRequest{
+			Type:        "deposit",
+			Destination: "100",
+			Amount:      10,
+		}})
+		suite.Equal("insufficient_scope", suite.receive(conn).Error.Code)
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "s1", Type: "subscribe", Account: "200"})
+		suite.Equal("account_forbidden", suite.receive(conn).Error.Code)
+
+		suite.send(conn, handlers.WebSocketRequest{ID: "s2", Type: "subscribe", Account: "100"})
+		suite.Equal("subscribed", suite.receive(conn).Type)
+	})
+}
+
+func TestWebSocket(t *testing.T) {
+	suite.Run(t, new(TestWebSocketSuite))
+}
@@ -2,50 +2,270 @@ package support
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/domain/event"
+	"simple-bank/internal/infrastructure/audit"
+	appGrpc "simple-bank/internal/infrastructure/grpc"
+	"simple-bank/internal/infrastructure/health"
 	appHttp "simple-bank/internal/infrastructure/http"
+	"simple-bank/internal/infrastructure/http/auth"
 	handlers "simple-bank/internal/infrastructure/http/handler"
+	handlersV2 "simple-bank/internal/infrastructure/http/handler/v2"
+	"simple-bank/internal/infrastructure/http/problem"
+	"simple-bank/internal/infrastructure/metrics"
+	"simple-bank/internal/infrastructure/outbox"
 	"simple-bank/internal/infrastructure/repository/inmemory"
+	"simple-bank/internal/infrastructure/stream"
+	"simple-bank/internal/infrastructure/telemetry"
+	"simple-bank/internal/infrastructure/webhook"
+	"simple-bank/internal/shared/egress"
 	"simple-bank/internal/usecase/account"
+	"simple-bank/internal/usecase/apikey"
+	customerUseCase "simple-bank/internal/usecase/customer"
+	productUseCase "simple-bank/internal/usecase/product"
+	webhookUseCase "simple-bank/internal/usecase/webhook"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
+// TestResetToken confirms resets, send it in the X-Reset-Confirmation header.
+const TestResetToken = "test-reset-token"
+
+// AuditKey is the key the audit log of test apps is chained with.
+var AuditKey = []byte("test-audit-key")
+
 type TestApp struct {
-	AccountRepository *inmemory.AccountRepository
-	HTTPServer        *appHttp.HTTPServer
+	AccountRepository  *inmemory.AccountRepository
+	CustomerRepository *inmemory.CustomerRepository
+	ProductRepository  *inmemory.ProductRepository
+	APIKeyRepository   *inmemory.APIKeyRepository
+	WebhookRepository  *inmemory.WebhookRepository
+	Problems           *ProblemCounter
+	Audit              *audit.MemoryStore
+	Metrics            *metrics.Metrics
+	Relay              *outbox.Relay
+	Dispatcher         *webhook.Dispatcher
+	Bus                *event.Bus
+	Broker             *stream.Broker
+	WebSocket          *handlers.WebSocketHandler
+	HTTPServer         *appHttp.HTTPServer
+	GRPCServer         *appGrpc.GRPCServer
 }
 
 func NewTestApp() *TestApp {
+	return NewTestAppWithConfig(appHttp.HTTPServerConfig{Port: "3000"})
+}
+
+func NewTestAppWithConfig(config appHttp.HTTPServerConfig) *TestApp {
+	return newTestApp(config, testAppOptions{})
+}
+
+// NewTestAppWithAuth builds an app requiring credentials, create API keys with
+// TestApp.CreateAPIKey and bearer tokens with MintToken.
+func NewTestAppWithAuth() *TestApp {
+	return newTestApp(appHttp.HTTPServerConfig{Port: "3000"}, testAppOptions{withAuth: true})
+}
+
+// NewTestAppWithAuthConfig builds an app requiring credentials and served
+// with config.
+func NewTestAppWithAuthConfig(config appHttp.HTTPServerConfig) *TestApp {
+	return newTestApp(config, testAppOptions{withAuth: true})
+}
+
+// NewTestAppWithWebSocket builds an app whose websocket connections are
+// bounded by config.
+func NewTestAppWithWebSocket(config handlers.WebSocketConfig) *TestApp {
+	return newTestApp(appHttp.HTTPServerConfig{Port: "3000"}, testAppOptions{webSocket: config})
+}
+
+type testAppOptions struct {
+	withAuth  bool
+	webSocket handlers.WebSocketConfig
+}
+
+func newTestApp(config appHttp.HTTPServerConfig, options testAppOptions) *TestApp {
+	problems := NewProblemCounter()
+	auditLogger := audit.NewMemoryStore(AuditKey)
+	appMetrics := metrics.New()
+	config.Metrics = appMetrics
+	config.ErrorRecorder = problemRecorders{problems, appMetrics}
+
 	accountRepository := inmemory.NewAccountRepository()
+	customerRepository := inmemory.NewCustomerRepository()
+	productRepository := inmemory.NewProductRepository("checking", Products()...)
+	apiKeyRepository := inmemory.NewAPIKeyRepository()
+	webhookRepository := inmemory.NewWebhookRepository()
+
+	// Nothing runs in the background, tests call Relay.Flush and
+	// Dispatcher.DeliverDue when they expect deliveries. Webhooks may target
+	// the loopback servers of the tests.
+	webhookTargets := egress.Policy{AllowPrivate: true}
+	dispatcher := webhook.NewDispatcher(webhookRepository, webhook.Config{
+		Targets: webhookTargets,
+		Retry:   webhook.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+	})
+	relay := outbox.NewRelay(accountRepository).WithSink("webhooks", dispatcher)
+
+	bus := event.NewBus()
+	broker := stream.NewBroker(stream.DefaultBufferSize)
+	bus.Subscribe("stream", event.Sync, broker.Handle)
+	bus.Subscribe("metrics", event.Async, appMetrics.HandleEvent)
+
+	appMetrics.WatchAccounts(accountRepository)
+	if config.Readiness == nil {
+		config.Readiness = health.NewReadiness(0, health.RepositoryCheck("account_repository", accountRepository))
+	}
+	accounts := metrics.NewAccountRepository(telemetry.NewAccountRepository(accountRepository), appMetrics)
+	customers := metrics.NewCustomerRepository(customerRepository, appMetrics)
+	products := metrics.NewProductRepository(productRepository, appMetrics)
+
+	getBalanceUseCase := account.NewGetBalanceUseCase(accounts)
+	getAccountUseCase := account.NewGetAccountUseCase(accounts)
+	resetUseCase := account.NewResetUseCase(accounts)
+	depositUseCase := account.NewDepositUseCase(accounts, products).WithRecorder(appMetrics).WithPublisher(bus)
+	withdrawUseCase := account.NewWithdrawUseCase(accounts, products).WithRecorder(appMetrics).WithPublisher(bus)
+	transferUseCase := account.NewTransferUseCase(accounts, customers, products).
+		WithRecorder(appMetrics).
+		WithPublisher(bus)
+	listAccountsUseCase := account.NewListAccountsUseCase(accounts)
 
-	getBalanceUseCase := account.NewGetBalanceUseCase(accountRepository)
-	resetUseCase := account.NewResetUseCase(accountRepository)
-	depositUseCase := account.NewDepositUseCase(accountRepository)
-	withdrawUseCase := account.NewWithdrawUseCase(accountRepository)
-	transferUseCase := account.NewTransferUseCase(accountRepository)
+	createCustomerUseCase := customerUseCase.NewCreateCustomerUseCase(customers)
+	getCustomerUseCase := customerUseCase.NewGetCustomerUseCase(customers)
+	updateCustomerUseCase := customerUseCase.NewUpdateCustomerUseCase(customers)
+	deleteCustomerUseCase := customerUseCase.NewDeleteCustomerUseCase(customers)
+	listCustomerAccountsUseCase := customerUseCase.NewListCustomerAccountsUseCase(customers, accounts)
+	linkAccountUseCase := customerUseCase.NewLinkAccountUseCase(customers, accounts)
+	unlinkAccountUseCase := customerUseCase.NewUnlinkAccountUseCase(customers)
+	setKYCStatusUseCase := customerUseCase.NewSetKYCStatusUseCase(customers)
+
+	listProductsUseCase := productUseCase.NewListProductsUseCase(products)
+
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(apiKeyRepository)
+	rotateAPIKeyUseCase := apikey.NewRotateAPIKeyUseCase(apiKeyRepository)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(apiKeyRepository)
+	createWebhookUseCase := webhookUseCase.NewCreateWebhookUseCase(webhookRepository).WithTargetPolicy(webhookTargets)
+	listWebhooksUseCase := webhookUseCase.NewListWebhooksUseCase(webhookRepository)
+	deleteWebhookUseCase := webhookUseCase.NewDeleteWebhookUseCase(webhookRepository)
+	listDeliveriesUseCase := webhookUseCase.NewListDeliveriesUseCase(webhookRepository)
+	redeliverUseCase := webhookUseCase.NewRedeliverUseCase(webhookRepository)
+	if options.withAuth {
+		authenticateAPIKeyUseCase := apikey.NewAuthenticateAPIKeyUseCase(apiKeyRepository)
+		config.Authenticators = append(config.Authenticators,
+			auth.NewAPIKeyAuthenticator(authenticateAPIKeyUseCase),
+			NewTestJWTAuthenticator(),
+		)
+	}
 
 	balanceHandler := handlers.NewBalanceHandler(getBalanceUseCase)
-	resetHandler := handlers.NewResetHandler(resetUseCase)
+	resetHandler := handlers.NewResetHandler(resetUseCase, handlers.ResetConfig{
+		ConfirmationToken: TestResetToken,
+		Audit:             auditLogger,
+	})
+	accountHandler := handlers.NewAccountHandler(listAccountsUseCase)
+	productHandler := handlers.NewProductHandler(listProductsUseCase)
+	customerHandler := handlers.NewCustomerHandler(
+		createCustomerUseCase,
+		getCustomerUseCase,
+		updateCustomerUseCase,
+		deleteCustomerUseCase,
+		listCustomerAccountsUseCase,
+		linkAccountUseCase,
+		unlinkAccountUseCase,
+		setKYCStatusUseCase,
+	)
 	eventHandler := handlers.NewEventHandler(
 		depositUseCase,
 		withdrawUseCase,
 		transferUseCase,
-	)
+	).WithAudit(auditLogger)
+	accountV2Handler := handlersV2.NewAccountHandler(getAccountUseCase, depositUseCase, withdrawUseCase).
+		WithAudit(auditLogger)
+	transferV2Handler := handlersV2.NewTransferHandler(transferUseCase).WithAudit(auditLogger)
+	docsHandler := handlers.NewDocsHandler()
+	apiKeyHandler := handlers.NewAPIKeyHandler(createAPIKeyUseCase, rotateAPIKeyUseCase, revokeAPIKeyUseCase).
+		WithAudit(auditLogger)
+	webhookHandler := handlers.NewWebhookHandler(
+		createWebhookUseCase,
+		listWebhooksUseCase,
+		deleteWebhookUseCase,
+		listDeliveriesUseCase,
+		redeliverUseCase,
+	).WithAudit(auditLogger)
+	streamHandler := handlers.NewStreamHandler(broker)
+	options.webSocket.StrictValidation = config.StrictValidation
+	options.webSocket.RateLimit = config.RateLimit
+	webSocketHandler := handlers.NewWebSocketHandler(eventHandler, broker, options.webSocket)
 
 	httpServer := appHttp.NewHTTPServer(
-		"3000",
+		config,
 		balanceHandler,
 		resetHandler,
 		eventHandler,
+		accountHandler,
+		customerHandler,
+		productHandler,
+		accountV2Handler,
+		transferV2Handler,
+		docsHandler,
+		apiKeyHandler,
+		webhookHandler,
+		streamHandler,
+		webSocketHandler,
 	)
 
+	accountService := appGrpc.NewAccountService(
+		getBalanceUseCase,
+		depositUseCase,
+		withdrawUseCase,
+		transferUseCase,
+		broker,
+	).WithReset(resetUseCase, TestResetToken).WithAudit(auditLogger)
+	grpcServer := appGrpc.NewGRPCServer(appGrpc.GRPCServerConfig{
+		Authenticators: config.Authenticators,
+		RateLimit:      config.RateLimit,
+	}, accountService)
+
 	return &TestApp{
-		AccountRepository: accountRepository,
-		HTTPServer:        httpServer,
+		AccountRepository:  accountRepository,
+		CustomerRepository: customerRepository,
+		ProductRepository:  productRepository,
+		APIKeyRepository:   apiKeyRepository,
+		WebhookRepository:  webhookRepository,
+		Problems:           problems,
+		Audit:              auditLogger,
+		Metrics:            appMetrics,
+		Relay:              relay,
+		Dispatcher:         dispatcher,
+		Bus:                bus,
+		Broker:             broker,
+		WebSocket:          webSocketHandler,
+		HTTPServer:         httpServer,
+		GRPCServer:         grpcServer,
+	}
+}
+
+// CreateAPIKey stores a key with the given scopes, restricted to accountIDs
+// when any, and returns its secret.
+func (a *TestApp) CreateAPIKey(scopes []entity.Scope, accountIDs ...string) string {
+	return a.CreateCustomerAPIKey("", scopes, accountIDs...)
+}
+
+// CreateCustomerAPIKey stores a key acting on behalf of customerID and
+// returns its secret.
+func (a *TestApp) CreateCustomerAPIKey(customerID string, scopes []entity.Scope, accountIDs ...string) string {
+	secret := fmt.Sprintf("test-key-%d", len(a.APIKeyRepository.APIKeys))
+	key := entity.NewAPIKey(secret, secret, apikey.HashSecret(secret), scopes, accountIDs, time.Now())
+	key.CustomerID = customerID
+	if err := a.APIKeyRepository.SaveAPIKey(context.Background(), key); err != nil {
+		panic(err)
 	}
+	return secret
 }
 
 func (a *TestApp) PerformRequest(res *httptest.ResponseRecorder, req *http.Request) {
@@ -59,3 +279,17 @@ func (a *TestApp) NewJSONRequest(method, path string, body any) *http.Request {
 
 	return req
 }
+
+func (a *TestApp) AuditEntries() []audit.Entry {
+	entries, _ := a.Audit.Entries(context.Background())
+	return entries
+}
+
+// problemRecorders hands every problem to each recorder.
+type problemRecorders []problem.Recorder
+
+func (r problemRecorders) RecordProblem(class problem.Class, code string) {
+	for _, recorder := range r {
+		recorder.RecordProblem(class, code)
+	}
+}
@@ -0,0 +1,32 @@
+package support
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ServeGRPC serves the gRPC server of the app in memory and returns a
+// connection to it. close stops the server and closes the connection.
+func (a *TestApp) ServeGRPC() (conn *grpc.ClientConn, close func()) {
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = a.GRPCServer.Serve(listener) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return conn, func() {
+		_ = conn.Close()
+		a.GRPCServer.Server.Stop()
+	}
+}
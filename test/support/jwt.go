@@ -0,0 +1,101 @@
+package support
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"simple-bank/internal/domain/entity"
+	"simple-bank/internal/infrastructure/http/auth"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	TestJWTSecret = "test-jwt-secret"
+	TestJWTKeyID  = "test-rsa"
+)
+
+var (
+	testRSAKey     *rsa.PrivateKey
+	testRSAKeyOnce sync.Once
+)
+
+func testRSAPrivateKey() *rsa.PrivateKey {
+	testRSAKeyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(err)
+		}
+		testRSAKey = key
+	})
+	return testRSAKey
+}
+
+// TestJWKS returns a JWKS document with the public key RS256 test tokens are
+// signed with.
+func TestJWKS() []byte {
+	publicKey := testRSAPrivateKey().PublicKey
+	document, _ := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": TestJWTKeyID,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+	return document
+}
+
+// NewTestJWTAuthenticator accepts tokens minted by MintToken and MintRS256Token.
+func NewTestJWTAuthenticator() *auth.JWTAuthenticator {
+	keys, err := auth.ParseJWKS(TestJWKS())
+	if err != nil {
+		panic(err)
+	}
+	keys.AddHMAC("", []byte(TestJWTSecret))
+
+	return auth.NewJWTAuthenticator(keys, auth.JWTOptions{})
+}
+
+func testClaims(subject string, scopes []entity.Scope, accounts []string) auth.JWTClaims {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+
+	return auth.JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Scope:    strings.Join(names, " "),
+		Accounts: accounts,
+	}
+}
+
+// MintToken returns an HS256 bearer token allowed to operate on accounts.
+func MintToken(subject string, scopes []entity.Scope, accounts ...string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims(subject, scopes, accounts))
+	signed, err := token.SignedString([]byte(TestJWTSecret))
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// MintRS256Token returns an RS256 bearer token signed with the key of TestJWKS.
+func MintRS256Token(subject string, scopes []entity.Scope, accounts ...string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, testClaims(subject, scopes, accounts))
+	token.Header["kid"] = TestJWTKeyID
+	signed, err := token.SignedString(testRSAPrivateKey())
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
@@ -0,0 +1,37 @@
+package support
+
+import (
+	"simple-bank/internal/infrastructure/http/problem"
+	"sync"
+)
+
+// ProblemCounter counts the problems answered by a test app.
+type ProblemCounter struct {
+	mu     sync.Mutex
+	counts map[problem.Class]map[string]int
+}
+
+func NewProblemCounter() *ProblemCounter {
+	return &ProblemCounter{counts: make(map[problem.Class]map[string]int)}
+}
+
+func (c *ProblemCounter) RecordProblem(class problem.Class, code string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts[class] == nil {
+		c.counts[class] = make(map[string]int)
+	}
+	c.counts[class][code]++
+}
+
+func (c *ProblemCounter) Count(class problem.Class) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	total := 0
+	for _, count := range c.counts[class] {
+		total += count
+	}
+	return total
+}
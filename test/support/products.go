@@ -0,0 +1,17 @@
+package support
+
+import "simple-bank/internal/domain/entity"
+
+func Products() []*entity.Product {
+	checking := entity.NewProduct("checking", "Checking")
+
+	savings := entity.NewProduct("savings", "Savings")
+	savings.MonthlyWithdrawalLimit = 2
+	savings.Fees = entity.FeeSchedule{Withdrawal: 2, Transfer: 2}
+
+	business := entity.NewProduct("business", "Business")
+	business.OverdraftLimit = 500
+	business.Fees = entity.FeeSchedule{Deposit: 1, Withdrawal: 1, Transfer: 3}
+
+	return []*entity.Product{checking, savings, business}
+}